@@ -10,18 +10,26 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"go.dedis.ch/d-voting/contracts/evoting"
+	etypes "go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/output"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/cli/node"
 	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/ordering/cosipbft/contracts/viewchange"
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/dela/core/txn/pool"
 	"go.dedis.ch/dela/cosi"
+	"go.dedis.ch/dela/cosi/threshold"
 	"go.dedis.ch/dela/crypto"
 	"go.dedis.ch/dela/mino"
+	"go.dedis.ch/dela/serde/json"
 	"golang.org/x/xerrors"
 )
 
@@ -127,6 +135,159 @@ func (a exportAction) Execute(ctx node.Context) error {
 	return nil
 }
 
+// RosterMember is a member of the roster as printed by the roster show
+// command.
+type RosterMember struct {
+	Index   int
+	Address string
+	// Member is the base64 description of the member, as printed by the
+	// export command
+	Member string
+	Leader bool
+}
+
+// RosterShowAction is an action to print the members of the chain.
+//
+// - implements node.ActionTemplate
+type rosterShowAction struct{}
+
+// Execute implements node.ActionTemplate. It prints the members of the current
+// roster, and which one is the leader of the current view.
+func (rosterShowAction) Execute(ctx node.Context) error {
+	var srvc Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	roster, err := srvc.GetRoster()
+	if err != nil {
+		return xerrors.Errorf("failed to read roster: %v", err)
+	}
+
+	leader, hasLeader := leaderIndex()
+
+	members := make([]RosterMember, 0, roster.Len())
+	table := output.Table{Header: []string{"INDEX", "ADDRESS", "MEMBER", "LEADER"}}
+
+	addrs := roster.AddressIterator()
+	pubkeys := roster.PublicKeyIterator()
+
+	for i := 0; addrs.HasNext() && pubkeys.HasNext(); i++ {
+		addr := addrs.GetNext()
+
+		desc, err := encodeMember(addr, pubkeys.GetNext())
+		if err != nil {
+			return xerrors.Errorf("failed to encode member %d: %v", i, err)
+		}
+
+		member := RosterMember{
+			Index:   i,
+			Address: addr.String(),
+			Member:  desc,
+			Leader:  hasLeader && i == leader,
+		}
+
+		members = append(members, member)
+		table.Rows = append(table.Rows, []string{strconv.Itoa(i), member.Address,
+			member.Member, strconv.FormatBool(member.Leader)})
+	}
+
+	return output.Print(ctx.Out, ctx.Flags.Bool("json"), members, table)
+}
+
+// ChainStatus is the status of the chain as seen by the node.
+type ChainStatus struct {
+	// Height is the number of blocks
+	Height        uint64
+	LastBlockHash string `json:",omitempty"`
+	// LastBlockTime is the time at which the node received the last block. It
+	// is unknown until a block is received after the node started.
+	LastBlockTime *time.Time `json:",omitempty"`
+	// LeaderIndex is the index in the roster of the current leader. It is -1
+	// if unknown.
+	LeaderIndex int
+	Leader      string `json:",omitempty"`
+	RosterSize  int
+}
+
+// StatusAction is an action to print the status of the chain.
+//
+// - implements node.ActionTemplate
+type statusAction struct{}
+
+// Execute implements node.ActionTemplate. It prints the height of the chain,
+// its last block, and the current leader.
+func (statusAction) Execute(ctx node.Context) error {
+	var srvc Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	var blocks blockstore.BlockStore
+	err = ctx.Injector.Resolve(&blocks)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	var tracker *blockTracker
+	err = ctx.Injector.Resolve(&tracker)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	roster, err := srvc.GetRoster()
+	if err != nil {
+		return xerrors.Errorf("failed to read roster: %v", err)
+	}
+
+	status := ChainStatus{
+		Height:      blocks.Len(),
+		LeaderIndex: -1,
+		RosterSize:  roster.Len(),
+	}
+
+	if status.Height > 0 {
+		link, err := blocks.Last()
+		if err != nil {
+			return xerrors.Errorf("failed to get last block: %v", err)
+		}
+
+		status.LastBlockHash = link.GetBlock().GetHash().String()
+	}
+
+	last := tracker.lastBlockTime()
+	if !last.IsZero() {
+		status.LastBlockTime = &last
+	}
+
+	leader, found := leaderIndex()
+	if found && leader < roster.Len() {
+		status.LeaderIndex = leader
+
+		iter := roster.AddressIterator()
+		iter.Seek(leader)
+		status.Leader = iter.GetNext().String()
+	}
+
+	lastBlockTime := "unknown"
+	if status.LastBlockTime != nil {
+		lastBlockTime = status.LastBlockTime.Format(time.RFC3339)
+	}
+
+	table := output.Table{Rows: [][]string{
+		{"Height:", strconv.FormatUint(status.Height, 10)},
+		{"Last block hash:", status.LastBlockHash},
+		{"Last block time:", lastBlockTime},
+		{"Leader index:", strconv.Itoa(status.LeaderIndex)},
+		{"Leader:", status.Leader},
+		{"Roster size:", strconv.Itoa(status.RosterSize)},
+	}}
+
+	return output.Print(ctx.Out, ctx.Flags.Bool("json"), status, table)
+}
+
 // RosterAddAction is an action to require a roster change in the change by
 // adding a new member.
 //
@@ -136,13 +297,94 @@ type rosterAddAction struct{}
 // Execute implements node.ActionTemplate. It reads the new member and send a
 // transaction to require a roster change.
 func (rosterAddAction) Execute(ctx node.Context) error {
+	return changeRoster(ctx, func(roster authority.Authority) (*authority.RosterChangeSet, error) {
+		addr, pubkey, err := decodeMember(ctx, ctx.Flags.String("member"))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decode member: %v", err)
+		}
+
+		cset := authority.NewChangeSet()
+		cset.Add(addr, pubkey)
+
+		return cset, nil
+	})
+}
+
+// RosterRemoveAction is an action to require a roster change in the chain by
+// removing a member.
+//
+// - implements node.ActionTemplate
+type rosterRemoveAction struct{}
+
+// Execute implements node.ActionTemplate. It reads the member to remove and
+// send a transaction to require a roster change, unless the removal would
+// prevent an ongoing form from being completed.
+func (rosterRemoveAction) Execute(ctx node.Context) error {
+	return changeRoster(ctx, func(roster authority.Authority) (*authority.RosterChangeSet, error) {
+		index, err := findMember(ctx, roster, ctx.Flags.String("member"))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to find member: %v", err)
+		}
+
+		cset := authority.NewChangeSet()
+		cset.Remove(index)
+
+		err = checkFormRosters(ctx, roster.Apply(cset))
+		if err != nil {
+			return nil, xerrors.Errorf("unsafe removal: %v", err)
+		}
+
+		return cset, nil
+	})
+}
+
+// RosterReplaceAction is an action to require a roster change in the chain by
+// replacing a member with a new one.
+//
+// - implements node.ActionTemplate
+type rosterReplaceAction struct{}
+
+// Execute implements node.ActionTemplate. It reads the old and the new member
+// and send a transaction to require a roster change, unless the removal of the
+// old member would prevent an ongoing form from being completed.
+func (rosterReplaceAction) Execute(ctx node.Context) error {
+	return changeRoster(ctx, func(roster authority.Authority) (*authority.RosterChangeSet, error) {
+		index, err := findMember(ctx, roster, ctx.Flags.String("old"))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to find old member: %v", err)
+		}
+
+		addr, pubkey, err := decodeMember(ctx, ctx.Flags.String("new"))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decode new member: %v", err)
+		}
+
+		cset := authority.NewChangeSet()
+		cset.Remove(index)
+		cset.Add(addr, pubkey)
+
+		err = checkFormRosters(ctx, roster.Apply(cset))
+		if err != nil {
+			return nil, xerrors.Errorf("unsafe replacement: %v", err)
+		}
+
+		return cset, nil
+	})
+}
+
+// changeRoster sends a transaction to apply the change set returned by makeSet
+// to the current roster. It waits for the transaction to be included if the
+// wait flag is set.
+func changeRoster(ctx node.Context,
+	makeSet func(authority.Authority) (*authority.RosterChangeSet, error)) error {
+
 	var srvc Service
 	err := ctx.Injector.Resolve(&srvc)
 	if err != nil {
 		return xerrors.Errorf(errInjector, err)
 	}
 
-	tx, err := prepareRosterTx(ctx, srvc)
+	tx, err := prepareRosterTx(ctx, srvc, makeSet)
 	if err != nil {
 		return xerrors.Errorf("while preparing tx: %v", err)
 	}
@@ -197,20 +439,19 @@ func (rosterAddAction) Execute(ctx node.Context) error {
 	return nil
 }
 
-func prepareRosterTx(ctx node.Context, srvc Service) (txn.Transaction, error) {
+func prepareRosterTx(ctx node.Context, srvc Service,
+	makeSet func(authority.Authority) (*authority.RosterChangeSet, error)) (txn.Transaction, error) {
+
 	roster, err := srvc.GetRoster()
 	if err != nil {
 		return nil, xerrors.Errorf("failed to read roster: %v", err)
 	}
 
-	addr, pubkey, err := decodeMember(ctx, ctx.Flags.String("member"))
+	cset, err := makeSet(roster)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to decode member: %v", err)
+		return nil, err
 	}
 
-	cset := authority.NewChangeSet()
-	cset.Add(addr, pubkey)
-
 	mgr, err := makeManager(ctx)
 	if err != nil {
 		return nil, xerrors.Errorf("txn manager: %v", err)
@@ -224,6 +465,86 @@ func prepareRosterTx(ctx node.Context, srvc Service) (txn.Transaction, error) {
 	return tx, nil
 }
 
+// findMember returns the index of a base64 encoded member in the roster.
+func findMember(ctx node.Context, roster authority.Authority, member string) (uint, error) {
+	addr, _, err := decodeMember(ctx, member)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to decode member: %v", err)
+	}
+
+	_, index := roster.GetPublicKey(addr)
+	if index < 0 {
+		return 0, xerrors.Errorf("%s is not in the roster", addr)
+	}
+
+	return uint(index), nil
+}
+
+// checkFormRosters returns an error if a form that is not done yet would have
+// less members of its roster in the new roster than its Byzantine threshold.
+// Such a form couldn't be shuffled or decrypted anymore. The check is skipped
+// if the force flag is set.
+func checkFormRosters(ctx node.Context, roster authority.Authority) error {
+	if ctx.Flags.Bool("force") {
+		return nil
+	}
+
+	var srvc Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	var rosterFac authority.Factory
+	err = ctx.Injector.Resolve(&rosterFac)
+	if err != nil {
+		return xerrors.Errorf(errInjector, err)
+	}
+
+	formFac := etypes.NewFormFactory(etypes.CiphervoteFactory{}, rosterFac)
+	serdeCtx := json.NewContext()
+	store := srvc.GetStore()
+
+	var formErr error
+
+	err = evoting.IterateForms(store, func(formID string) bool {
+		form, err := etypes.FormFromStore(serdeCtx, formFac, formID, store)
+		if err != nil {
+			formErr = xerrors.Errorf("failed to get form %s: %v", formID, err)
+			return false
+		}
+
+		if form.Status == etypes.ResultAvailable || form.Status == etypes.Canceled ||
+			form.Roster == nil {
+			return true
+		}
+
+		left := 0
+
+		iter := form.Roster.AddressIterator()
+		for iter.HasNext() {
+			_, index := roster.GetPublicKey(iter.GetNext())
+			if index >= 0 {
+				left++
+			}
+		}
+
+		required := threshold.ByzantineThreshold(form.Roster.Len())
+		if left < required {
+			formErr = xerrors.Errorf("form %s would have %d members of its roster "+
+				"left, but requires %d", formID, left, required)
+			return false
+		}
+
+		return true
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to iterate forms: %v", err)
+	}
+
+	return formErr
+}
+
 func makeManager(ctx node.Context) (txn.Manager, error) {
 	var mgr txn.Manager
 	err := ctx.Injector.Resolve(&mgr)
@@ -241,6 +562,23 @@ func makeManager(ctx node.Context) (txn.Manager, error) {
 	return mgr, nil
 }
 
+// encodeMember returns the base64 description of a member, which is
+// "$ADDR_BASE64:$PUBLIC_KEY_BASE64".
+func encodeMember(addr mino.Address, pubkey crypto.PublicKey) (string, error) {
+	addrBuf, err := addr.MarshalText()
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal address: %v", err)
+	}
+
+	pubkeyBuf, err := pubkey.MarshalBinary()
+	if err != nil {
+		return "", xerrors.Errorf("failed to marshal public key: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(addrBuf) + separator +
+		base64.StdEncoding.EncodeToString(pubkeyBuf), nil
+}
+
 func decodeMember(ctx node.Context, str string) (mino.Address, crypto.PublicKey, error) {
 	parts := strings.Split(str, separator)
 	if len(parts) != 2 {
@@ -3,23 +3,33 @@ package controller
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	stdjson "encoding/json"
 	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	etypes "go.dedis.ch/d-voting/contracts/evoting/types"
 	"go.dedis.ch/d-voting/internal/testing/fake"
 	"go.dedis.ch/dela/cli/node"
 	"go.dedis.ch/dela/core/access"
 	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
+	ctypes "go.dedis.ch/dela/core/ordering/cosipbft/types"
+	"go.dedis.ch/dela/core/store"
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/dela/core/txn/pool"
 	"go.dedis.ch/dela/core/txn/pool/mem"
 	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/core/validation/simple"
 	"go.dedis.ch/dela/cosi"
 	"go.dedis.ch/dela/crypto"
 	"go.dedis.ch/dela/mino"
+	"go.dedis.ch/dela/serde/json"
 )
 
 func TestSetupAction_Execute(t *testing.T) {
@@ -77,6 +87,116 @@ func TestExportAction_Execute(t *testing.T) {
 	require.EqualError(t, err, fake.Err("failed to marshal public key"))
 }
 
+func TestRosterShowAction_Execute(t *testing.T) {
+	action := rosterShowAction{}
+
+	ctx := prepContext(nil)
+	ctx.Injector.Inject(fakeService{roster: newRoster(2)})
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err := action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "INDEX  ADDRESS          MEMBER         LEADER\n"+
+		"0      fake.Address[0]  AAAAAA==:UEs=  true\n"+
+		"1      fake.Address[1]  AQAAAA==:UEs=  false\n", buffer.String())
+
+	buffer.Reset()
+	ctx.Flags.(node.FlagSet)["json"] = true
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	var members []RosterMember
+	require.NoError(t, stdjson.Unmarshal(buffer.Bytes(), &members))
+	require.Equal(t, []RosterMember{
+		{Index: 0, Address: "fake.Address[0]", Member: "AAAAAA==:UEs=", Leader: true},
+		{Index: 1, Address: "fake.Address[1]", Member: "AQAAAA==:UEs=", Leader: false},
+	}, members)
+
+	ctx.Injector.Inject(fakeService{roster: authority.New(
+		[]mino.Address{fake.NewBadAddress()}, []crypto.PublicKey{fake.PublicKey{}})})
+	err = action.Execute(ctx)
+	require.EqualError(t, err, fake.Err("failed to encode member 0: failed to marshal address"))
+
+	ctx.Injector.Inject(fakeService{err: fake.GetError()})
+	err = action.Execute(ctx)
+	require.EqualError(t, err, fake.Err("failed to read roster"))
+
+	ctx.Injector = node.NewInjector()
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "injector: couldn't find dependency for 'controller.Service'")
+}
+
+func TestStatusAction_Execute(t *testing.T) {
+	action := statusAction{}
+
+	ctx := prepContext(nil)
+	ctx.Injector.Inject(fakeService{roster: newRoster(3)})
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err := action.Execute(ctx)
+	require.EqualError(t, err, "injector: couldn't find dependency for 'blockstore.BlockStore'")
+
+	blocks := blockstore.NewInMemory()
+	ctx.Injector.Inject(blocks)
+
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "injector: couldn't find dependency for '*controller.blockTracker'")
+
+	tracker := newBlockTracker()
+	ctx.Injector.Inject(tracker)
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Height:           0\n"+
+		"Last block hash:  \n"+
+		"Last block time:  unknown\n"+
+		"Leader index:     0\n"+
+		"Leader:           fake.Address[0]\n"+
+		"Roster size:      3\n", buffer.String())
+
+	block, err := ctypes.NewBlock(simple.NewResult(nil))
+	require.NoError(t, err)
+
+	link, err := ctypes.NewBlockLink(ctypes.Digest{}, block)
+	require.NoError(t, err)
+
+	require.NoError(t, blocks.Store(link))
+
+	now := time.Unix(1690000000, 0).UTC()
+	tracker.now = func() time.Time { return now }
+	tracker.listen(context.Background(), fakeService{events: okEvents()})
+
+	buffer.Reset()
+	ctx.Flags.(node.FlagSet)["json"] = true
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	var status ChainStatus
+	require.NoError(t, stdjson.Unmarshal(buffer.Bytes(), &status))
+	require.Equal(t, ChainStatus{
+		Height:        1,
+		LastBlockHash: block.GetHash().String(),
+		LastBlockTime: &now,
+		LeaderIndex:   0,
+		Leader:        "fake.Address[0]",
+		RosterSize:    3,
+	}, status)
+
+	ctx.Injector.Inject(fakeService{err: fake.GetError()})
+	err = action.Execute(ctx)
+	require.EqualError(t, err, fake.Err("failed to read roster"))
+
+	ctx.Injector = node.NewInjector()
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "injector: couldn't find dependency for 'controller.Service'")
+}
+
 func TestRosterAddAction_Execute(t *testing.T) {
 	action := rosterAddAction{}
 
@@ -145,6 +265,101 @@ func TestRosterAddAction_Execute(t *testing.T) {
 	require.EqualError(t, err, "transaction not found after timeout")
 }
 
+func TestRosterRemoveAction_Execute(t *testing.T) {
+	action := rosterRemoveAction{}
+
+	ctx := prepContext(nil)
+	ctx.Injector.Inject(fakeService{roster: newRoster(4), events: okEvents()})
+	ctx.Flags.(node.FlagSet)["member"] = member(3)
+	ctx.Flags.(node.FlagSet)["wait"] = float64(time.Second)
+
+	err := action.Execute(ctx)
+	require.NoError(t, err)
+
+	ctx.Flags.(node.FlagSet)["member"] = member(5)
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: failed to find member: "+
+		"fake.Address[5] is not in the roster")
+
+	ctx.Flags.(node.FlagSet)["member"] = "YQ=="
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: failed to find member: "+
+		"failed to decode member: invalid member base64 string")
+
+	// a form of 4 members can lose one of them, but not two
+	ctx.Injector.Inject(fakeService{roster: newRoster(3), events: okEvents(),
+		store: newFormStore(t, etypes.Open)})
+	ctx.Flags.(node.FlagSet)["member"] = member(2)
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: unsafe removal: form "+
+		formID+" would have 2 members of its roster left, but requires 3")
+
+	ctx.Flags.(node.FlagSet)["force"] = true
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	ctx.Flags.(node.FlagSet)["force"] = false
+	ctx.Injector.Inject(fakeService{roster: newRoster(3), events: okEvents(),
+		store: newFormStore(t, etypes.ResultAvailable)})
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+}
+
+func TestRosterReplaceAction_Execute(t *testing.T) {
+	action := rosterReplaceAction{}
+
+	ctx := prepContext(nil)
+	ctx.Injector.Inject(fakeService{roster: newRoster(4), events: okEvents(),
+		store: newFormStore(t, etypes.Closed)})
+	ctx.Flags.(node.FlagSet)["old"] = member(3)
+	ctx.Flags.(node.FlagSet)["new"] = member(4)
+	ctx.Flags.(node.FlagSet)["wait"] = float64(time.Second)
+
+	err := action.Execute(ctx)
+	require.NoError(t, err)
+
+	ctx.Flags.(node.FlagSet)["old"] = member(5)
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: failed to find old member: "+
+		"fake.Address[5] is not in the roster")
+
+	ctx.Flags.(node.FlagSet)["old"] = member(3)
+	ctx.Flags.(node.FlagSet)["new"] = "YQ=="
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: failed to decode new member: "+
+		"invalid member base64 string")
+
+	ctx.Injector.Inject(fakeService{roster: newRoster(3), events: okEvents(),
+		store: newFormStore(t, etypes.Closed)})
+	ctx.Flags.(node.FlagSet)["old"] = member(2)
+	ctx.Flags.(node.FlagSet)["new"] = member(4)
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "while preparing tx: unsafe replacement: form "+
+		formID+" would have 2 members of its roster left, but requires 3")
+}
+
+func TestCheckFormRosters(t *testing.T) {
+	ctx := prepContext(nil)
+
+	ctx.Injector.Inject(fakeService{store: fake.NewBadSnapshot()})
+	err := checkFormRosters(ctx, newRoster(4))
+	require.EqualError(t, err, fake.Err("failed to iterate forms: failed to check index: "+
+		"failed to get count"))
+
+	store := fake.NewSnapshot()
+	require.NoError(t, etypes.InitFormsIndex(store))
+	require.NoError(t, etypes.AddFormToIndex(store, formID, etypes.Open))
+
+	ctx.Injector.Inject(fakeService{store: store})
+	err = checkFormRosters(ctx, newRoster(4))
+	require.EqualError(t, err, "failed to get form "+formID+": no form found")
+
+	ctx.Injector = node.NewInjector()
+	ctx.Injector.Inject(fakeService{})
+	err = checkFormRosters(ctx, newRoster(4))
+	require.EqualError(t, err, "injector: couldn't find dependency for 'authority.Factory'")
+}
+
 func TestDecodeMember(t *testing.T) {
 	ctx := prepContext(nil)
 
@@ -174,30 +389,96 @@ func prepContext(calls *fake.Call) node.Context {
 		Out:      io.Discard,
 	}
 
-	events := []ordering.Event{
-		{Transactions: []validation.TransactionResult{fakeResult{}}},
-	}
-
 	ctx.Injector.Inject(fake.Mino{})
 	ctx.Injector.Inject(fakeCosi{})
-	ctx.Injector.Inject(fakeService{calls: calls, events: events})
+	ctx.Injector.Inject(fakeService{calls: calls, events: okEvents()})
 	ctx.Injector.Inject(mem.NewPool())
 	ctx.Injector.Inject(fakeTxManager{})
 
+	var rosterFac authority.Factory = fake.NewRosterFac(newRoster(4))
+	ctx.Injector.Inject(rosterFac)
+
 	return ctx
 }
 
+const formID = "deadbeef"
+
+func okEvents() []ordering.Event {
+	return []ordering.Event{
+		{Transactions: []validation.TransactionResult{fakeResult{}}},
+	}
+}
+
+// member returns the base64 description of the member with the given index.
+func member(index int) string {
+	addr := make([]byte, 4)
+	binary.LittleEndian.PutUint32(addr, uint32(index))
+
+	return base64.StdEncoding.EncodeToString(addr) + ":YQ=="
+}
+
+// newRoster returns a roster of n members.
+func newRoster(n int) authority.Roster {
+	addrs := make([]mino.Address, n)
+	pubkeys := make([]crypto.PublicKey, n)
+
+	for i := range addrs {
+		addrs[i] = fake.NewAddress(i)
+		pubkeys[i] = fake.PublicKey{}
+	}
+
+	return authority.New(addrs, pubkeys)
+}
+
+// newFormStore returns a store with a form whose roster has the 4 members
+// returned by newRoster.
+func newFormStore(t *testing.T, status etypes.Status) store.Readable {
+	snap := fake.NewSnapshot()
+
+	form := etypes.Form{
+		FormID: formID,
+		Status: status,
+		Roster: newRoster(4),
+	}
+
+	buf, err := form.Serialize(json.NewContext())
+	require.NoError(t, err)
+
+	formIDBuf, err := hex.DecodeString(formID)
+	require.NoError(t, err)
+
+	require.NoError(t, snap.Set(formIDBuf, buf))
+	require.NoError(t, etypes.InitFormsIndex(snap))
+	require.NoError(t, etypes.AddFormToIndex(snap, formID, status))
+
+	return snap
+}
+
 type fakeService struct {
 	ordering.Service
 	calls  *fake.Call
 	events []ordering.Event
+	roster authority.Authority
+	store  store.Readable
 	err    error
 }
 
 func (s fakeService) GetRoster() (authority.Authority, error) {
+	if s.roster != nil {
+		return s.roster, s.err
+	}
+
 	return authority.New(nil, nil), s.err
 }
 
+func (s fakeService) GetStore() store.Readable {
+	if s.store != nil {
+		return s.store
+	}
+
+	return fake.NewSnapshot()
+}
+
 func (s fakeService) Setup(ctx context.Context, ca crypto.CollectiveAuthority) error {
 	s.calls.Add(ctx, ca)
 	return s.err
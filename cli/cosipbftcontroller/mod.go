@@ -4,6 +4,7 @@
 package controller
 
 import (
+	"context"
 	"encoding"
 	"path/filepath"
 	"time"
@@ -85,23 +86,88 @@ func (miniController) SetCommands(builder node.Builder) {
 	sub.SetDescription("Export the node information")
 	sub.SetAction(builder.MakeAction(exportAction{}))
 
+	sub = cmd.SetSubCommand("status")
+	sub.SetDescription("Show the height, the last block and the view of the chain")
+	sub.SetFlags(
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the status as JSON",
+		},
+	)
+	sub.SetAction(builder.MakeAction(statusAction{}))
+
 	sub = cmd.SetSubCommand("roster")
 	sub.SetDescription("Roster administration")
 
-	sub = sub.SetSubCommand("add")
-	sub.SetDescription("Add a member to the chain")
-	sub.SetFlags(
+	rosterSub := sub.SetSubCommand("show")
+	rosterSub.SetDescription("Show the members of the chain")
+	rosterSub.SetFlags(
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the members as JSON",
+		},
+	)
+	rosterSub.SetAction(builder.MakeAction(rosterShowAction{}))
+
+	rosterSub = sub.SetSubCommand("add")
+	rosterSub.SetDescription("Add a member to the chain")
+	rosterSub.SetFlags(
+		cli.StringFlag{
+			Name:     "member",
+			Required: true,
+			Usage:    "base64 description of the member to add",
+		},
+		cli.DurationFlag{
+			Name:  "wait",
+			Usage: "wait for the transaction to be processed",
+		},
+	)
+	rosterSub.SetAction(builder.MakeAction(rosterAddAction{}))
+
+	rosterSub = sub.SetSubCommand("remove")
+	rosterSub.SetDescription("Remove a member from the chain")
+	rosterSub.SetFlags(
 		cli.StringFlag{
 			Name:     "member",
 			Required: true,
+			Usage:    "base64 description of the member to remove",
+		},
+		cli.DurationFlag{
+			Name:  "wait",
+			Usage: "wait for the transaction to be processed",
+		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "remove the member even if an ongoing form would be left " +
+				"with less members than its threshold",
+		},
+	)
+	rosterSub.SetAction(builder.MakeAction(rosterRemoveAction{}))
+
+	rosterSub = sub.SetSubCommand("replace")
+	rosterSub.SetDescription("Replace a member of the chain with a new one")
+	rosterSub.SetFlags(
+		cli.StringFlag{
+			Name:     "old",
+			Required: true,
+			Usage:    "base64 description of the member to remove",
+		},
+		cli.StringFlag{
+			Name:     "new",
+			Required: true,
 			Usage:    "base64 description of the member to add",
 		},
 		cli.DurationFlag{
 			Name:  "wait",
 			Usage: "wait for the transaction to be processed",
 		},
+		cli.BoolFlag{
+			Name: "force",
+			Usage: "replace the member even if an ongoing form would be left " +
+				"with less members than its threshold",
+		},
 	)
-	sub.SetAction(builder.MakeAction(rosterAddAction{}))
+	rosterSub.SetAction(builder.MakeAction(rosterReplaceAction{}))
 }
 
 // OnStart implements node.Initializer. It starts the ordering components and
@@ -183,7 +249,11 @@ func (m miniController) OnStart(flags cli.Flags, inj node.Injector) error {
 		return xerrors.Errorf("service: %v", err)
 	}
 
+	tracker := newBlockTracker()
+	go tracker.listen(context.Background(), srvc)
+
 	inj.Inject(srvc)
+	inj.Inject(tracker)
 	inj.Inject(cosi)
 	inj.Inject(pool)
 	inj.Inject(vs)
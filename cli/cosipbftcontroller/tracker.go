@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/ordering"
+)
+
+// leaderMetric is the name of the gauge where the PBFT state machine publishes
+// the index in the roster of the leader of the current view.
+const leaderMetric = "dela_cosipbft_leader"
+
+// blockTracker records the time at which the node received the last block.
+// The blocks don't have a timestamp, so this time is local to the node and
+// unknown until a block is received after the node started.
+type blockTracker struct {
+	sync.Mutex
+
+	last time.Time
+
+	// now is used to get the time, it is replaced in the tests
+	now func() time.Time
+}
+
+// newBlockTracker returns a new tracker that didn't receive any block.
+func newBlockTracker() *blockTracker {
+	return &blockTracker{
+		now: time.Now,
+	}
+}
+
+// listen records the time of the blocks of the ordering service until the
+// context is done.
+func (t *blockTracker) listen(ctx context.Context, srvc ordering.Service) {
+	for range srvc.Watch(ctx) {
+		t.Lock()
+		t.last = t.now()
+		t.Unlock()
+	}
+}
+
+// lastBlockTime returns the time at which the last block was received. The
+// time is zero if no block was received yet.
+func (t *blockTracker) lastBlockTime() time.Time {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.last
+}
+
+// leaderIndex returns the index in the roster of the current leader, which is
+// published by the PBFT state machine in a Prometheus gauge. Dela doesn't
+// expose the number of the view itself. The boolean is false if the gauge is
+// not found.
+func leaderIndex() (int, bool) {
+	for _, collector := range dela.PromCollectors {
+		gauge, ok := collector.(prometheus.Gauge)
+		if !ok || !strings.Contains(gauge.Desc().String(), `"`+leaderMetric+`"`) {
+			continue
+		}
+
+		var metric dto.Metric
+
+		err := gauge.Write(&metric)
+		if err != nil {
+			return 0, false
+		}
+
+		return int(metric.GetGauge().GetValue()), true
+	}
+
+	return 0, false
+}
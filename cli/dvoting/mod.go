@@ -23,6 +23,13 @@
 //	# Add the third after the chain is set up.
 //	dvoting --config /tmp/node1 ordering roster add\
 //	  --member $(dvoting --config /tmp/node3 ordering export)
+//
+//	# Replace the second with a fourth one, then remove the fourth.
+//	dvoting --config /tmp/node1 ordering roster replace\
+//	  --old $(dvoting --config /tmp/node2 ordering export)\
+//	  --new $(dvoting --config /tmp/node4 ordering export)
+//	dvoting --config /tmp/node1 ordering roster remove\
+//	  --member $(dvoting --config /tmp/node4 ordering export)
 package main
 
 import (
@@ -30,14 +37,19 @@ import (
 	"io"
 	"os"
 
+	ballot "go.dedis.ch/d-voting/client/controller"
 	dkg "go.dedis.ch/d-voting/services/dkg/pedersen/controller"
 	"go.dedis.ch/d-voting/services/dkg/pedersen/json"
 	shuffle "go.dedis.ch/d-voting/services/shuffle/neff/controller"
+	webhook "go.dedis.ch/d-voting/services/webhook/controller"
 
 	cosipbft "go.dedis.ch/d-voting/cli/cosipbftcontroller"
 	"go.dedis.ch/d-voting/cli/postinstall"
 	evoting "go.dedis.ch/d-voting/contracts/evoting/controller"
 	metrics "go.dedis.ch/d-voting/metrics/controller"
+	drain "go.dedis.ch/d-voting/proxy/drain/controller"
+	keyring "go.dedis.ch/d-voting/proxy/keyring/controller"
+	tlsproxy "go.dedis.ch/d-voting/proxy/tlsproxy/controller"
 	"go.dedis.ch/dela/cli/node"
 	access "go.dedis.ch/dela/contracts/access/controller"
 	db "go.dedis.ch/dela/core/store/kv/controller"
@@ -81,11 +93,16 @@ func runWithCfg(args []string, cfg config) error {
 		pool.NewController(),
 		access.NewController(),
 		proxy.NewController(),
+		tlsproxy.NewController(),
+		keyring.NewController(),
+		drain.NewController(),
 		shuffle.NewController(),
 		evoting.NewController(),
+		webhook.NewController(),
 		gapi.NewController(),
 		metrics.NewController(),
 		postinstall.NewController(),
+		ballot.NewController(),
 	)
 
 	app := builder.Build()
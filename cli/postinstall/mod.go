@@ -7,6 +7,8 @@ import (
 
 	evoting "go.dedis.ch/d-voting/contracts/evoting/controller"
 	prom "go.dedis.ch/d-voting/metrics/controller"
+	"go.dedis.ch/d-voting/proxy/tlsproxy"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
 	dkg "go.dedis.ch/d-voting/services/dkg/pedersen/controller"
 	neff "go.dedis.ch/d-voting/services/shuffle/neff/controller"
 	"go.dedis.ch/dela"
@@ -42,6 +44,22 @@ func (m controller) SetCommands(builder node.Builder) {
 			Required: false,
 			Value:    defaultProxyAddr,
 		},
+		cli.StringFlag{
+			Name:     "proxy-tls-cert",
+			Usage:    "the PEM certificate of the proxy, to serve HTTPS instead of HTTP",
+			Required: false,
+		},
+		cli.StringFlag{
+			Name:     "proxy-tls-key",
+			Usage:    "the PEM private key of the certificate of the proxy",
+			Required: false,
+		},
+		cli.StringFlag{
+			Name: "proxy-tls-client-ca",
+			Usage: "the PEM certificates of the CAs that sign the certificates " +
+				"the clients of the proxy must present, for mutual TLS",
+			Required: false,
+		},
 		cli.StringFlag{
 			Name:     "promaddr",
 			Usage:    "the Prometheus address",
@@ -58,6 +76,32 @@ func (m controller) SetCommands(builder node.Builder) {
 			Usage:    "the frontend public key that signs requests, hex encoded",
 			Required: false,
 		},
+		cli.DurationFlag{
+			Name:     "txnretention",
+			Usage:    "the duration during which the status of a transaction can be checked",
+			Required: false,
+			Value:    txnmanager.DefaultRetention,
+		},
+		cli.IntFlag{
+			Name:     "formvoterate",
+			Usage:    "the maximum number of votes per minute in a form, 0 for no limit",
+			Required: false,
+		},
+		cli.IntFlag{
+			Name:     "formvoteburst",
+			Usage:    "the maximum number of votes a form accepts at once, 0 for the size of the largest batch",
+			Required: false,
+		},
+		cli.IntFlag{
+			Name:     "uservoterate",
+			Usage:    "the maximum number of votes per minute of a user in a form, 0 for no limit",
+			Required: false,
+		},
+		cli.IntFlag{
+			Name:     "poolrate",
+			Usage:    "the maximum number of transactions per minute submitted to the pool, 0 for no limit",
+			Required: false,
+		},
 	)
 }
 
@@ -92,9 +136,10 @@ func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
 	// Start the proxy server
 	//
 
-	proxyAddr := ctx.String("proxyaddr")
-
-	proxyhttp := proxyFac(proxyAddr)
+	proxyhttp, err := newProxy(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to create proxy: %v", err)
+	}
 
 	inj.Inject(proxyhttp)
 
@@ -122,6 +167,12 @@ func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
 		Flags: node.FlagSet{
 			"signer":   filepath.Join(ctx.Path("config"), "private.key"),
 			"proxykey": ctx.String("proxykey"),
+			// the flags are read as if they were JSON-encoded
+			"txnretention":  float64(ctx.Duration("txnretention")),
+			"formvoterate":  ctx.Int("formvoterate"),
+			"formvoteburst": ctx.Int("formvoteburst"),
+			"uservoterate":  ctx.Int("uservoterate"),
+			"poolrate":      ctx.Int("poolrate"),
 		},
 		Out: os.Stdout,
 	})
@@ -177,7 +228,33 @@ func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
 	return nil
 }
 
-// OnStop implements node.Initializer.
+// OnStop implements node.Initializer. The proxy is stopped by the controller
+// of the HTTP or HTTPS proxy, once it is drained.
 func (controller) OnStop(inj node.Injector) error {
 	return nil
 }
+
+// newProxy returns the proxy server, which serves HTTPS if the TLS
+// certificate and key are set.
+func newProxy(ctx cli.Flags) (proxy.Proxy, error) {
+	proxyAddr := ctx.String("proxyaddr")
+
+	cert := ctx.String("proxy-tls-cert")
+	key := ctx.String("proxy-tls-key")
+	clientCA := ctx.String("proxy-tls-client-ca")
+
+	if cert == "" && key == "" && clientCA == "" {
+		return proxyFac(proxyAddr), nil
+	}
+
+	config, err := tlsproxy.NewConfig(cert, key, clientCA)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load TLS configuration: %v", err)
+	}
+
+	if clientCA != "" {
+		dela.Logger.Info().Msg("the proxy requires client certificates")
+	}
+
+	return tlsproxy.NewHTTPS(proxyAddr, config), nil
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+	"golang.org/x/xerrors"
+)
+
+// ChunkSize is the maximum number of bytes of a ballot that fit in one
+// ElGamal pair, which is the number of bytes that can be embedded in a point.
+const ChunkSize = 29
+
+// paddingChars are the characters used to pad the ballots, as done by the web
+// frontend.
+const paddingChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// EncodeBallot returns the ballot in the format expected by
+// types.Ballot.Unmarshal, as described in docs/ballot_encoding.md. The
+// encoding ends with an empty line. A rank lower than 0 is a choice that is
+// not ranked.
+func EncodeBallot(ballot types.Ballot) string {
+	var encoded strings.Builder
+
+	writeLine := func(kind string, id types.ID, answers []string) {
+		encoded.WriteString(kind)
+		encoded.WriteString(":")
+		encoded.WriteString(base64.StdEncoding.EncodeToString([]byte(id)))
+		encoded.WriteString(":")
+		encoded.WriteString(strings.Join(answers, ","))
+		encoded.WriteString("\n")
+	}
+
+	for i, id := range ballot.SelectResultIDs {
+		answers := make([]string, len(ballot.SelectResult[i]))
+		for j, selected := range ballot.SelectResult[i] {
+			answers[j] = "0"
+			if selected {
+				answers[j] = "1"
+			}
+		}
+
+		writeLine("select", id, answers)
+	}
+
+	for i, id := range ballot.RankResultIDs {
+		answers := make([]string, len(ballot.RankResult[i]))
+		for j, rank := range ballot.RankResult[i] {
+			if rank >= 0 {
+				answers[j] = strconv.Itoa(int(rank))
+			}
+		}
+
+		writeLine("rank", id, answers)
+	}
+
+	for i, id := range ballot.TextResultIDs {
+		answers := make([]string, len(ballot.TextResult[i]))
+		for j, text := range ballot.TextResult[i] {
+			answers[j] = base64.StdEncoding.EncodeToString([]byte(text))
+		}
+
+		writeLine("text", id, answers)
+	}
+
+	encoded.WriteString("\n")
+
+	return encoded.String()
+}
+
+// PadBallot appends random characters to the encoded ballot until it has the
+// given size, so that all the ballots of a form have the same size once
+// encrypted. It returns an error if the ballot is bigger than the size.
+func PadBallot(encoded string, size int) (string, error) {
+	if len(encoded) > size {
+		return "", xerrors.Errorf("the ballot has %d bytes, more than the %d bytes "+
+			"of the ballots of the form", len(encoded), size)
+	}
+
+	padding := make([]byte, size-len(encoded))
+	max := big.NewInt(int64(len(paddingChars)))
+
+	for i := range padding {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", xerrors.Errorf("failed to pick padding: %v", err)
+		}
+
+		padding[i] = paddingChars[index.Int64()]
+	}
+
+	return encoded + string(padding), nil
+}
+
+// EncryptBallot splits the padded ballot in chunks of ChunkSize bytes and
+// ElGamal-encrypts each of them with the public key of the form. The ciphervote
+// always has the given number of chunks.
+func EncryptBallot(padded string, pubkey kyber.Point, chunks int) (ptypes.CiphervoteJSON, error) {
+	if len(padded) > chunks*ChunkSize {
+		return nil, xerrors.Errorf("the ballot has %d bytes, more than the %d "+
+			"bytes of %d chunks", len(padded), chunks*ChunkSize, chunks)
+	}
+
+	ciphervote := make(ptypes.CiphervoteJSON, chunks)
+
+	for i := range ciphervote {
+		start := i * ChunkSize
+		if start > len(padded) {
+			start = len(padded)
+		}
+
+		end := start + ChunkSize
+		if end > len(padded) {
+			end = len(padded)
+		}
+
+		K, C := encrypt([]byte(padded[start:end]), pubkey)
+
+		kbuf, err := K.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal K: %v", err)
+		}
+
+		cbuf, err := C.MarshalBinary()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to marshal C: %v", err)
+		}
+
+		ciphervote[i] = ptypes.EGPairJSON{
+			K: kbuf,
+			C: cbuf,
+		}
+	}
+
+	return ciphervote, nil
+}
+
+// encrypt embeds the message in a point and ElGamal-encrypts it. The message
+// must fit in a point.
+func encrypt(message []byte, pubkey kyber.Point) (K, C kyber.Point) {
+	M := suite.Point().Embed(message, random.New())
+
+	k := suite.Scalar().Pick(random.New()) // ephemeral private key
+	K = suite.Point().Mul(k, nil)          // ephemeral DH public key
+	S := suite.Point().Mul(k, pubkey)      // ephemeral DH shared secret
+	C = S.Add(S, M)                        // message blinded with secret
+
+	return K, C
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+var configuration = types.Configuration{Scaffold: []types.Subject{{
+	ID: "aa",
+	Selects: []types.Select{{
+		ID:      "bb",
+		MaxN:    1,
+		MinN:    1,
+		Choices: make([]types.Choice, 3),
+	}},
+	Ranks: []types.Rank{{
+		ID:      "cc",
+		MaxN:    3,
+		MinN:    0,
+		Choices: make([]types.Choice, 3),
+	}},
+	Texts: []types.Text{{
+		ID:        "dd",
+		MaxN:      2,
+		MinN:      1,
+		MaxLength: 10,
+		Choices:   make([]types.Choice, 2),
+	}},
+}}}
+
+var ballot = types.Ballot{
+	SelectResultIDs: []types.ID{"bb"},
+	SelectResult:    [][]bool{{false, true, false}},
+	RankResultIDs:   []types.ID{"cc"},
+	RankResult:      [][]int8{{1, -1, 0}},
+	TextResultIDs:   []types.ID{"dd"},
+	TextResult:      [][]string{{"yes", ""}},
+}
+
+func TestEncodeBallot(t *testing.T) {
+	encoded := EncodeBallot(ballot)
+	require.Equal(t, "select:YmI=:0,1,0\nrank:Y2M=:1,,0\ntext:ZGQ=:eWVz,\n\n", encoded)
+
+	var decoded types.Ballot
+
+	err := decoded.Unmarshal(encoded, types.Form{Configuration: configuration})
+	require.NoError(t, err)
+	require.True(t, ballot.Equal(decoded))
+
+	require.Equal(t, "\n", EncodeBallot(types.Ballot{}))
+}
+
+func TestPadBallot(t *testing.T) {
+	encoded := EncodeBallot(ballot)
+
+	padded, err := PadBallot(encoded, 100)
+	require.NoError(t, err)
+	require.Len(t, padded, 100)
+	require.True(t, strings.HasPrefix(padded, encoded))
+
+	var decoded types.Ballot
+
+	err = decoded.Unmarshal(padded, types.Form{Configuration: configuration})
+	require.NoError(t, err)
+	require.True(t, ballot.Equal(decoded))
+
+	_, err = PadBallot(encoded, 10)
+	require.EqualError(t, err, "the ballot has 50 bytes, more than the 10 bytes "+
+		"of the ballots of the form")
+}
+
+func TestEncryptBallot(t *testing.T) {
+	secret := suite.Scalar().Pick(random.New())
+	pubkey := suite.Point().Mul(secret, nil)
+
+	padded, err := PadBallot(EncodeBallot(ballot), 60)
+	require.NoError(t, err)
+
+	ciphervote, err := EncryptBallot(padded, pubkey, 3)
+	require.NoError(t, err)
+	require.Len(t, ciphervote, 3)
+
+	var decrypted []byte
+
+	for _, pair := range ciphervote {
+		K := suite.Point()
+		require.NoError(t, K.UnmarshalBinary(pair.K))
+
+		C := suite.Point()
+		require.NoError(t, C.UnmarshalBinary(pair.C))
+
+		decrypted = append(decrypted, decrypt(t, secret, K, C)...)
+	}
+
+	require.Equal(t, padded, string(decrypted))
+
+	_, err = EncryptBallot(padded, pubkey, 2)
+	require.EqualError(t, err, "the ballot has 60 bytes, more than the 58 bytes of 2 chunks")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func decrypt(t *testing.T, secret kyber.Scalar, K, C kyber.Point) []byte {
+	S := suite.Point().Mul(secret, K)
+	M := suite.Point().Sub(C, S)
+
+	data, err := M.Data()
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"go.dedis.ch/d-voting/client"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela/cli"
+	"go.dedis.ch/dela/cli/node"
+	"golang.org/x/xerrors"
+)
+
+// NewController returns a new controller initializer
+func NewController() node.Initializer {
+	return controller{out: os.Stdout}
+}
+
+// controller is an initializer with a set of commands that don't need a
+// running node.
+//
+// - implements node.Initializer
+type controller struct {
+	out io.Writer
+}
+
+// SetCommands implements node.Initializer.
+func (m controller) SetCommands(builder node.Builder) {
+	cmd := builder.SetCommand("ballot")
+	cmd.SetDescription("prepare ballots without a node")
+
+	// dvoting ballot encrypt --form form.json --ballot ballot.json \
+	//   --userID <ID>
+	sub := cmd.SetSubCommand("encrypt")
+	sub.SetDescription("encrypt a ballot for a form and print the vote request")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "form",
+			Usage:    "path to the form as returned by GET /evoting/forms/{formID}",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "ballot",
+			Usage:    "path to the JSON ballot, in the format of the results",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "userID",
+			Usage: "the ID of the voter",
+		},
+	)
+
+	// the action is executed by the CLI process, without the daemon
+	sub.SetAction(m.encrypt)
+}
+
+// encrypt prints the JSON request to cast the ballot. The request is not
+// signed.
+func (m controller) encrypt(flags cli.Flags) error {
+	var formResp ptypes.GetFormResponse
+
+	err := readJSON(flags.String("form"), &formResp)
+	if err != nil {
+		return xerrors.Errorf("failed to read form: %v", err)
+	}
+
+	form, err := client.NewForm(formResp)
+	if err != nil {
+		return xerrors.Errorf("failed to get form: %v", err)
+	}
+
+	var ballot types.Ballot
+
+	err = readJSON(flags.String("ballot"), &ballot)
+	if err != nil {
+		return xerrors.Errorf("failed to read ballot: %v", err)
+	}
+
+	ciphervote, err := form.EncryptBallot(ballot)
+	if err != nil {
+		return xerrors.Errorf("failed to encrypt ballot: %v", err)
+	}
+
+	encoder := json.NewEncoder(m.out)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(ptypes.CastVoteRequest{
+		UserID: flags.String("userID"),
+		Ballot: ciphervote,
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to encode request: %v", err)
+	}
+
+	return nil
+}
+
+// OnStart implements node.Initializer.
+func (controller) OnStart(cli.Flags, node.Injector) error {
+	return nil
+}
+
+// OnStop implements node.Initializer.
+func (controller) OnStop(node.Injector) error {
+	return nil
+}
+
+// readJSON unmarshals the JSON file into el, which must be a pointer.
+func readJSON(path string, el interface{}) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return xerrors.Errorf("failed to read %q: %v", path, err)
+	}
+
+	err = json.Unmarshal(buf, el)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal %q: %v", path, err)
+	}
+
+	return nil
+}
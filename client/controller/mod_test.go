@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/kyber/v3/suites"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestController_Encrypt(t *testing.T) {
+	dir := t.TempDir()
+
+	formPath := filepath.Join(dir, "form.json")
+	ballotPath := filepath.Join(dir, "ballot.json")
+
+	buffer := new(bytes.Buffer)
+	ctrl := controller{out: buffer}
+
+	flags := node.FlagSet{
+		"form":   formPath,
+		"ballot": ballotPath,
+		"userID": "user1",
+	}
+
+	err := ctrl.encrypt(flags)
+	require.ErrorContains(t, err, "failed to read form")
+
+	pubkey, err := suites.MustFind("ed25519").Point().Pick(random.New()).MarshalBinary()
+	require.NoError(t, err)
+
+	writeJSON(t, formPath, ptypes.GetFormResponse{
+		FormID: "deadbeef",
+		Configuration: types.Configuration{Scaffold: []types.Subject{{
+			ID: "aa",
+			Selects: []types.Select{{
+				ID:      "bb",
+				MaxN:    1,
+				MinN:    1,
+				Choices: make([]types.Choice, 2),
+			}},
+		}}},
+		Pubkey:          hex.EncodeToString(pubkey),
+		BallotSize:      30,
+		ChunksPerBallot: 2,
+	})
+
+	err = ctrl.encrypt(flags)
+	require.ErrorContains(t, err, "failed to read ballot")
+
+	writeJSON(t, ballotPath, types.Ballot{
+		SelectResultIDs: []types.ID{"bb"},
+		SelectResult:    [][]bool{{true, true}},
+	})
+
+	err = ctrl.encrypt(flags)
+	require.ErrorContains(t, err, "failed to encrypt ballot: invalid ballot")
+
+	writeJSON(t, ballotPath, types.Ballot{
+		SelectResultIDs: []types.ID{"bb"},
+		SelectResult:    [][]bool{{false, true}},
+	})
+
+	err = ctrl.encrypt(flags)
+	require.NoError(t, err)
+
+	var req ptypes.CastVoteRequest
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &req))
+	require.Equal(t, "user1", req.UserID)
+	require.Len(t, req.Ballot, 2)
+}
+
+func TestController_OnStart(t *testing.T) {
+	err := NewController().OnStart(node.FlagSet{}, nil)
+	require.NoError(t, err)
+}
+
+func TestController_OnStop(t *testing.T) {
+	err := NewController().OnStop(nil)
+	require.NoError(t, err)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func writeJSON(t *testing.T, path string, value interface{}) {
+	buf, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, buf, 0600)
+	require.NoError(t, err)
+}
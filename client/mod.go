@@ -0,0 +1,138 @@
+// Package client implements what a voter or a web backend needs to cast a
+// ballot on a form: it encodes the answers in the format expected by the
+// smart contract, pads and encrypts them with the public key of the form, and
+// submits the signed vote to the proxy of a node. Client is a typed client of
+// the whole proxy API.
+package client
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/suites"
+	"golang.org/x/xerrors"
+)
+
+var suite = suites.MustFind("ed25519")
+
+// Form is the public information of a form needed to cast a ballot.
+type Form struct {
+	FormID          string
+	Configuration   types.Configuration
+	Pubkey          kyber.Point
+	BallotSize      int
+	ChunksPerBallot int
+}
+
+// NewForm returns the form described by the response of the proxy. The form
+// must be open, so that it has a public key.
+func NewForm(resp ptypes.GetFormResponse) (Form, error) {
+	if resp.Pubkey == "" {
+		return Form{}, xerrors.Errorf("the form has no public key, it must be open")
+	}
+
+	buf, err := hex.DecodeString(resp.Pubkey)
+	if err != nil {
+		return Form{}, xerrors.Errorf("failed to decode public key: %v", err)
+	}
+
+	pubkey := suite.Point()
+
+	err = pubkey.UnmarshalBinary(buf)
+	if err != nil {
+		return Form{}, xerrors.Errorf("failed to unmarshal public key: %v", err)
+	}
+
+	return Form{
+		FormID:          resp.FormID,
+		Configuration:   resp.Configuration,
+		Pubkey:          pubkey,
+		BallotSize:      resp.BallotSize,
+		ChunksPerBallot: resp.ChunksPerBallot,
+	}, nil
+}
+
+// EncryptBallot checks the ballot against the configuration of the form, then
+// encodes, pads and encrypts it.
+func (f Form) EncryptBallot(ballot types.Ballot) (ptypes.CiphervoteJSON, error) {
+	encoded := EncodeBallot(ballot)
+
+	var check types.Ballot
+
+	err := check.Unmarshal(encoded, types.Form{Configuration: f.Configuration})
+	if err != nil {
+		return nil, xerrors.Errorf("invalid ballot: %v", err)
+	}
+
+	padded, err := PadBallot(encoded, f.BallotSize)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to pad ballot: %v", err)
+	}
+
+	ciphervote, err := EncryptBallot(padded, f.Pubkey, f.ChunksPerBallot)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encrypt ballot: %v", err)
+	}
+
+	return ciphervote, nil
+}
+
+// SignRequest returns the JSON of the signed request holding the message, as
+// described in docs/msg_sig.md. The payload is the message with the fields
+// that bind it to the time, the method and the path of the request.
+func SignRequest(secret kyber.Scalar, keyID, method, path string, now time.Time,
+	msg interface{}) ([]byte, error) {
+
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal message: %v", err)
+	}
+
+	fields := map[string]interface{}{}
+
+	err = json.Unmarshal(buf, &fields)
+	if err != nil {
+		return nil, xerrors.Errorf("the message must be a JSON object: %v", err)
+	}
+
+	nonce := make([]byte, 16)
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to pick nonce: %v", err)
+	}
+
+	fields["Timestamp"] = now.Unix()
+	fields["Nonce"] = hex.EncodeToString(nonce)
+	fields["Method"] = method
+	fields["Path"] = path
+
+	buf, err = json.Marshal(fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal payload: %v", err)
+	}
+
+	payload := base64.URLEncoding.EncodeToString(buf)
+	digest := sha256.Sum256([]byte(payload))
+
+	signature, err := schnorr.Sign(suite, secret, digest[:])
+	if err != nil {
+		return nil, xerrors.Errorf("failed to sign: %v", err)
+	}
+
+	signed := ptypes.SignedRequest{
+		Payload:   payload,
+		Signature: hex.EncodeToString(signature),
+		KeyID:     keyID,
+	}
+
+	return json.Marshal(signed)
+}
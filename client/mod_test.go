@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+const formID = "deadbeef"
+
+func TestNewForm(t *testing.T) {
+	_, err := NewForm(ptypes.GetFormResponse{})
+	require.EqualError(t, err, "the form has no public key, it must be open")
+
+	_, err = NewForm(ptypes.GetFormResponse{Pubkey: "not hex"})
+	require.ErrorContains(t, err, "failed to decode public key")
+
+	_, err = NewForm(ptypes.GetFormResponse{Pubkey: "aabb"})
+	require.ErrorContains(t, err, "failed to unmarshal public key")
+
+	form, err := NewForm(newFormResponse(t))
+	require.NoError(t, err)
+	require.Equal(t, formID, form.FormID)
+	require.Equal(t, 60, form.BallotSize)
+	require.Equal(t, 3, form.ChunksPerBallot)
+}
+
+func TestForm_EncryptBallot(t *testing.T) {
+	form, err := NewForm(newFormResponse(t))
+	require.NoError(t, err)
+
+	ciphervote, err := form.EncryptBallot(ballot)
+	require.NoError(t, err)
+	require.Len(t, ciphervote, 3)
+
+	_, err = form.EncryptBallot(types.Ballot{
+		SelectResultIDs: []types.ID{"bb"},
+		SelectResult:    [][]bool{{true, true, false}},
+	})
+	require.ErrorContains(t, err, "invalid ballot")
+
+	form.BallotSize = 10
+
+	_, err = form.EncryptBallot(ballot)
+	require.ErrorContains(t, err, "failed to pad ballot")
+}
+
+func TestSignRequest(t *testing.T) {
+	secret := suite.Scalar().Pick(random.New())
+	now := time.Unix(1690000000, 0)
+
+	buf, err := SignRequest(secret, "backend", http.MethodPut, "/evoting/forms/"+formID,
+		now, ptypes.UpdateFormRequest{Action: "open"})
+	require.NoError(t, err)
+
+	var signed ptypes.SignedRequest
+	require.NoError(t, json.Unmarshal(buf, &signed))
+	require.Equal(t, "backend", signed.KeyID)
+	require.NoError(t, signed.Verify(suite.Point().Mul(secret, nil)))
+
+	metadata, err := signed.GetMetadata()
+	require.NoError(t, err)
+	require.Equal(t, int64(1690000000), metadata.Timestamp)
+	require.Equal(t, http.MethodPut, metadata.Method)
+	require.Equal(t, "/evoting/forms/"+formID, metadata.Path)
+	require.Len(t, metadata.Nonce, 32)
+
+	var req ptypes.UpdateFormRequest
+	require.NoError(t, signed.GetMessage(&req))
+	require.Equal(t, "open", req.Action)
+
+	_, err = SignRequest(secret, "", http.MethodPut, "/", now, "not an object")
+	require.ErrorContains(t, err, "the message must be a JSON object")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func newFormResponse(t *testing.T) ptypes.GetFormResponse {
+	pubkey := suite.Point().Pick(random.New())
+
+	buf, err := pubkey.MarshalBinary()
+	require.NoError(t, err)
+
+	return ptypes.GetFormResponse{
+		FormID:          formID,
+		Configuration:   configuration,
+		Status:          uint16(types.Open),
+		Pubkey:          hex.EncodeToString(buf),
+		BallotSize:      60,
+		ChunksPerBallot: 3,
+	}
+}
@@ -0,0 +1,504 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/d-voting/services/dkg"
+	"go.dedis.ch/kyber/v3"
+	"golang.org/x/xerrors"
+)
+
+const (
+	formsPath        = "/evoting/forms"
+	dkgActorsPath    = "/evoting/services/dkg/actors"
+	shufflePath      = "/evoting/services/shuffle/"
+	transactionsPath = "/evoting/transactions/"
+	contentType      = "application/json"
+)
+
+const (
+	// DefaultRetries is the default number of times a request is sent again
+	// when the proxy can't be reached or is unavailable.
+	DefaultRetries = 3
+	// DefaultRetryDelay is the default delay before the first retry. It is
+	// doubled after each retry.
+	DefaultRetryDelay = 500 * time.Millisecond
+	// DefaultPollInterval is the default interval at which the status of a
+	// transaction or of a DKG actor is checked while waiting for it.
+	DefaultPollInterval = time.Second
+)
+
+// Error is the error of a request that the proxy didn't accept.
+type Error struct {
+	StatusCode int
+	// HTTPError is set if the proxy answered with a structured error,
+	// otherwise the body of the response is in Body
+	HTTPError *ptypes.HTTPError
+	Body      string
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.HTTPError != nil {
+		return fmt.Sprintf("%d %s: %v", e.StatusCode, e.HTTPError.Title,
+			e.HTTPError.Args["error"])
+	}
+
+	return fmt.Sprintf("unexpected status: %d %s, body: %s", e.StatusCode,
+		http.StatusText(e.StatusCode), e.Body)
+}
+
+// Client sends requests to the proxy of a node. The requests that change the
+// state of the forms are signed with the secret key of the web backend.
+type Client struct {
+	HTTPClient *http.Client
+	// Retries is the number of times a request is sent again. A read is
+	// retried if the proxy can't be reached, or answers that it or its
+	// gateway is unavailable or overloaded. A request that changes the state
+	// is only retried if the proxy refused it without processing it, because
+	// of its rate limits or because the node is stopping. A signed request is
+	// signed again with a new nonce for each retry.
+	Retries    int
+	RetryDelay time.Duration
+	// RetryAllWrites also retries the requests that change the state like
+	// the reads. The proxy may have processed such a request, for example if
+	// the connection was lost before the response, so it may be submitted
+	// twice.
+	RetryAllWrites bool
+	// PollInterval is the interval at which the proxy is polled while waiting
+	PollInterval time.Duration
+
+	proxyAddr string
+	secret    kyber.Scalar
+	keyID     string
+
+	// now is used to timestamp the signed requests, it is replaced in the
+	// tests
+	now func() time.Time
+}
+
+// NewClient returns a new client of the proxy at the given base address, for
+// example "http://localhost:9080". The keyID is the ID of the secret key in
+// the keyring of the node, and is empty for the default key of the proxy.
+func NewClient(proxyAddr string, secret kyber.Scalar, keyID string) *Client {
+	return &Client{
+		HTTPClient:   http.DefaultClient,
+		Retries:      DefaultRetries,
+		RetryDelay:   DefaultRetryDelay,
+		PollInterval: DefaultPollInterval,
+
+		proxyAddr: proxyAddr,
+		secret:    secret,
+		keyID:     keyID,
+		now:       time.Now,
+	}
+}
+
+// CreateForm creates a form with the configuration. The ID of the form is
+// known right away, but the form exists once the transaction is included,
+// see WaitTransaction.
+func (c *Client) CreateForm(ctx context.Context, adminID string,
+	configuration types.Configuration) (ptypes.CreateFormResponse, error) {
+
+	var resp ptypes.CreateFormResponse
+
+	req := ptypes.CreateFormRequest{
+		AdminID:       adminID,
+		Configuration: configuration,
+	}
+
+	err := c.send(ctx, http.MethodPost, formsPath, req, &resp)
+	if err != nil {
+		return resp, xerrors.Errorf("failed to create form: %w", err)
+	}
+
+	return resp, nil
+}
+
+// OpenForm opens the form, once its DKG is set up.
+func (c *Client) OpenForm(ctx context.Context, formID string) (txnmanager.TransactionClientInfo, error) {
+	return c.updateForm(ctx, formID, "open")
+}
+
+// CloseForm closes the form, so that no more ballot can be cast.
+func (c *Client) CloseForm(ctx context.Context, formID string) (txnmanager.TransactionClientInfo, error) {
+	return c.updateForm(ctx, formID, "close")
+}
+
+// CancelForm cancels the form.
+func (c *Client) CancelForm(ctx context.Context, formID string) (txnmanager.TransactionClientInfo, error) {
+	return c.updateForm(ctx, formID, "cancel")
+}
+
+// CombineShares decrypts the ballots of the form once the nodes submitted
+// their public shares.
+func (c *Client) CombineShares(ctx context.Context, formID string) (txnmanager.TransactionClientInfo, error) {
+	return c.updateForm(ctx, formID, "combineShares")
+}
+
+// GetForm returns the form with the given hex-encoded ID.
+func (c *Client) GetForm(ctx context.Context, formID string) (ptypes.GetFormResponse, error) {
+	var resp ptypes.GetFormResponse
+
+	err := c.send(ctx, http.MethodGet, formsPath+"/"+formID, nil, &resp)
+	if err != nil {
+		return resp, xerrors.Errorf("failed to get form: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetResults returns the decrypted ballots of the form. It returns an error if
+// the result is not available yet.
+func (c *Client) GetResults(ctx context.Context, formID string) ([]types.Ballot, error) {
+	form, err := c.GetForm(ctx, formID)
+	if err != nil {
+		return nil, err
+	}
+
+	if types.Status(form.Status) != types.ResultAvailable {
+		return nil, xerrors.Errorf("the result is not available, current status: %d",
+			form.Status)
+	}
+
+	return form.Result, nil
+}
+
+// CastVote encrypts the ballot for the form and submits the vote of the user.
+// The form must be open.
+func (c *Client) CastVote(ctx context.Context, formID, userID string,
+	ballot types.Ballot) (txnmanager.TransactionClientInfo, error) {
+
+	var info txnmanager.TransactionClientInfo
+
+	formResp, err := c.GetForm(ctx, formID)
+	if err != nil {
+		return info, err
+	}
+
+	form, err := NewForm(formResp)
+	if err != nil {
+		return info, xerrors.Errorf("failed to get form: %v", err)
+	}
+
+	ciphervote, err := form.EncryptBallot(ballot)
+	if err != nil {
+		return info, err
+	}
+
+	req := ptypes.CastVoteRequest{
+		UserID: userID,
+		Ballot: ciphervote,
+	}
+
+	err = c.send(ctx, http.MethodPost, formsPath+"/"+formID+"/vote", req, &info)
+	if err != nil {
+		return info, xerrors.Errorf("failed to cast vote: %w", err)
+	}
+
+	return info, nil
+}
+
+// WaitTransaction polls the status of the transaction of the token until it
+// is included or rejected, or the context is done. It returns an error if the
+// transaction is rejected.
+func (c *Client) WaitTransaction(ctx context.Context, token string) (txnmanager.TransactionClientInfo, error) {
+	for {
+		var info txnmanager.TransactionClientInfo
+
+		err := c.send(ctx, http.MethodGet, transactionsPath+url.PathEscape(token), nil, &info)
+		if err != nil {
+			return info, xerrors.Errorf("failed to get transaction status: %w", err)
+		}
+
+		switch info.Status {
+		case txnmanager.IncludedTransaction:
+			return info, nil
+		case txnmanager.RejectedTransaction:
+			return info, xerrors.Errorf("transaction rejected: %s: %s", info.Code, info.Message)
+		}
+
+		// each status comes with the token to use for the next check
+		token = info.Token
+
+		err = c.sleep(ctx, c.PollInterval)
+		if err != nil {
+			return info, xerrors.Errorf("transaction not included: %v", err)
+		}
+	}
+}
+
+// InitDKG starts the DKG actor of the form on the node of the proxy. It must
+// be done on every node of the roster before the DKG is set up.
+func (c *Client) InitDKG(ctx context.Context, formID string) error {
+	req := ptypes.NewDKGRequest{
+		FormID: formID,
+	}
+
+	err := c.send(ctx, http.MethodPost, dkgActorsPath, req, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to init DKG: %w", err)
+	}
+
+	return nil
+}
+
+// SetupDKG starts the setup of the DKG of the form, which must be done on one
+// node only. The setup is asynchronous, see WaitDKGSetup.
+func (c *Client) SetupDKG(ctx context.Context, formID string) error {
+	return c.updateDKG(ctx, formID, "setup")
+}
+
+// ComputePubshares requests the nodes to submit their public shares of the
+// shuffled ballots of the form.
+func (c *Client) ComputePubshares(ctx context.Context, formID string) error {
+	return c.updateDKG(ctx, formID, "computePubshares")
+}
+
+// DKGStatus returns the status of the DKG actor of the form on the node of the
+// proxy.
+func (c *Client) DKGStatus(ctx context.Context, formID string) (ptypes.GetActorInfo, error) {
+	var info ptypes.GetActorInfo
+
+	err := c.send(ctx, http.MethodGet, dkgActorsPath+"/"+formID, nil, &info)
+	if err != nil {
+		return info, xerrors.Errorf("failed to get DKG status: %w", err)
+	}
+
+	return info, nil
+}
+
+// WaitDKGSetup polls the status of the DKG actor of the form until it is set
+// up, failed, or the context is done.
+func (c *Client) WaitDKGSetup(ctx context.Context, formID string) error {
+	for {
+		info, err := c.DKGStatus(ctx, formID)
+		if err != nil {
+			return err
+		}
+
+		switch dkg.StatusCode(info.Status) {
+		case dkg.Setup:
+			return nil
+		case dkg.Failed:
+			return xerrors.Errorf("DKG setup failed: %s", info.Error.Message)
+		}
+
+		err = c.sleep(ctx, c.PollInterval)
+		if err != nil {
+			return xerrors.Errorf("DKG not set up: %v", err)
+		}
+	}
+}
+
+// Shuffle shuffles the ballots of the closed form. It returns once enough
+// nodes shuffled them.
+func (c *Client) Shuffle(ctx context.Context, formID string) error {
+	req := ptypes.UpdateShuffle{
+		Action: "shuffle",
+	}
+
+	err := c.send(ctx, http.MethodPut, shufflePath+formID, req, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to shuffle: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) updateForm(ctx context.Context, formID,
+	action string) (txnmanager.TransactionClientInfo, error) {
+
+	var info txnmanager.TransactionClientInfo
+
+	req := ptypes.UpdateFormRequest{
+		Action: action,
+	}
+
+	err := c.send(ctx, http.MethodPut, formsPath+"/"+formID, req, &info)
+	if err != nil {
+		return info, xerrors.Errorf("failed to %s form: %w", action, err)
+	}
+
+	return info, nil
+}
+
+func (c *Client) updateDKG(ctx context.Context, formID, action string) error {
+	req := ptypes.UpdateDKG{
+		Action: action,
+	}
+
+	err := c.send(ctx, http.MethodPut, dkgActorsPath+"/"+formID, req, nil)
+	if err != nil {
+		return xerrors.Errorf("failed to %s: %w", action, err)
+	}
+
+	return nil
+}
+
+// send sends the request and JSON decodes the response into out, unless it is
+// nil. The message is signed if it is not nil. The request is sent again if
+// the proxy can't be reached, or answers with a status that asks to retry.
+func (c *Client) send(ctx context.Context, method, path string, msg, out interface{}) error {
+	delay := c.RetryDelay
+
+	write := !isRead(method) && !c.RetryAllWrites
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.do(ctx, method, path, msg)
+		if err == nil && !retryable(resp.StatusCode, write) {
+			return decodeResponse(resp, out)
+		}
+
+		// the write may have been processed if the proxy couldn't be reached
+		// or didn't answer
+		if attempt >= c.Retries || ctx.Err() != nil || (err != nil && write) {
+			if err != nil {
+				return err
+			}
+
+			return decodeResponse(resp, out)
+		}
+
+		wait := delay
+		delay *= 2
+
+		if err == nil {
+			wait = retryAfter(resp, wait)
+			resp.Body.Close()
+		}
+
+		err = c.sleep(ctx, wait)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// do sends one request, signed if the message is not nil.
+func (c *Client) do(ctx context.Context, method, path string, msg interface{}) (*http.Response, error) {
+	target := c.proxyAddr + path
+
+	var body io.Reader
+
+	if msg != nil {
+		u, err := url.Parse(target)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to parse URL: %v", err)
+		}
+
+		signed, err := SignRequest(c.secret, c.keyID, method, u.Path, c.now(), msg)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to sign request: %v", err)
+		}
+
+		body = bytes.NewReader(signed)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create request: %v", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to send request: %v", err)
+	}
+
+	return resp, nil
+}
+
+// sleep waits for the duration, or returns an error if the context is done
+// before.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isRead returns true if the request with the method doesn't change the
+// state, so that it can be sent again safely.
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// retryable returns true if the request can be sent again after a response
+// with the status. A write is only sent again if the proxy refused it before
+// processing it.
+func retryable(status int, write bool) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return !write
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay of the Retry-After header of the response, in
+// seconds, or the default delay if there is none.
+func retryAfter(resp *http.Response, def time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return def
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// decodeResponse closes the body of the response after JSON decoding it into
+// out if the status is 200. Otherwise it returns an *Error.
+func decodeResponse(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		buf, _ := io.ReadAll(resp.Body)
+
+		proxyErr := &Error{
+			StatusCode: resp.StatusCode,
+			Body:       string(bytes.TrimSpace(buf)),
+		}
+
+		var httpErr ptypes.HTTPError
+
+		err := json.Unmarshal(buf, &httpErr)
+		if err == nil && httpErr.Code != 0 {
+			proxyErr.HTTPError = &httpErr
+		}
+
+		return proxyErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	err := json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return xerrors.Errorf("failed to decode response: %v", err)
+	}
+
+	return nil
+}
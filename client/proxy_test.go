@@ -0,0 +1,510 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	eproxy "go.dedis.ch/d-voting/proxy"
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/d-voting/services/dkg"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/store"
+	sjson "go.dedis.ch/dela/serde/json"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestClient_Scenario(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	client := p.newClient(p.secret)
+	ctx := context.Background()
+
+	created, err := client.CreateForm(ctx, "admin", configuration)
+	require.NoError(t, err)
+
+	txID, err := hex.DecodeString(created.Token)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(txID)
+	require.Equal(t, hex.EncodeToString(digest[:]), created.FormID)
+
+	info, err := client.WaitTransaction(ctx, created.Token)
+	require.NoError(t, err)
+	require.Equal(t, txnmanager.IncludedTransaction, info.Status)
+
+	err = client.InitDKG(ctx, formID)
+	require.NoError(t, err)
+
+	err = client.SetupDKG(ctx, formID)
+	require.NoError(t, err)
+
+	err = client.WaitDKGSetup(ctx, formID)
+	require.NoError(t, err)
+
+	_, err = client.OpenForm(ctx, formID)
+	require.NoError(t, err)
+
+	info, err = client.CastVote(ctx, formID, "user1", ballot)
+	require.NoError(t, err)
+
+	_, err = client.WaitTransaction(ctx, info.Token)
+	require.NoError(t, err)
+
+	_, err = client.CloseForm(ctx, formID)
+	require.NoError(t, err)
+
+	err = client.Shuffle(ctx, formID)
+	require.NoError(t, err)
+
+	err = client.ComputePubshares(ctx, formID)
+	require.NoError(t, err)
+
+	p.setStatus(types.PubSharesSubmitted)
+
+	_, err = client.CombineShares(ctx, formID)
+	require.NoError(t, err)
+
+	_, err = client.CancelForm(ctx, formID)
+	require.NoError(t, err)
+
+	require.Equal(t, []evoting.Command{
+		evoting.CmdCreateForm,
+		evoting.CmdOpenForm,
+		evoting.CmdCastVote,
+		evoting.CmdCloseForm,
+		evoting.CmdCombineShares,
+		evoting.CmdCancelForm,
+	}, p.mngr.cmds)
+
+	_, err = client.GetResults(ctx, formID)
+	require.ErrorContains(t, err, "the result is not available")
+
+	form := p.srvc.Forms[formID]
+	form.DecryptedBallots = []types.Ballot{ballot}
+	p.srvc.Forms[formID] = form
+
+	p.setStatus(types.ResultAvailable)
+
+	results, err := client.GetResults(ctx, formID)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.True(t, ballot.Equal(results[0]))
+}
+
+func TestClient_WaitTransaction(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	client := p.newClient(p.secret)
+
+	_, err := client.WaitTransaction(context.Background(), rejectedToken)
+	require.EqualError(t, err, "transaction rejected: UNAUTHORIZED: not the admin")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = client.WaitTransaction(ctx, "aa")
+	require.ErrorContains(t, err, "failed to get transaction status")
+}
+
+func TestClient_WaitDKGSetup(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	client := p.newClient(p.secret)
+
+	err := client.WaitDKGSetup(context.Background(), "beef")
+	require.EqualError(t, err, "failed to get DKG status: 404 Not Found: actor not found")
+
+	p.actor.err.Store(true)
+
+	err = client.WaitDKGSetup(context.Background(), formID)
+	require.EqualError(t, err, "DKG setup failed: oops")
+}
+
+func TestClient_Errors(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	ctx := context.Background()
+
+	// the proxy refuses a request signed by another key
+	client := p.newClient(suite.Scalar().Pick(random.New()))
+
+	_, err := client.OpenForm(ctx, formID)
+	require.ErrorContains(t, err, "failed to open form: 403 Forbidden: "+
+		"failed to get and verify signed request")
+
+	var proxyErr *Error
+	require.True(t, errors.As(err, &proxyErr))
+	require.Equal(t, http.StatusForbidden, proxyErr.StatusCode)
+	require.NotNil(t, proxyErr.HTTPError)
+	require.Equal(t, uint(http.StatusForbidden), proxyErr.HTTPError.Code)
+	require.Equal(t, ptypes.CodeInvalidSignature, proxyErr.HTTPError.Reason)
+
+	client = p.newClient(p.secret)
+
+	_, err = client.updateForm(ctx, formID, "fake")
+	require.True(t, errors.As(err, &proxyErr))
+	require.Equal(t, uint(http.StatusBadRequest), proxyErr.HTTPError.Code)
+	require.Equal(t, "invalid action: fake", proxyErr.HTTPError.Args["error"])
+	require.Equal(t, ptypes.CodeInvalidAction, proxyErr.HTTPError.Reason)
+
+	_, err = client.OpenForm(ctx, "beef")
+	require.EqualError(t, err, "failed to open form: 404 Not Found: form beef not found")
+
+	require.True(t, errors.As(err, &proxyErr))
+	require.Equal(t, ptypes.CodeFormNotFound, proxyErr.HTTPError.Reason)
+
+	_, err = client.GetForm(ctx, "beef")
+	require.EqualError(t, err, "failed to get form: 404 Not Found: form beef not found")
+
+	// plain text errors are kept as they are
+	p.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "oops", http.StatusBadGateway)
+	})
+
+	client.Retries = 0
+
+	_, err = client.GetForm(ctx, formID)
+	require.EqualError(t, err, "failed to get form: unexpected status: 502 Bad Gateway, "+
+		"body: oops")
+
+	require.True(t, errors.As(err, &proxyErr))
+	require.Nil(t, proxyErr.HTTPError)
+}
+
+func TestClient_Retry(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	var failures int32 = 2
+
+	// the proxy is unavailable for the first requests
+	p.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&failures, -1) >= 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		p.router.ServeHTTP(w, r)
+	})
+
+	client := p.newClient(p.secret)
+
+	// each retry is signed again, which the proxy must accept
+	_, err := client.OpenForm(context.Background(), formID)
+	require.NoError(t, err)
+	require.Equal(t, int32(-1), atomic.LoadInt32(&failures))
+
+	atomic.StoreInt32(&failures, 2)
+	client.Retries = 1
+
+	_, err = client.OpenForm(context.Background(), formID)
+	require.EqualError(t, err, "failed to open form: unexpected status: 503 Service Unavailable, body: ")
+
+	// the proxy can't be reached
+	client = NewClient("http://127.0.0.1:0", p.secret, "")
+	client.RetryDelay = time.Millisecond
+
+	_, err = client.GetForm(context.Background(), formID)
+	require.ErrorContains(t, err, "failed to send request")
+}
+
+func TestClient_RetryWrites(t *testing.T) {
+	p := newProxy(t)
+	defer p.server.Close()
+
+	var requests int32
+
+	// the gateway of the proxy times out, but the request may have been
+	// processed
+	p.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	client := p.newClient(p.secret)
+	client.RetryDelay = time.Millisecond
+
+	_, err := client.OpenForm(context.Background(), formID)
+	require.EqualError(t, err, "failed to open form: unexpected status: 504 Gateway Timeout, body: ")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the reads are retried
+	atomic.StoreInt32(&requests, 0)
+
+	_, err = client.GetForm(context.Background(), formID)
+	require.Error(t, err)
+	require.Equal(t, int32(1+DefaultRetries), atomic.LoadInt32(&requests))
+
+	// unless the writes are retried too
+	atomic.StoreInt32(&requests, 0)
+	client.RetryAllWrites = true
+
+	_, err = client.OpenForm(context.Background(), formID)
+	require.Error(t, err)
+	require.Equal(t, int32(1+DefaultRetries), atomic.LoadInt32(&requests))
+
+	// a write is not retried if the proxy can't be reached
+	var attempts int32
+
+	client = NewClient("http://127.0.0.1:0", p.secret, "")
+	client.RetryDelay = time.Millisecond
+	client.HTTPClient = &http.Client{Transport: roundTripper(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, errors.New("connection reset")
+	})}
+
+	_, err = client.OpenForm(context.Background(), formID)
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	require.Equal(t, time.Second, retryAfter(resp, time.Second))
+
+	resp.Header.Set("Retry-After", "3")
+	require.Equal(t, 3*time.Second, retryAfter(resp, time.Second))
+
+	resp.Header.Set("Retry-After", "-1")
+	require.Equal(t, time.Second, retryAfter(resp, time.Second))
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// roundTripper is a function implementing http.RoundTripper
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (fn roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+// rejectedToken is the token of a transaction that the fake transaction
+// manager rejects.
+const rejectedToken = "ff"
+
+// proxy is an in-process proxy serving the handlers of the forms, the DKG and
+// the shuffle over fake services.
+type proxy struct {
+	server *httptest.Server
+	router *mux.Router
+	secret kyber.Scalar
+	srvc   *fake.Service
+	mngr   *fakeManager
+	actor  *fakeActor
+}
+
+func newProxy(t *testing.T) proxy {
+	secret := suite.Scalar().Pick(random.New())
+	verifier := ptypes.NewKeyVerifier(suite.Point().Mul(secret, nil))
+
+	ctx := sjson.NewContext()
+	roster := authority.FromAuthority(fake.NewAuthority(2, fake.NewSigner))
+	formFac := types.NewFormFactory(types.CiphervoteFactory{}, fake.NewRosterFac(roster))
+
+	srvc := fake.NewService(formID, types.Form{
+		FormID:        formID,
+		Configuration: configuration,
+		Status:        types.Open,
+		Pubkey:        suite.Point().Pick(random.New()),
+		BallotSize:    60,
+		Roster:        roster,
+	}, ctx)
+
+	require.NoError(t, types.InitFormsIndex(srvc.BallotSnap))
+	require.NoError(t, types.AddFormToIndex(srvc.BallotSnap, formID, types.Open))
+
+	formIDBuf, err := hex.DecodeString(formID)
+	require.NoError(t, err)
+
+	actor := &fakeActor{}
+	pedersen := fake.Pedersen{Actors: map[string]dkg.Actor{string(formIDBuf): actor}}
+
+	mngr := &fakeManager{polls: make(map[string]int)}
+
+	ep := eproxy.NewForm(service{&srvc}, &fake.Pool{}, ctx, formFac, verifier, mngr,
+		ratelimit.NewLimiter(ratelimit.Config{}))
+	dp := eproxy.NewDKG(nil, pedersen, verifier, drain.NewTracker(fake.NewInMemoryDB(), 0))
+	sp := eproxy.NewShuffle(fakeShuffle{}, verifier)
+
+	router := mux.NewRouter()
+	router.HandleFunc(formsPath, ep.NewForm).Methods(http.MethodPost)
+	router.HandleFunc(formsPath+"/{formID}", ep.Form).Methods(http.MethodGet)
+	router.HandleFunc(formsPath+"/{formID}", ep.EditForm).Methods(http.MethodPut)
+	router.HandleFunc(formsPath+"/{formID}/vote", ep.NewFormVote).Methods(http.MethodPost)
+	router.HandleFunc(transactionsPath+"{token}", mngr.StatusHandlerGet).Methods(http.MethodGet)
+	router.HandleFunc(dkgActorsPath, dp.NewDKGActor).Methods(http.MethodPost)
+	router.HandleFunc(dkgActorsPath+"/{formID}", dp.Actor).Methods(http.MethodGet)
+	router.HandleFunc(dkgActorsPath+"/{formID}", dp.EditDKGActor).Methods(http.MethodPut)
+	router.HandleFunc(shufflePath+"{formID}", sp.EditShuffle).Methods(http.MethodPut)
+
+	return proxy{
+		server: httptest.NewServer(router),
+		router: router,
+		secret: secret,
+		srvc:   &srvc,
+		mngr:   mngr,
+		actor:  actor,
+	}
+}
+
+// service is a fake ordering service whose store returns no value for the
+// keys that don't exist, as the store of a node does.
+type service struct {
+	*fake.Service
+}
+
+func (s service) GetStore() store.Readable {
+	return emptyStore{Readable: s.Service.GetStore()}
+}
+
+type emptyStore struct {
+	store.Readable
+}
+
+func (s emptyStore) Get(key []byte) ([]byte, error) {
+	buf, err := s.Readable.Get(key)
+	if err != nil {
+		return nil, nil
+	}
+
+	return buf, nil
+}
+
+// setStatus sets the status of the form, as if the smart contract changed it.
+func (p proxy) setStatus(status types.Status) {
+	form := p.srvc.Forms[formID]
+	form.Status = status
+	p.srvc.Forms[formID] = form
+}
+
+func (p proxy) newClient(secret kyber.Scalar) *Client {
+	client := NewClient(p.server.URL, secret, "")
+	client.RetryDelay = time.Millisecond
+	client.PollInterval = time.Millisecond
+
+	return client
+}
+
+// fakeManager is a transaction manager that records the commands of the
+// submitted transactions. The token of a transaction is its hex-encoded ID,
+// and a transaction is included the second time its status is requested.
+//
+// - implements txnmanager.Manager
+type fakeManager struct {
+	sync.Mutex
+	txnmanager.Manager
+
+	cmds  []evoting.Command
+	polls map[string]int
+}
+
+func (m *fakeManager) Submit(r *http.Request, cmd evoting.Command, cmdArg string,
+	payload []byte) (txnmanager.Submission, error) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.cmds = append(m.cmds, cmd)
+
+	return txnmanager.Submission{TransactionID: []byte{byte(len(m.cmds))}}, nil
+}
+
+func (m *fakeManager) SendSubmission(w http.ResponseWriter, submission txnmanager.Submission) error {
+	info, err := m.CreateTransactionResult(submission.TransactionID, submission.BlockIdx,
+		submission.Status)
+	if err != nil {
+		return err
+	}
+
+	return txnmanager.SendResponse(w, info)
+}
+
+func (m *fakeManager) CreateTransactionResult(txnID []byte, lastBlockIdx uint64,
+	status txnmanager.TransactionStatus) (txnmanager.TransactionClientInfo, error) {
+
+	return txnmanager.TransactionClientInfo{
+		Status: status,
+		Token:  hex.EncodeToString(txnID),
+	}, nil
+}
+
+func (m *fakeManager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	token := mux.Vars(r)["token"]
+
+	info := txnmanager.TransactionClientInfo{Token: token}
+
+	switch {
+	case token == rejectedToken:
+		info.Status = txnmanager.RejectedTransaction
+		info.Code = txnmanager.CodeUnauthorized
+		info.Message = "not the admin"
+	case m.polls[token] > 0:
+		info.Status = txnmanager.IncludedTransaction
+	}
+
+	m.polls[token]++
+
+	txnmanager.SendResponse(w, info)
+}
+
+// fakeShuffle is a shuffle actor that always succeeds.
+//
+// - implements shuffle.Actor
+type fakeShuffle struct{}
+
+func (fakeShuffle) Shuffle(formID []byte) error {
+	return nil
+}
+
+// fakeActor is a DKG actor that is set up once Setup is called, or failed if
+// err is set.
+//
+// - implements dkg.Actor
+type fakeActor struct {
+	fake.DKGActor
+
+	setup atomic.Bool
+	err   atomic.Bool
+}
+
+func (a *fakeActor) Setup() (kyber.Point, error) {
+	a.setup.Store(true)
+	return nil, nil
+}
+
+func (a *fakeActor) Status() dkg.Status {
+	switch {
+	case a.err.Load():
+		return dkg.Status{Status: dkg.Failed, Err: errors.New("oops")}
+	case a.setup.Load():
+		return dkg.Status{Status: dkg.Setup}
+	default:
+		return dkg.Status{Status: dkg.Initialized}
+	}
+}
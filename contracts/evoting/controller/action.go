@@ -10,7 +10,6 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"go.dedis.ch/kyber/v3"
@@ -18,9 +17,13 @@ import (
 	"go.dedis.ch/kyber/v3/suites"
 
 	"github.com/gorilla/mux"
+	vclient "go.dedis.ch/d-voting/client"
 	"go.dedis.ch/d-voting/contracts/evoting/types"
 	"go.dedis.ch/d-voting/internal/testing/fake"
 	eproxy "go.dedis.ch/d-voting/proxy"
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/keyring"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
 	"go.dedis.ch/d-voting/proxy/txnmanager"
 	ptypes "go.dedis.ch/d-voting/proxy/types"
 	"go.dedis.ch/d-voting/services/dkg"
@@ -50,6 +53,10 @@ const (
 	// FormPathSlash is the path to the form with a trailing slash
 	FormPathSlash          = formPath + "/"
 	formIDPath             = FormPathSlash + "{formID}"
+	templatePath           = "/evoting/templates"
+	templatePathSlash      = templatePath + "/"
+	templateNamePath       = templatePathSlash + "{templateName}"
+	eventsPath             = "/evoting/events"
 	transactionSlash       = "/evoting/transactions/"
 	transactionPath        = transactionSlash + "{token}"
 	unexpectedStatus       = "unexpected status: %s, body: %s"
@@ -155,23 +162,63 @@ func (a *RegisterAction) Execute(ctx node.Context) error {
 	formFac := types.NewFormFactory(types.CiphervoteFactory{}, rosterFac)
 	mngr := getManager(signer, client)
 
-	proxykeyHex := ctx.Flags.String("proxykey")
+	var keys *keyring.Keyring
+	err = ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
 
-	proxykeyBuf, err := hex.DecodeString(proxykeyHex)
+	// the proxy key is the default key of the keyring
+	err = keys.SetDefault(ctx.Flags.String("proxykey"))
 	if err != nil {
-		return xerrors.Errorf("failed to decode proxykeyHex: %v", err)
+		return xerrors.Errorf("failed to set proxy key: %v", err)
 	}
 
-	proxykey := suite.Point()
+	limiter := ratelimit.NewLimiter(ratelimit.Config{
+		FormVotes: ctx.Flags.Int("formvoterate"),
+		UserVotes: ctx.Flags.Int("uservoterate"),
+		Pool:      ctx.Flags.Int("poolrate"),
+		FormBurst: ctx.Flags.Int("formvoteburst"),
+	})
+
+	transactionManager := txnmanager.NewTransactionManager(mngr, p, ordering, sjson.NewContext(),
+		blocks, signer, ctx.Flags.Duration("txnretention"), limiter)
+
+	ep := eproxy.NewForm(ordering, p, sjson.NewContext(), formFac, keys, transactionManager, limiter)
+
+	tp := eproxy.NewTemplate(ordering, sjson.NewContext(), keys, transactionManager)
+
+	evp := eproxy.NewEvents(ordering, sjson.NewContext(), formFac, transactionManager)
 
-	err = proxykey.UnmarshalBinary(proxykeyBuf)
+	var tracker *drain.Tracker
+	err = ctx.Injector.Resolve(&tracker)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
+		return xerrors.Errorf("failed to resolve tracker: %v", err)
 	}
 
-	transactionManager := txnmanager.NewTransactionManager(mngr, p, sjson.NewContext(), proxykey, blocks, signer)
+	// the node waits for the transactions submitted by the proxy when it
+	// stops
+	tracker.OnDrain(transactionManager.WaitPending)
 
-	ep := eproxy.NewForm(ordering, p, sjson.NewContext(), formFac, proxykey, transactionManager)
+	router := tracker.Handler(newRouter(ep, tp, evp, transactionManager))
+
+	proxy.RegisterHandler(formPath, router.ServeHTTP)
+	proxy.RegisterHandler(FormPathSlash, router.ServeHTTP)
+	proxy.RegisterHandler(templatePath, router.ServeHTTP)
+	proxy.RegisterHandler(templatePathSlash, router.ServeHTTP)
+	proxy.RegisterHandler(transactionSlash, router.ServeHTTP)
+	proxy.RegisterHandler(eventsPath, router.ServeHTTP)
+	proxy.RegisterHandler(eproxy.OpenAPIPath, router.ServeHTTP)
+
+	dela.Logger.Info().Msg("d-voting proxy handlers registered")
+
+	return nil
+}
+
+// newRouter returns the router of the forms, templates, transactions and
+// events handlers. Each route must be described in eproxy.Operations.
+func newRouter(ep eproxy.Form, tp eproxy.Template, evp eproxy.Events,
+	transactionManager txnmanager.Manager) *mux.Router {
 
 	router := mux.NewRouter()
 
@@ -183,18 +230,24 @@ func (a *RegisterAction) Execute(ctx node.Context) error {
 	router.HandleFunc(formIDPath, eproxy.AllowCORS).Methods("OPTIONS")
 	router.HandleFunc(formIDPath, ep.DeleteForm).Methods("DELETE")
 	router.HandleFunc(formIDPath+"/vote", ep.NewFormVote).Methods("POST")
+	router.HandleFunc(formIDPath+"/votes:batch", ep.NewFormVotes).Methods("POST")
+	router.HandleFunc(formIDPath+"/clone", ep.CloneForm).Methods("POST")
+	router.HandleFunc(templatePath, tp.NewTemplate).Methods("POST")
+	router.HandleFunc(templatePath, tp.Templates).Methods("GET")
+	router.HandleFunc(templatePath, eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc(templateNamePath, tp.Template).Methods("GET")
+	router.HandleFunc(templateNamePath, eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc(templateNamePath+"/forms", tp.NewTemplateForm).Methods("POST")
 	router.HandleFunc(transactionPath, transactionManager.StatusHandlerGet).Methods("GET")
+	router.HandleFunc(eventsPath, evp.Events).Methods("GET")
+	router.HandleFunc(eventsPath, eproxy.AllowCORS).Methods("OPTIONS")
+	router.HandleFunc(eproxy.OpenAPIPath, eproxy.OpenAPI).Methods("GET")
+	router.HandleFunc(eproxy.OpenAPIPath, eproxy.AllowCORS).Methods("OPTIONS")
 
 	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
 	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
 
-	proxy.RegisterHandler(formPath, router.ServeHTTP)
-	proxy.RegisterHandler(FormPathSlash, router.ServeHTTP)
-	proxy.RegisterHandler(transactionSlash, router.ServeHTTP)
-
-	dela.Logger.Info().Msg("d-voting proxy handlers registered")
-
-	return nil
+	return router
 }
 
 // getSigner creates a signer from a file.
@@ -449,7 +502,7 @@ func (a *scenarioTestAction) Execute(ctx node.Context) error {
 		return xerrors.Errorf(getFormErr, err)
 	}
 
-	dela.Logger.Info().Msg("Title of the form: " + form.Configuration.Title.En)
+	dela.Logger.Info().Msg("Title of the form: " + form.Configuration.Title.Text.Get("en"))
 	dela.Logger.Info().Msg("Status of the form: " + strconv.Itoa(int(form.Status)))
 
 	// ###################################### SHUFFLE BALLOTS ##################
@@ -650,7 +703,7 @@ func setupSimpleForm(ctx node.Context, secret kyber.Scalar, proxyAddr1 string,
 		return "", types.Form{}, nil, xerrors.Errorf("formID mismatch: %s != %s", form.FormID, formID)
 	}
 
-	fmt.Fprintf(ctx.Out, "Title of the form: "+form.Configuration.Title.En)
+	fmt.Fprintf(ctx.Out, "Title of the form: "+form.Configuration.Title.Text.Get("en"))
 	fmt.Fprintf(ctx.Out, "ID of the form: "+form.FormID)
 	fmt.Fprintf(ctx.Out, "Status of the form: "+strconv.Itoa(int(form.Status)))
 
@@ -658,7 +711,7 @@ func setupSimpleForm(ctx node.Context, secret kyber.Scalar, proxyAddr1 string,
 }
 
 func logFormStatus(form types.Form) {
-	dela.Logger.Info().Msg("Title of the form : " + form.Configuration.Title.En)
+	dela.Logger.Info().Msg("Title of the form : " + form.Configuration.Title.Text.Get("en"))
 	dela.Logger.Info().Msg("ID of the form : " + form.FormID)
 	dela.Logger.Info().Msg("Status of the form : " + strconv.Itoa(int(form.Status)))
 }
@@ -667,45 +720,14 @@ func encodeID(ID string) types.ID {
 	return types.ID(base64.StdEncoding.EncodeToString([]byte(ID)))
 }
 
+// marshallBallot encrypts the vote with the public key of the DKG actor.
 func marshallBallot(voteStr string, actor dkg.Actor, chunks int) (ptypes.CiphervoteJSON, error) {
-
-	var ballot = make(ptypes.CiphervoteJSON, chunks)
-	vote := strings.NewReader(voteStr)
-
-	buf := make([]byte, 29)
-
-	for i := 0; i < chunks; i++ {
-		var K, C kyber.Point
-		var err error
-
-		n, err := vote.Read(buf)
-		if err != nil {
-			return nil, xerrors.Errorf("failed to read: %v", err)
-		}
-
-		K, C, _, err = actor.Encrypt(buf[:n])
-
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to encrypt the plaintext: %v", err)
-		}
-
-		kbuff, err := K.MarshalBinary()
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to marshal K: %v", err)
-		}
-
-		cbuff, err := C.MarshalBinary()
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to marshal C: %v", err)
-		}
-
-		ballot[i] = ptypes.EGPairJSON{
-			K: kbuff,
-			C: cbuff,
-		}
+	pubkey, err := actor.GetPublicKey()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get public key: %v", err)
 	}
 
-	return ballot, nil
+	return vclient.EncryptBallot(voteStr, pubkey, chunks)
 }
 
 // formID is hex-encoded
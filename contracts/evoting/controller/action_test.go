@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	eproxy "go.dedis.ch/d-voting/proxy"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	sjson "go.dedis.ch/dela/serde/json"
+)
+
+func TestNewRouter_OpenAPI(t *testing.T) {
+	ctx := sjson.NewContext()
+
+	router := newRouter(eproxy.NewForm(nil, nil, ctx, nil, nil, nil, nil),
+		eproxy.NewTemplate(nil, ctx, nil, nil), eproxy.NewEvents(nil, ctx, nil, nil),
+		fakeManager{})
+
+	missing, err := eproxy.MissingOperations(router)
+	require.NoError(t, err)
+	require.Empty(t, missing, "routes without an OpenAPI operation in proxy/openapi.go")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// fakeManager is a transaction manager that only serves the status of the
+// transactions.
+//
+// - implements txnmanager.Manager
+type fakeManager struct {
+	txnmanager.Manager
+}
+
+func (fakeManager) StatusHandlerGet(http.ResponseWriter, *http.Request) {}
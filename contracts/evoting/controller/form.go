@@ -0,0 +1,517 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/output"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	"go.dedis.ch/d-voting/services/dkg"
+	"go.dedis.ch/d-voting/services/shuffle"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/txn/pool"
+	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/serde"
+	sjson "go.dedis.ch/dela/serde/json"
+	"golang.org/x/xerrors"
+)
+
+// rosterService is an ordering service that has a roster, such as cosipbft.
+type rosterService interface {
+	ordering.Service
+
+	GetRoster() (authority.Authority, error)
+}
+
+// FormRosterMember is a member of the roster of a form as printed by the forms
+// roster command.
+type FormRosterMember struct {
+	Index   int
+	Address string
+	// InChain is true if the member is still in the roster of the chain
+	InChain bool
+	// Shuffled is true if the member submitted an accepted shuffle
+	Shuffled bool
+	// PubShares is true if the member submitted its public shares
+	PubShares bool
+}
+
+// FormRoster is the roster of a form as printed by the forms roster command.
+type FormRoster struct {
+	FormID           string
+	Status           types.Status
+	ShuffleThreshold int
+	Members          []FormRosterMember
+}
+
+// formsRosterAction is an action to print the roster of a form, and what each
+// member has done for the form.
+//
+// - implements node.ActionTemplate
+type formsRosterAction struct{}
+
+// Execute implements node.ActionTemplate. It prints the members of the roster
+// of the form, whether they are still in the chain, and whether they
+// submitted their shuffle and public shares.
+func (formsRosterAction) Execute(ctx node.Context) error {
+	var srvc ordering.Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	form, err := getFormFromStore(ctx, srvc, ctx.Flags.String("formID"))
+	if err != nil {
+		return err
+	}
+
+	var chainRoster authority.Authority
+
+	rosterSrvc, ok := srvc.(rosterService)
+	if ok {
+		chainRoster, err = rosterSrvc.GetRoster()
+		if err != nil {
+			return xerrors.Errorf("failed to get roster: %v", err)
+		}
+	}
+
+	formRoster, err := newFormRoster(form, chainRoster)
+	if err != nil {
+		return err
+	}
+
+	table := output.Table{Header: []string{"INDEX", "ADDRESS", "IN CHAIN", "SHUFFLED",
+		"PUBSHARES"}}
+
+	for _, member := range formRoster.Members {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(member.Index),
+			member.Address,
+			strconv.FormatBool(member.InChain),
+			strconv.FormatBool(member.Shuffled),
+			strconv.FormatBool(member.PubShares),
+		})
+	}
+
+	return output.Print(ctx.Out, ctx.Flags.Bool("json"), formRoster, table)
+}
+
+// newFormRoster returns the roster of the form. The members are not in the
+// chain if the roster of the chain is nil.
+func newFormRoster(form types.Form, chainRoster authority.Authority) (FormRoster, error) {
+	formRoster := FormRoster{
+		FormID:           form.FormID,
+		Status:           form.Status,
+		ShuffleThreshold: form.ShuffleThreshold,
+		Members:          []FormRosterMember{},
+	}
+
+	if form.Roster == nil {
+		return formRoster, nil
+	}
+
+	addrs := form.Roster.AddressIterator()
+	pubkeys := form.Roster.PublicKeyIterator()
+
+	for i := 0; addrs.HasNext() && pubkeys.HasNext(); i++ {
+		addr := addrs.GetNext()
+
+		pubkey, err := pubkeys.GetNext().MarshalBinary()
+		if err != nil {
+			return formRoster, xerrors.Errorf("failed to marshal public key %d: %v", i, err)
+		}
+
+		member := FormRosterMember{
+			Index:   i,
+			Address: addr.String(),
+		}
+
+		if chainRoster != nil {
+			_, index := chainRoster.GetPublicKey(addr)
+			member.InChain = index >= 0
+		}
+
+		for _, instance := range form.ShuffleInstances {
+			if bytes.Equal(instance.ShufflerPublicKey, pubkey) {
+				member.Shuffled = true
+			}
+		}
+
+		for _, submitter := range form.PubsharesUnits.PubKeys {
+			if bytes.Equal(submitter, pubkey) {
+				member.PubShares = true
+			}
+		}
+
+		formRoster.Members = append(formRoster.Members, member)
+	}
+
+	return formRoster, nil
+}
+
+// getFormFromStore returns the form with the given hex-encoded ID from the
+// store of the ordering service.
+func getFormFromStore(ctx node.Context, srvc ordering.Service, formID string) (types.Form, error) {
+	var rosterFac authority.Factory
+	err := ctx.Injector.Resolve(&rosterFac)
+	if err != nil {
+		return types.Form{}, xerrors.Errorf("failed to resolve authority factory: %v", err)
+	}
+
+	formFac := types.NewFormFactory(types.CiphervoteFactory{}, rosterFac)
+
+	form, err := types.FormFromStore(sjson.NewContext(), formFac, formID, srvc.GetStore())
+	if err != nil {
+		return types.Form{}, xerrors.Errorf(getFormErr, err)
+	}
+
+	return form, nil
+}
+
+// formTxAction is an action to submit a transaction of the evoting contract
+// that only needs the ID of a form, such as opening or closing it.
+//
+// - implements node.ActionTemplate
+type formTxAction struct {
+	cmd   evoting.Command
+	newTx func(formID string) serde.Message
+}
+
+// Execute implements node.ActionTemplate. It submits the transaction through
+// the pool of the node.
+func (a formTxAction) Execute(ctx node.Context) error {
+	formID := ctx.Flags.String("formID")
+
+	_, err := hex.DecodeString(formID)
+	if err != nil {
+		return xerrors.Errorf("failed to decode formID: %v", err)
+	}
+
+	tx, err := submitFormTx(ctx, a.cmd, a.newTx(formID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ctx.Out, "transaction %x submitted\n", tx.GetID())
+
+	return nil
+}
+
+// formCreateAction is an action to create a form from a configuration file.
+//
+// - implements node.ActionTemplate
+type formCreateAction struct{}
+
+// Execute implements node.ActionTemplate. It reads the JSON configuration of
+// the form, submits the transaction that creates it and prints the ID of the
+// form, which is the hash of the transaction ID.
+func (formCreateAction) Execute(ctx node.Context) error {
+	path := ctx.Flags.String("file")
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return xerrors.Errorf("failed to read %q: %v", path, err)
+	}
+
+	var configuration types.Configuration
+
+	err = json.Unmarshal(buf, &configuration)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal configuration: %v", err)
+	}
+
+	createForm := types.CreateForm{
+		Configuration: configuration,
+		AdminID:       ctx.Flags.String("admin"),
+	}
+
+	tx, err := submitFormTx(ctx, evoting.CmdCreateForm, createForm)
+	if err != nil {
+		return err
+	}
+
+	formID := sha256.Sum256(tx.GetID())
+
+	fmt.Fprintln(ctx.Out, hex.EncodeToString(formID[:]))
+
+	return nil
+}
+
+// formShuffleAction is an action to shuffle the ballots of a closed form.
+//
+// - implements node.ActionTemplate
+type formShuffleAction struct{}
+
+// Execute implements node.ActionTemplate. It starts the shuffle on the node,
+// which returns once enough nodes of the roster of the form shuffled the
+// ballots.
+func (formShuffleAction) Execute(ctx node.Context) error {
+	formIDBuf, err := hex.DecodeString(ctx.Flags.String("formID"))
+	if err != nil {
+		return xerrors.Errorf("failed to decode formID: %v", err)
+	}
+
+	var actor shuffle.Actor
+	err = ctx.Injector.Resolve(&actor)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve shuffle actor: %v", err)
+	}
+
+	err = actor.Shuffle(formIDBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to shuffle: %v", err)
+	}
+
+	fmt.Fprintln(ctx.Out, "ballots shuffled")
+
+	return nil
+}
+
+// formDecryptAction is an action to decrypt the shuffled ballots of a form.
+//
+// - implements node.ActionTemplate
+type formDecryptAction struct{}
+
+// Execute implements node.ActionTemplate. The decryption is done in two steps:
+// once the ballots are shuffled, it requests the nodes to submit their public
+// shares, and once they are submitted, it submits the transaction that
+// combines them to get the result.
+func (formDecryptAction) Execute(ctx node.Context) error {
+	var srvc ordering.Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	formID := ctx.Flags.String("formID")
+
+	form, err := getFormFromStore(ctx, srvc, formID)
+	if err != nil {
+		return err
+	}
+
+	switch form.Status {
+	case types.ShuffledBallots:
+		var dkgService dkg.DKG
+		err = ctx.Injector.Resolve(&dkgService)
+		if err != nil {
+			return xerrors.Errorf("failed to resolve dkg.DKG: %v", err)
+		}
+
+		formIDBuf, err := hex.DecodeString(formID)
+		if err != nil {
+			return xerrors.Errorf("failed to decode formID: %v", err)
+		}
+
+		actor, found := dkgService.GetActor(formIDBuf)
+		if !found {
+			return xerrors.Errorf("no DKG actor for form %s", formID)
+		}
+
+		err = actor.ComputePubshares()
+		if err != nil {
+			return xerrors.Errorf("failed to compute pubshares: %v", err)
+		}
+
+		fmt.Fprintln(ctx.Out, "public shares requested, run decrypt again "+
+			"once they are submitted")
+	case types.PubSharesSubmitted:
+		tx, err := submitFormTx(ctx, evoting.CmdCombineShares, types.CombineShares{FormID: formID})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(ctx.Out, "transaction %x submitted\n", tx.GetID())
+	default:
+		return xerrors.Errorf("the ballots of the form must be shuffled, current "+
+			"status: %d", form.Status)
+	}
+
+	return nil
+}
+
+// formShowAction is an action to print the summary of a form.
+//
+// - implements node.ActionTemplate
+type formShowAction struct{}
+
+// Execute implements node.ActionTemplate.
+func (formShowAction) Execute(ctx node.Context) error {
+	summaries, err := getFormSummaries(ctx, []string{ctx.Flags.String("formID")})
+	if err != nil {
+		return err
+	}
+
+	return output.Print(ctx.Out, ctx.Flags.Bool("json"), summaries[0],
+		newSummariesTable(summaries))
+}
+
+// formListAction is an action to print the summaries of all the forms.
+//
+// - implements node.ActionTemplate
+type formListAction struct{}
+
+// Execute implements node.ActionTemplate. The forms are printed in the order
+// of their creation.
+func (formListAction) Execute(ctx node.Context) error {
+	var srvc ordering.Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	formIDs := []string{}
+
+	err = evoting.IterateForms(srvc.GetStore(), func(formID string) bool {
+		formIDs = append(formIDs, formID)
+		return true
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to list forms: %v", err)
+	}
+
+	summaries, err := getFormSummaries(ctx, formIDs)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(ctx.Out, ctx.Flags.Bool("json"), summaries,
+		newSummariesTable(summaries))
+}
+
+// getFormSummaries returns the summaries of the forms from the store of the
+// ordering service.
+func getFormSummaries(ctx node.Context, formIDs []string) ([]types.FormSummary, error) {
+	var srvc ordering.Service
+	err := ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	var rosterFac authority.Factory
+	err = ctx.Injector.Resolve(&rosterFac)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve authority factory: %v", err)
+	}
+
+	formFac := types.NewFormFactory(types.CiphervoteFactory{}, rosterFac)
+
+	summaries := make([]types.FormSummary, len(formIDs))
+
+	for i, formID := range formIDs {
+		summaries[i], err = types.FormSummaryFromStore(sjson.NewContext(), formFac, formID,
+			srvc.GetStore())
+		if err != nil {
+			return nil, xerrors.Errorf(getFormErr, err)
+		}
+	}
+
+	return summaries, nil
+}
+
+// newSummariesTable returns the table of the summaries of forms. The title is
+// printed in English.
+func newSummariesTable(summaries []types.FormSummary) output.Table {
+	table := output.Table{Header: []string{"FORM ID", "TITLE", "STATUS", "ADMIN",
+		"BALLOTS", "SHUFFLES", "PUBSHARES"}}
+
+	for _, summary := range summaries {
+		table.Rows = append(table.Rows, []string{
+			summary.FormID,
+			summary.Title.Text.Get("en"),
+			strconv.Itoa(int(summary.Status)),
+			summary.AdminID,
+			strconv.FormatUint(uint64(summary.BallotCount), 10),
+			strconv.Itoa(summary.ShuffleRounds),
+			strconv.Itoa(summary.PubsharesSubmissions),
+		})
+	}
+
+	return table
+}
+
+// submitFormTx submits a transaction of the evoting contract signed by the
+// key of the signer flag. If the wait flag is set, it waits at most this
+// duration for the transaction to be accepted in a block.
+func submitFormTx(ctx node.Context, cmd evoting.Command, msg serde.Message) (txn.Transaction, error) {
+	signer, err := getSigner(ctx.Flags.String("signer"))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get the signer: %v", err)
+	}
+
+	var p pool.Pool
+	err = ctx.Injector.Resolve(&p)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve pool.Pool: %v", err)
+	}
+
+	var srvc ordering.Service
+	err = ctx.Injector.Resolve(&srvc)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	var vs validation.Service
+	err = ctx.Injector.Resolve(&vs)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to resolve validation: %v", err)
+	}
+
+	data, err := msg.Serialize(sjson.NewContext())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to serialize transaction: %v", err)
+	}
+
+	mngr := getManager(signer, client{srvc: srvc, mgr: vs})
+
+	tx, err := txnmanager.CreateTransaction(mngr, cmd, evoting.FormArg, data)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create transaction: %v", err)
+	}
+
+	wait := ctx.Flags.Duration("wait")
+
+	// the blocks are watched before the submission so that the block
+	// including the transaction is not missed
+	watchCtx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+
+	events := srvc.Watch(watchCtx)
+
+	err = p.Add(tx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to add transaction: %v", err)
+	}
+
+	if wait <= 0 {
+		return tx, nil
+	}
+
+	for event := range events {
+		for _, res := range event.Transactions {
+			if !bytes.Equal(res.GetTransaction().GetID(), tx.GetID()) {
+				continue
+			}
+
+			accepted, message := res.GetStatus()
+			if !accepted {
+				return nil, xerrors.Errorf("transaction refused: %s", message)
+			}
+
+			return tx, nil
+		}
+	}
+
+	return nil, xerrors.Errorf("transaction not included after %s", wait)
+}
@@ -0,0 +1,371 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/services/dkg"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/txn/pool"
+	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/crypto"
+	"go.dedis.ch/dela/crypto/bls"
+	"go.dedis.ch/dela/mino"
+	"go.dedis.ch/dela/serde"
+	sjson "go.dedis.ch/dela/serde/json"
+)
+
+const formID = "deadbeef"
+
+func TestFormsRosterAction_Execute(t *testing.T) {
+	action := formsRosterAction{}
+
+	roster, pubkeys := newRoster(t, 3)
+
+	form := types.Form{
+		FormID:           formID,
+		Status:           types.ShuffledBallots,
+		ShuffleThreshold: 2,
+		Roster:           roster,
+		ShuffleInstances: []types.ShuffleInstance{
+			{ShufflerPublicKey: pubkeys[0]},
+			{ShufflerPublicKey: pubkeys[2]},
+		},
+		PubsharesUnits: types.PubsharesUnits{
+			PubKeys: [][]byte{pubkeys[2]},
+		},
+	}
+
+	// the third member left the chain
+	chainRoster := authority.New(
+		[]mino.Address{fake.NewAddress(0), fake.NewAddress(1)},
+		[]crypto.PublicKey{fake.PublicKey{}, fake.PublicKey{}})
+
+	ctx := node.Context{
+		Injector: node.NewInjector(),
+		Flags:    node.FlagSet{"formID": formID},
+	}
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err := action.Execute(ctx)
+	require.EqualError(t, err, "failed to resolve ordering.Service: couldn't find "+
+		"dependency for 'ordering.Service'")
+
+	ctx.Injector.Inject(&fakeRosterService{
+		Service: &fake.Service{
+			Forms:      map[string]types.Form{formID: form},
+			Context:    sjson.NewContext(),
+			BallotSnap: fake.NewSnapshot(),
+		},
+		roster: chainRoster,
+	})
+
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "failed to resolve authority factory: couldn't find "+
+		"dependency for 'authority.Factory'")
+
+	var rosterFac authority.Factory = fake.NewRosterFac(roster)
+	ctx.Injector.Inject(rosterFac)
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "INDEX  ADDRESS          IN CHAIN  SHUFFLED  PUBSHARES\n"+
+		"0      fake.Address[0]  true      true      false\n"+
+		"1      fake.Address[1]  true      false     false\n"+
+		"2      fake.Address[2]  false     true      true\n", buffer.String())
+
+	buffer.Reset()
+	ctx.Flags.(node.FlagSet)["json"] = true
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	var formRoster FormRoster
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &formRoster))
+	require.Equal(t, FormRoster{
+		FormID:           formID,
+		Status:           types.ShuffledBallots,
+		ShuffleThreshold: 2,
+		Members: []FormRosterMember{
+			{Index: 0, Address: "fake.Address[0]", InChain: true, Shuffled: true},
+			{Index: 1, Address: "fake.Address[1]", InChain: true},
+			{Index: 2, Address: "fake.Address[2]", Shuffled: true, PubShares: true},
+		},
+	}, formRoster)
+
+	ctx.Flags.(node.FlagSet)["formID"] = "beef"
+
+	err = action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to get form: while getting data for form")
+}
+
+func TestFormCreateAction_Execute(t *testing.T) {
+	action := formCreateAction{}
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "form.json")
+
+	ctx, srvc := newTxContext(t, dir)
+	ctx.Flags.(node.FlagSet)["file"] = configPath
+	ctx.Flags.(node.FlagSet)["admin"] = "123456"
+
+	err := action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to read")
+
+	err = os.WriteFile(configPath, []byte(`{`), 0600)
+	require.NoError(t, err)
+
+	err = action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to unmarshal configuration")
+
+	err = os.WriteFile(configPath, []byte(`{"Title":{"en":"title"}}`), 0600)
+	require.NoError(t, err)
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	formID := sha256.Sum256(srvc.Pool.Transaction.GetID())
+	require.Equal(t, hex.EncodeToString(formID[:])+"\n", buffer.String())
+
+	srvc.Status = false
+
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "transaction refused: ")
+}
+
+func TestFormTxAction_Execute(t *testing.T) {
+	action := formTxAction{
+		cmd: evoting.CmdOpenForm,
+		newTx: func(formID string) serde.Message {
+			return types.OpenForm{FormID: formID}
+		},
+	}
+
+	dir := t.TempDir()
+
+	ctx, srvc := newTxContext(t, dir)
+	ctx.Flags.(node.FlagSet)["formID"] = "not hex"
+
+	err := action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to decode formID")
+
+	ctx.Flags.(node.FlagSet)["formID"] = formID
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("transaction %x submitted\n",
+		srvc.Pool.Transaction.GetID()), buffer.String())
+
+	ctx.Flags.(node.FlagSet)["signer"] = filepath.Join(dir, "unknown.key")
+
+	err = action.Execute(ctx)
+	require.ErrorContains(t, err, "failed to get the signer")
+}
+
+func TestFormDecryptAction_Execute(t *testing.T) {
+	action := formDecryptAction{}
+
+	ctx, srvc := newTxContext(t, t.TempDir())
+	ctx.Flags.(node.FlagSet)["formID"] = formID
+
+	roster, _ := newRoster(t, 3)
+
+	var rosterFac authority.Factory = fake.NewRosterFac(roster)
+	ctx.Injector.Inject(rosterFac)
+
+	form := types.Form{FormID: formID, Status: types.Open, Roster: roster}
+	srvc.Forms = map[string]types.Form{formID: form}
+
+	err := action.Execute(ctx)
+	require.EqualError(t, err, "the ballots of the form must be shuffled, current status: 1")
+
+	form.Status = types.ShuffledBallots
+	srvc.Forms[formID] = form
+
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "failed to resolve dkg.DKG: couldn't find dependency for 'dkg.DKG'")
+
+	var dkgService dkg.DKG = fake.Pedersen{Actors: map[string]dkg.Actor{}}
+	ctx.Injector.Inject(dkgService)
+
+	err = action.Execute(ctx)
+	require.EqualError(t, err, "no DKG actor for form deadbeef")
+
+	formIDBuf, err := hex.DecodeString(formID)
+	require.NoError(t, err)
+
+	dkgService.(fake.Pedersen).Actors[string(formIDBuf)] = fake.DKGActor{}
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "public shares requested, run decrypt again once they are "+
+		"submitted\n", buffer.String())
+
+	form.Status = types.PubSharesSubmitted
+	srvc.Forms[formID] = form
+
+	buffer.Reset()
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("transaction %x submitted\n",
+		srvc.Pool.Transaction.GetID()), buffer.String())
+}
+
+func TestFormListAction_Execute(t *testing.T) {
+	action := formListAction{}
+
+	roster, _ := newRoster(t, 3)
+
+	snap := fake.NewSnapshot()
+	require.NoError(t, types.InitFormsIndex(snap))
+	require.NoError(t, types.AddFormToIndex(snap, formID, types.Open))
+
+	ctx := node.Context{
+		Injector: node.NewInjector(),
+		Flags:    node.FlagSet{},
+	}
+
+	buffer := new(bytes.Buffer)
+	ctx.Out = buffer
+
+	err := action.Execute(ctx)
+	require.EqualError(t, err, "failed to resolve ordering.Service: couldn't find "+
+		"dependency for 'ordering.Service'")
+
+	ctx.Injector.Inject(&fake.Service{
+		Forms: map[string]types.Form{formID: {
+			FormID:        formID,
+			Status:        types.Open,
+			AdminID:       "123456",
+			BallotCount:   2,
+			Roster:        roster,
+			Configuration: types.Configuration{Title: types.Title{Text: types.LangMap{"en": "title"}}},
+		}},
+		Context:    sjson.NewContext(),
+		BallotSnap: snap,
+	})
+
+	var rosterFac authority.Factory = fake.NewRosterFac(roster)
+	ctx.Injector.Inject(rosterFac)
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "FORM ID   TITLE  STATUS  ADMIN   BALLOTS  SHUFFLES  PUBSHARES\n"+
+		"deadbeef  title  1       123456  2        0         0\n", buffer.String())
+
+	buffer.Reset()
+	ctx.Flags.(node.FlagSet)["json"] = true
+
+	err = action.Execute(ctx)
+	require.NoError(t, err)
+
+	var summaries []types.FormSummary
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	require.Equal(t, "123456", summaries[0].AdminID)
+
+	buffer.Reset()
+	ctx.Flags.(node.FlagSet)["formID"] = formID
+
+	err = formShowAction{}.Execute(ctx)
+	require.NoError(t, err)
+
+	var summary types.FormSummary
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &summary))
+	require.Equal(t, summaries[0], summary)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// newRoster returns a roster of n members and their marshalled public keys.
+func newRoster(t *testing.T, n int) (authority.Roster, [][]byte) {
+	addrs := make([]mino.Address, n)
+	pubkeys := make([]crypto.PublicKey, n)
+	pubkeysBuf := make([][]byte, n)
+
+	for i := range addrs {
+		addrs[i] = fake.NewAddress(i)
+		pubkeys[i] = bls.NewSigner().GetPublicKey()
+
+		buf, err := pubkeys[i].MarshalBinary()
+		require.NoError(t, err)
+
+		pubkeysBuf[i] = buf
+	}
+
+	return authority.New(addrs, pubkeys), pubkeysBuf
+}
+
+type fakeRosterService struct {
+	*fake.Service
+
+	roster authority.Authority
+}
+
+func (s *fakeRosterService) GetRoster() (authority.Authority, error) {
+	return s.roster, nil
+}
+
+// newTxContext returns the context of an action that submits a transaction
+// with a signer stored in the directory. The transactions are accepted until
+// the Status of the service is set to false.
+func newTxContext(t *testing.T, dir string) (node.Context, *fake.Service) {
+	signerPath := filepath.Join(dir, "private.key")
+
+	buf, err := bls.NewSigner().MarshalBinary()
+	require.NoError(t, err)
+
+	err = os.WriteFile(signerPath, buf, 0600)
+	require.NoError(t, err)
+
+	srvc := &fake.Service{
+		Status:     true,
+		Context:    sjson.NewContext(),
+		BallotSnap: fake.NewSnapshot(),
+	}
+	srvc.Pool = &fake.Pool{Service: srvc}
+
+	var p pool.Pool = srvc.Pool
+	var vs validation.Service = fake.ValidationService{}
+
+	ctx := node.Context{
+		Injector: node.NewInjector(),
+		Flags: node.FlagSet{
+			"signer": signerPath,
+			"wait":   float64(time.Second),
+		},
+		Out: io.Discard,
+	}
+
+	ctx.Injector.Inject(srvc)
+	ctx.Injector.Inject(p)
+	ctx.Injector.Inject(vs)
+
+	return ctx, srvc
+}
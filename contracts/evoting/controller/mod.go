@@ -1,11 +1,42 @@
 package controller
 
 import (
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
 	"go.dedis.ch/dela/cli"
 	"go.dedis.ch/dela/cli/node"
 	"go.dedis.ch/dela/core/access"
 	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/serde"
+)
+
+var (
+	formIDFlag = cli.StringFlag{
+		Name:     "formID",
+		Usage:    "the hex-encoded ID of the form",
+		Required: true,
+	}
+
+	jsonFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "print the forms as JSON",
+	}
+
+	// txFlags are the flags of the commands that submit a transaction. The
+	// signer must be allowed to use the evoting contract.
+	txFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:     "signer",
+			Usage:    "path to the private key signing the transaction",
+			Required: true,
+		},
+		cli.DurationFlag{
+			Name:  "wait",
+			Usage: "wait for the transaction to be included in a block",
+		},
+	}
 )
 
 // NewController returns a new controller initializer
@@ -35,6 +66,27 @@ func (m controller) SetCommands(builder node.Builder) {
 			Usage:    "Path to signer's private key",
 			Required: true,
 		},
+		cli.DurationFlag{
+			Name:  "txnretention",
+			Usage: "the duration during which the status of a transaction can be checked",
+			Value: txnmanager.DefaultRetention,
+		},
+		cli.IntFlag{
+			Name:  "formvoterate",
+			Usage: "the maximum number of votes per minute in a form, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name:  "formvoteburst",
+			Usage: "the maximum number of votes a form accepts at once, 0 for the size of the largest batch",
+		},
+		cli.IntFlag{
+			Name:  "uservoterate",
+			Usage: "the maximum number of votes per minute of a user in a form, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name:  "poolrate",
+			Usage: "the maximum number of transactions per minute submitted to the pool, 0 for no limit",
+		},
 	)
 	sub.SetAction(builder.MakeAction(&RegisterAction{}))
 
@@ -64,6 +116,103 @@ func (m controller) SetCommands(builder node.Builder) {
 		},
 	)
 	sub.SetAction(builder.MakeAction(&scenarioTestAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms roster --formID <ID>
+	sub = cmd.SetSubCommand("forms")
+	sub.SetDescription("manage the forms")
+
+	formsSub := sub.SetSubCommand("roster")
+	formsSub.SetDescription("show the roster of a form and what each member did")
+	formsSub.SetFlags(formIDFlag,
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the roster as JSON",
+		},
+	)
+	formsSub.SetAction(builder.MakeAction(formsRosterAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms create --signer private.key \
+	//   --file form.json --admin <ID>
+	formsSub = sub.SetSubCommand("create")
+	formsSub.SetDescription("create a form from a JSON configuration and print its ID")
+	formsSub.SetFlags(append(txFlags,
+		cli.StringFlag{
+			Name:     "file",
+			Usage:    "path to the JSON configuration of the form",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "admin",
+			Usage: "the ID of the admin of the form",
+		},
+	)...)
+	formsSub.SetAction(builder.MakeAction(formCreateAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms open --signer private.key \
+	//   --formID <ID>
+	formTxs := []struct {
+		name        string
+		description string
+		action      formTxAction
+	}{
+		{"open", "open a form", formTxAction{
+			cmd: evoting.CmdOpenForm,
+			newTx: func(formID string) serde.Message {
+				return types.OpenForm{FormID: formID}
+			},
+		}},
+		{"close", "close a form", formTxAction{
+			cmd: evoting.CmdCloseForm,
+			newTx: func(formID string) serde.Message {
+				return types.CloseForm{FormID: formID}
+			},
+		}},
+		{"cancel", "cancel a form", formTxAction{
+			cmd: evoting.CmdCancelForm,
+			newTx: func(formID string) serde.Message {
+				return types.CancelForm{FormID: formID}
+			},
+		}},
+		{"delete", "delete a form", formTxAction{
+			cmd: evoting.CmdDeleteForm,
+			newTx: func(formID string) serde.Message {
+				return types.DeleteForm{FormID: formID}
+			},
+		}},
+	}
+
+	for _, formTx := range formTxs {
+		formsSub = sub.SetSubCommand(formTx.name)
+		formsSub.SetDescription(formTx.description)
+		formsSub.SetFlags(append(txFlags, formIDFlag)...)
+		formsSub.SetAction(builder.MakeAction(formTx.action))
+	}
+
+	// dvoting --config /tmp/node1 e-voting forms shuffle --formID <ID>
+	formsSub = sub.SetSubCommand("shuffle")
+	formsSub.SetDescription("shuffle the ballots of a closed form")
+	formsSub.SetFlags(formIDFlag)
+	formsSub.SetAction(builder.MakeAction(formShuffleAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms decrypt --signer private.key \
+	//   --formID <ID>
+	formsSub = sub.SetSubCommand("decrypt")
+	formsSub.SetDescription("request the public shares of a shuffled form, or " +
+		"combine them once they are submitted")
+	formsSub.SetFlags(append(txFlags, formIDFlag)...)
+	formsSub.SetAction(builder.MakeAction(formDecryptAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms show --formID <ID>
+	formsSub = sub.SetSubCommand("show")
+	formsSub.SetDescription("show the summary of a form")
+	formsSub.SetFlags(formIDFlag, jsonFlag)
+	formsSub.SetAction(builder.MakeAction(formShowAction{}))
+
+	// dvoting --config /tmp/node1 e-voting forms list
+	formsSub = sub.SetSubCommand("list")
+	formsSub.SetDescription("list the summaries of the forms")
+	formsSub.SetFlags(jsonFlag)
+	formsSub.SetAction(builder.MakeAction(formListAction{}))
 }
 
 // OnStart implements node.Initializer. It creates and registers a pedersen DKG.
@@ -34,6 +34,8 @@ const (
 	errGetTransaction     = "failed to get transaction: %v"
 	errGetForm            = "failed to get form: %v"
 	errWrongTx            = "wrong type of transaction: %T"
+	errSaveForm           = "failed to save form: %v"
+	errFormsIndex         = "failed to update forms index: %v"
 )
 
 // evotingCommand implements the commands of the Evoting contract.
@@ -60,6 +62,102 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 		return xerrors.Errorf(errWrongTx, msg)
 	}
 
+	return e.addForm(snap, step, tx.Configuration, tx.AdminID)
+}
+
+// createFormFromTemplate implements commands. It performs the
+// CREATE_FORM_FROM_TEMPLATE command. The configuration is copied from an
+// existing form, whatever its status, or from a version of a template.
+func (e evotingCommand) createFormFromTemplate(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.CreateFormFromTemplate)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	var configuration types.Configuration
+
+	switch {
+	case tx.SourceFormID != "" && tx.TemplateName != "":
+		return xerrors.Errorf("only one of the source form and the template can be set")
+	case tx.SourceFormID != "":
+		form, _, err := e.getForm(tx.SourceFormID, snap)
+		if err != nil {
+			return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
+		}
+
+		configuration = form.Configuration
+	case tx.TemplateName != "":
+		versions, err := types.TemplateVersions(snap, tx.TemplateName)
+		if err != nil {
+			return xerrors.Errorf("failed to get template: %v", err)
+		}
+
+		if versions == 0 {
+			return types.NewRejection(types.CodeTemplateNotFound,
+				"template %q not found", tx.TemplateName)
+		}
+
+		templateVersion, err := types.GetTemplateVersion(snap, tx.TemplateName, tx.TemplateVersion)
+		if err != nil {
+			return xerrors.Errorf("failed to get template version: %v", err)
+		}
+
+		if templateVersion == nil {
+			return types.NewRejection(types.CodeTemplateNotFound,
+				"template %q has no version %d", tx.TemplateName, tx.TemplateVersion)
+		}
+
+		configuration = templateVersion.Configuration
+	default:
+		return xerrors.Errorf("either the source form or the template must be set")
+	}
+
+	configuration = configuration.ApplyOverrides(tx.Title, tx.AdditionalInfo, tx.Schedule)
+
+	return e.addForm(snap, step, configuration, tx.AdminID)
+}
+
+// saveTemplate implements commands. It performs the SAVE_TEMPLATE command
+func (e evotingCommand) saveTemplate(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.SaveTemplate)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	if tx.Name == "" {
+		return types.NewRejection(types.CodeInvalidConfiguration, "the template must have a name")
+	}
+
+	if !tx.Configuration.IsValid() {
+		return types.NewRejection(types.CodeInvalidConfiguration,
+			"configuration of template is incoherent or has duplicated IDs")
+	}
+
+	_, err = types.AddTemplateVersion(snap, tx.Name, tx.AdminID, tx.Configuration)
+	if err != nil {
+		return xerrors.Errorf("failed to add template version: %v", err)
+	}
+
+	return nil
+}
+
+// addForm creates a new form with the given configuration and adds it to the
+// forms metadata. The form ID is derived from the current transaction.
+func (e evotingCommand) addForm(snap store.Snapshot, step execution.Step,
+	configuration types.Configuration, adminID string) error {
+
 	rosterBuf, err := snap.Get(viewchange.GetRosterKey())
 	if err != nil {
 		return xerrors.Errorf("failed to get roster")
@@ -75,8 +173,9 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 	h.Write(step.Current.GetID())
 	formIDBuf := h.Sum(nil)
 
-	if !tx.Configuration.IsValid() {
-		return xerrors.Errorf("configuration of form is incoherent or has duplicated IDs")
+	if !configuration.IsValid() {
+		return types.NewRejection(types.CodeInvalidConfiguration,
+			"configuration of form is incoherent or has duplicated IDs")
 	}
 
 	units := types.PubsharesUnits{
@@ -87,10 +186,11 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 
 	form := types.Form{
 		FormID:        hex.EncodeToString(formIDBuf),
-		Configuration: tx.Configuration,
+		Configuration: configuration,
+		AdminID:       adminID,
 		Status:        types.Initial,
 		// Pubkey is set by the opening command
-		BallotSize:       tx.Configuration.MaxBallotSize(),
+		BallotSize:       configuration.MaxBallotSize(),
 		PubsharesUnits:   units,
 		ShuffleInstances: []types.ShuffleInstance{},
 		DecryptedBallots: []types.Ballot{},
@@ -102,47 +202,25 @@ func (e evotingCommand) createForm(snap store.Snapshot, step execution.Step) err
 
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
-	}
-
-	err = snap.Set(formIDBuf, formBuf)
+	err = e.ensureFormsIndex(snap)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errFormsIndex, err)
 	}
 
-	// Update the form metadata store
-
-	formsMetadataBuf, err := snap.Get([]byte(FormsMetadataKey))
+	entry, err := types.GetFormIndexEntry(snap, form.FormID)
 	if err != nil {
-		return xerrors.Errorf("failed to get key '%s': %v", formsMetadataBuf, err)
+		return xerrors.Errorf(errFormsIndex, err)
 	}
 
-	formsMetadata := &types.FormsMetadata{
-		FormsIDs: types.FormIDs{},
+	if entry != nil {
+		return types.NewRejection(types.CodeFormAlreadyExists,
+			"couldn't add new form: id %q already exist", form.FormID)
 	}
 
-	if len(formsMetadataBuf) != 0 {
-		err := json.Unmarshal(formsMetadataBuf, formsMetadata)
-		if err != nil {
-			return xerrors.Errorf("failed to unmarshal FormsMetadata: %v", err)
-		}
-	}
-
-	err = formsMetadata.FormsIDs.Add(form.FormID)
-	if err != nil {
-		return xerrors.Errorf("couldn't add new form: %v", err)
-	}
-
-	formMetadataJSON, err := json.Marshal(formsMetadata)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal FormsMetadata: %v", err)
-	}
-
-	err = snap.Set([]byte(FormsMetadataKey), formMetadataJSON)
+	// saving the form adds it to the forms index
+	err = e.saveForm(snap, formIDBuf, form)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -164,11 +242,12 @@ func (e evotingCommand) openForm(snap store.Snapshot, step execution.Step) error
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.Initial {
-		return xerrors.Errorf("the form was opened before, current status: %d", form.Status)
+		return types.NewRejection(types.CodeFormNotInitial,
+			"the form was opened before, current status: %d", form.Status)
 	}
 
 	form.Status = types.Open
@@ -190,14 +269,109 @@ func (e evotingCommand) openForm(snap store.Snapshot, step execution.Step) error
 
 	form.Pubkey = pubkey
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	return nil
+}
+
+// updateConfiguration implements commands. It performs the
+// UPDATE_CONFIGURATION command. The configuration can only be updated before
+// the form is opened, since the ballot size must not change once ballots are
+// cast.
+func (e evotingCommand) updateConfiguration(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.UpdateConfiguration)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
+	}
+
+	if form.Status != types.Initial {
+		return types.NewRejection(types.CodeFormNotInitial,
+			"the form must be in its initial state to update "+
+				"the configuration, current status: %d", form.Status)
+	}
+
+	if !tx.Configuration.IsValid() {
+		return types.NewRejection(types.CodeInvalidConfiguration,
+			"configuration of form is incoherent or has duplicated IDs")
+	}
+
+	form.Configuration = tx.Configuration
+	form.BallotSize = tx.Configuration.MaxBallotSize()
+
+	err = e.saveForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSaveForm, err)
+	}
+
+	return nil
+}
+
+// setVoterWeights implements commands. It performs the SET_VOTER_WEIGHTS
+// command. Weights can only be set before the form is opened.
+func (e evotingCommand) setVoterWeights(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
+	if err != nil {
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.SetVoterWeights)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
+	}
+
+	if form.Status != types.Initial {
+		return types.NewRejection(types.CodeFormNotInitial,
+			"the form must be in its initial state to set "+
+				"the voter weights, current status: %d", form.Status)
+	}
+
+	weights := make(map[string]uint32, len(tx.VoterWeights))
+
+	for i, voter := range tx.VoterWeights {
+		if voter.UserID == "" {
+			return types.NewRejection(types.CodeInvalidVoterWeights,
+				"invalid voter weight %d: unknown voter, the user ID is empty", i)
+		}
+
+		if voter.Weight == 0 {
+			return types.NewRejection(types.CodeInvalidVoterWeights,
+				"invalid voter weight %d: the weight of user %q is 0", i, voter.UserID)
+		}
+
+		_, found := weights[voter.UserID]
+		if found {
+			return types.NewRejection(types.CodeInvalidVoterWeights,
+				"invalid voter weight %d: user %q is listed twice", i, voter.UserID)
+		}
+
+		weights[voter.UserID] = voter.Weight
+	}
+
+	form.VoterWeights = weights
+
+	err = e.saveForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -218,31 +392,97 @@ func (e evotingCommand) castVote(snap store.Snapshot, step execution.Step) error
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.Open {
-		return xerrors.Errorf("the form is not open, current status: %d", form.Status)
+		return types.NewRejection(types.CodeFormNotOpen,
+			"the form is not open, current status: %d", form.Status)
 	}
 
-	if len(tx.Ballot) != form.ChunksPerBallot() {
-		return xerrors.Errorf("the ballot has unexpected length: %d != %d",
-			len(tx.Ballot), form.ChunksPerBallot())
+	ciphervote, err := prepareCiphervote(form, tx.UserID, tx.Ballot, tx.Weight)
+	if err != nil {
+		return err
 	}
 
-	err = form.CastVote(e.context, snap, tx.UserID, tx.Ballot)
+	err = form.CastVote(e.context, snap, tx.UserID, ciphervote)
 	if err != nil {
 		return xerrors.Errorf("couldn't cast vote: %v", err)
 	}
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
-	err = snap.Set(formID, formBuf)
+	PromFormBallots.WithLabelValues(form.FormID).Set(float64(form.BallotCount))
+
+	return nil
+}
+
+// castVotes implements commands. It performs the CAST_VOTES command. All the
+// votes are validated before any is cast, so that the batch is either fully
+// cast or rejected.
+func (e evotingCommand) castVotes(snap store.Snapshot, step execution.Step) error {
+
+	msg, err := e.getTransaction(step.Current)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errGetTransaction, err)
+	}
+
+	tx, ok := msg.(types.CastVotes)
+	if !ok {
+		return xerrors.Errorf(errWrongTx, msg)
+	}
+
+	form, formID, err := e.getForm(tx.FormID, snap)
+	if err != nil {
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
+	}
+
+	if form.Status != types.Open {
+		return types.NewRejection(types.CodeFormNotOpen,
+			"the form is not open, current status: %d", form.Status)
+	}
+
+	if len(tx.Votes) == 0 {
+		return types.NewRejection(types.CodeInvalidBatch, "the batch has no vote")
+	}
+
+	if len(tx.Votes) > types.MaxVotesPerBatch {
+		return types.NewRejection(types.CodeInvalidBatch, "the batch has too many votes: %d > %d",
+			len(tx.Votes), types.MaxVotesPerBatch)
+	}
+
+	ciphervotes := make([]types.Ciphervote, len(tx.Votes))
+	userIDs := make(map[string]struct{}, len(tx.Votes))
+
+	for i, vote := range tx.Votes {
+		_, found := userIDs[vote.UserID]
+		if found {
+			return types.NewRejection(types.CodeInvalidBatch,
+				"invalid vote %d: user %q already voted in the batch",
+				i, vote.UserID)
+		}
+
+		userIDs[vote.UserID] = struct{}{}
+
+		ciphervotes[i], err = prepareCiphervote(form, vote.UserID, vote.Ballot, vote.Weight)
+		if err != nil {
+			return xerrors.Errorf("invalid vote %d: %w", i, err)
+		}
+	}
+
+	for i, vote := range tx.Votes {
+		err = form.CastVote(e.context, snap, vote.UserID, ciphervotes[i])
+		if err != nil {
+			return xerrors.Errorf("couldn't cast vote %d: %v", i, err)
+		}
+	}
+
+	err = e.saveForm(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	PromFormBallots.WithLabelValues(form.FormID).Set(float64(form.BallotCount))
@@ -250,6 +490,48 @@ func (e evotingCommand) castVote(snap store.Snapshot, step execution.Step) error
 	return nil
 }
 
+// prepareCiphervote checks that the ballot matches the form, and returns the
+// ciphervote to store. In a weighted form, the encrypted weight of the voter
+// is appended to the ballot once its proof is verified. Only the voters listed
+// in the weights of the form can vote.
+func prepareCiphervote(form types.Form, userID string, ballot types.Ciphervote,
+	weight *types.EncryptedWeight) (types.Ciphervote, error) {
+
+	if len(ballot) != form.ChunksPerBallot() {
+		return nil, types.NewRejection(types.CodeInvalidBallot,
+			"the ballot has unexpected length: %d != %d",
+			len(ballot), form.ChunksPerBallot())
+	}
+
+	if !form.IsWeighted() {
+		if weight != nil {
+			return nil, types.NewRejection(types.CodeInvalidBallot,
+				"the form is not weighted, the ballot must have no weight")
+		}
+
+		return ballot, nil
+	}
+
+	voterWeight := form.VoterWeight(userID)
+	if voterWeight == 0 {
+		return nil, types.NewRejection(types.CodeVoterNotAllowed,
+			"user %q is not allowed to vote", userID)
+	}
+
+	if weight == nil {
+		return nil, types.NewRejection(types.CodeInvalidBallot,
+			"the form is weighted, the ballot must have a weight")
+	}
+
+	err := weight.Verify(form.Pubkey, voterWeight)
+	if err != nil {
+		return nil, types.NewRejection(types.CodeInvalidBallot,
+			"invalid weight of user %q: %v", userID, err)
+	}
+
+	return append(ballot.Copy(), weight.Pair.Copy()), nil
+}
+
 // shuffleBallots implements commands. It performs the SHUFFLE_BALLOTS command
 func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step) error {
 
@@ -270,11 +552,12 @@ func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step)
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.Closed {
-		return xerrors.Errorf("the form is not in state closed (current: %d != closed: %d)",
+		return types.NewRejection(types.CodeFormNotClosed,
+			"the form is not in state closed (current: %d != closed: %d)",
 			form.Status, types.Closed)
 	}
 
@@ -342,12 +625,12 @@ func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step)
 		return xerrors.Errorf("could not create semi-random stream: %v", err)
 	}
 
-	if form.ChunksPerBallot() != len(randomVector) {
+	if form.CiphervoteSize() != len(randomVector) {
 		return xerrors.Errorf("randomVector has unexpected length : %v != %v",
-			len(randomVector), form.ChunksPerBallot())
+			len(randomVector), form.CiphervoteSize())
 	}
 
-	for i := 0; i < form.ChunksPerBallot(); i++ {
+	for i := 0; i < form.CiphervoteSize(); i++ {
 		v := suite.Scalar().Pick(semiRandomStream)
 		if !randomVector[i].Equal(v) {
 			return xerrors.Errorf("random vector from shuffle transaction is " +
@@ -376,7 +659,8 @@ func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step)
 	}
 
 	if len(ciphervotes) < 2 {
-		return xerrors.Errorf("not enough votes: %d < 2", len(ciphervotes))
+		return types.NewRejection(types.CodeNotEnoughBallots,
+			"not enough votes: %d < 2", len(ciphervotes))
 	}
 
 	X, Y := types.CiphervotesToPairs(ciphervotes)
@@ -408,14 +692,9 @@ func (e evotingCommand) shuffleBallots(snap store.Snapshot, step execution.Step)
 		PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 	}
 
-	formBuf, err := form.Serialize(e.context)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
-	}
-
-	err = snap.Set(formID, formBuf)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -469,28 +748,24 @@ func (e evotingCommand) closeForm(snap store.Snapshot, step execution.Step) erro
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.Open {
-		return xerrors.Errorf("the form is not open, current status: %d", form.Status)
+		return types.NewRejection(types.CodeFormNotOpen,
+			"the form is not open, current status: %d", form.Status)
 	}
 
 	if form.BallotCount <= 1 {
-		return xerrors.Errorf("at least two ballots are required")
+		return types.NewRejection(types.CodeNotEnoughBallots, "at least two ballots are required")
 	}
 
 	form.Status = types.Closed
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
-	}
-
-	err = snap.Set(formID, formBuf)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -511,7 +786,7 @@ func (e evotingCommand) registerPubshares(snap store.Snapshot, step execution.St
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.ShuffledBallots {
@@ -595,14 +870,9 @@ func (e evotingCommand) registerPubshares(snap store.Snapshot, step execution.St
 		PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 	}
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form: %v", err)
-	}
-
-	err = snap.Set(formID, formBuf)
-	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -623,7 +893,7 @@ func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step)
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	if form.Status != types.PubSharesSubmitted {
@@ -640,6 +910,11 @@ func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step)
 
 	decryptedBallots := make([]types.Ballot, shuffledBallotsSize)
 
+	// in a weighted form, the last pair holds the weight of the voter
+	if form.IsWeighted() {
+		ballotSize--
+	}
+
 	for i := 0; i < shuffledBallotsSize; i++ {
 		// decryption of one ballot:
 		marshalledBallot := strings.Builder{}
@@ -660,6 +935,19 @@ func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step)
 			dela.Logger.Warn().Msgf("Failed to unmarshal a ballot: %v", err)
 		}
 
+		if form.IsWeighted() {
+			weightBuf, err := decrypt(i, ballotSize, allPubShares, form.PubsharesUnits.Indexes)
+			if err != nil {
+				return xerrors.Errorf("failed to decrypt weight: %v", err)
+			}
+
+			if len(weightBuf) != 4 {
+				dela.Logger.Warn().Msgf("Unexpected weight length: %d", len(weightBuf))
+			} else {
+				ballot.Weight = binary.BigEndian.Uint32(weightBuf)
+			}
+		}
+
 		decryptedBallots[i] = ballot
 	}
 
@@ -668,14 +956,9 @@ func (e evotingCommand) combineShares(snap store.Snapshot, step execution.Step)
 	form.Status = types.ResultAvailable
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
-	}
-
-	err = snap.Set(formID, formBuf)
-	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -696,20 +979,15 @@ func (e evotingCommand) cancelForm(snap store.Snapshot, step execution.Step) err
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
 	form.Status = types.Canceled
 	PromFormStatus.WithLabelValues(form.FormID).Set(float64(form.Status))
 
-	formBuf, err := form.Serialize(e.context)
-	if err != nil {
-		return xerrors.Errorf("failed to marshal Form : %v", err)
-	}
-
-	err = snap.Set(formID, formBuf)
+	err = e.saveForm(snap, formID, form)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errSaveForm, err)
 	}
 
 	return nil
@@ -730,42 +1008,29 @@ func (e evotingCommand) deleteForm(snap store.Snapshot, step execution.Step) err
 
 	form, formID, err := e.getForm(tx.FormID, snap)
 	if err != nil {
-		return xerrors.Errorf(errGetForm, err)
+		return types.NewRejection(types.CodeFormNotFound, errGetForm, err)
 	}
 
-	err = snap.Delete(formID)
-	if err != nil {
-		return xerrors.Errorf("failed to delete form: %v", err)
-	}
-
-	// Update the form metadata store
-
-	formsMetadataBuf, err := snap.Get([]byte(FormsMetadataKey))
+	// the migration of the forms index needs the form, so it must be done
+	// before the form is deleted
+	err = e.ensureFormsIndex(snap)
 	if err != nil {
-		return xerrors.Errorf("failed to get key '%s': %v", formsMetadataBuf, err)
+		return xerrors.Errorf(errFormsIndex, err)
 	}
 
-	if len(formsMetadataBuf) == 0 {
-		return nil
-	}
-
-	var formsMetadata types.FormsMetadata
-
-	err = json.Unmarshal(formsMetadataBuf, &formsMetadata)
+	err = snap.Delete(formID)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal FormsMetadata: %v", err)
+		return xerrors.Errorf("failed to delete form: %v", err)
 	}
 
-	formsMetadata.FormsIDs.Remove(form.FormID)
-
-	formMetadataJSON, err := json.Marshal(formsMetadata)
+	err = snap.Delete(types.FormSummaryKey(formID))
 	if err != nil {
-		return xerrors.Errorf("failed to marshal FormsMetadata: %v", err)
+		return xerrors.Errorf("failed to delete form summary: %v", err)
 	}
 
-	err = snap.Set([]byte(FormsMetadataKey), formMetadataJSON)
+	err = types.RemoveFormFromIndex(snap, form.FormID)
 	if err != nil {
-		return xerrors.Errorf("failed to set value: %v", err)
+		return xerrors.Errorf(errFormsIndex, err)
 	}
 
 	return nil
@@ -856,6 +1121,112 @@ func (e evotingCommand) getForm(formIDHex string,
 	return form, formIDBuf, nil
 }
 
+// saveForm stores the form and its summary, which is the lightweight view of
+// the form used to list forms without deserializing the whole form. It also
+// keeps the status of the form up to date in the forms index.
+func (e evotingCommand) saveForm(snap store.Snapshot, formID []byte, form types.Form) error {
+	formBuf, err := form.Serialize(e.context)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal Form : %v", err)
+	}
+
+	err = snap.Set(formID, formBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set value: %v", err)
+	}
+
+	err = setFormSummary(snap, formID, form)
+	if err != nil {
+		return xerrors.Errorf("failed to set summary: %v", err)
+	}
+
+	err = e.ensureFormsIndex(snap)
+	if err != nil {
+		return xerrors.Errorf(errFormsIndex, err)
+	}
+
+	err = types.UpdateFormStatusInIndex(snap, form.FormID, form.Status)
+	if err != nil {
+		return xerrors.Errorf(errFormsIndex, err)
+	}
+
+	return nil
+}
+
+// setFormSummary stores the summary of the form.
+func setFormSummary(snap store.Snapshot, formID []byte, form types.Form) error {
+	summaryBuf, err := json.Marshal(form.Summary())
+	if err != nil {
+		return xerrors.Errorf("failed to marshal FormSummary: %v", err)
+	}
+
+	err = snap.Set(types.FormSummaryKey(formID), summaryBuf)
+	if err != nil {
+		return xerrors.Errorf("failed to set value: %v", err)
+	}
+
+	return nil
+}
+
+// ensureFormsIndex creates the forms index if it doesn't exist yet. Forms
+// created before the index was introduced are listed in the legacy
+// FormsMetadata, which is migrated to the index and then deleted.
+func (e evotingCommand) ensureFormsIndex(snap store.Snapshot) error {
+	initialized, err := types.IsFormsIndexInitialized(snap)
+	if err != nil {
+		return xerrors.Errorf("failed to check index: %v", err)
+	}
+
+	if initialized {
+		return nil
+	}
+
+	err = types.InitFormsIndex(snap)
+	if err != nil {
+		return xerrors.Errorf("failed to init index: %v", err)
+	}
+
+	formsMetadataBuf, err := snap.Get([]byte(FormsMetadataKey))
+	if err != nil {
+		return xerrors.Errorf("failed to get key '%s': %v", FormsMetadataKey, err)
+	}
+
+	if len(formsMetadataBuf) == 0 {
+		return nil
+	}
+
+	var formsMetadata types.FormsMetadata
+
+	err = json.Unmarshal(formsMetadataBuf, &formsMetadata)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal FormsMetadata: %v", err)
+	}
+
+	for _, formIDHex := range formsMetadata.FormsIDs {
+		form, formID, err := e.getForm(formIDHex, snap)
+		if err != nil {
+			return xerrors.Errorf("failed to migrate form %q: %v", formIDHex, err)
+		}
+
+		err = types.AddFormToIndex(snap, form.FormID, form.Status)
+		if err != nil {
+			return xerrors.Errorf("failed to migrate form %q: %v", formIDHex, err)
+		}
+
+		err = setFormSummary(snap, formID, form)
+		if err != nil {
+			return xerrors.Errorf("failed to migrate summary of %q: %v", formIDHex, err)
+		}
+	}
+
+	err = snap.Delete([]byte(FormsMetadataKey))
+	if err != nil {
+		return xerrors.Errorf("failed to delete FormsMetadata: %v", err)
+	}
+
+	return nil
+}
+
 // getTransaction extracts the argument from the transaction.
 func (e evotingCommand) getTransaction(tx txn.Transaction) (serde.Message, error) {
 	buff := tx.GetArg(FormArg)
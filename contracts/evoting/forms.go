@@ -0,0 +1,50 @@
+package evoting
+
+import (
+	"encoding/json"
+
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// IterateForms calls fn with the IDs of all the forms in the creation order.
+// It falls back to the legacy FormsMetadata if the contract didn't migrate it
+// to the forms index yet. The iteration stops when fn returns false.
+func IterateForms(rd store.Readable, fn func(formID string) bool) error {
+	initialized, err := types.IsFormsIndexInitialized(rd)
+	if err != nil {
+		return xerrors.Errorf("failed to check index: %v", err)
+	}
+
+	if initialized {
+		return types.IterateFormsIndex(rd, nil, 0, func(_ uint64, formID string) bool {
+			return fn(formID)
+		})
+	}
+
+	buf, err := rd.Get([]byte(FormsMetadataKey))
+	if err != nil {
+		return xerrors.Errorf("failed to get key '%s': %v", FormsMetadataKey, err)
+	}
+
+	// if there is no form created yet the metadata is empty
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var formsMetadata types.FormsMetadata
+
+	err = json.Unmarshal(buf, &formsMetadata)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal FormsMetadata: %v", err)
+	}
+
+	for _, formID := range formsMetadata.FormsIDs {
+		if !fn(formID) {
+			return nil
+		}
+	}
+
+	return nil
+}
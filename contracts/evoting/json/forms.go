@@ -65,6 +65,7 @@ func (formFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, erro
 		formJSON := FormJSON{
 			Configuration:    m.Configuration,
 			FormID:           m.FormID,
+			AdminID:          m.AdminID,
 			Status:           uint16(m.Status),
 			Pubkey:           pubkey,
 			BallotSize:       m.BallotSize,
@@ -75,6 +76,7 @@ func (formFormat) Encode(ctx serde.Context, message serde.Message) ([]byte, erro
 			ShuffleThreshold: m.ShuffleThreshold,
 			PubsharesUnits:   pubsharesUnits,
 			DecryptedBallots: m.DecryptedBallots,
+			VoterWeights:     m.VoterWeights,
 			RosterBuf:        rosterBuf,
 		}
 
@@ -148,6 +150,7 @@ func (formFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error)
 	return types.Form{
 		Configuration:      formJSON.Configuration,
 		FormID:             formJSON.FormID,
+		AdminID:            formJSON.AdminID,
 		Status:             types.Status(formJSON.Status),
 		Pubkey:             pubKey,
 		BallotSize:         formJSON.BallotSize,
@@ -158,6 +161,7 @@ func (formFormat) Decode(ctx serde.Context, data []byte) (serde.Message, error)
 		ShuffleThreshold:   formJSON.ShuffleThreshold,
 		PubsharesUnits:     pubSharesSubmissions,
 		DecryptedBallots:   formJSON.DecryptedBallots,
+		VoterWeights:       formJSON.VoterWeights,
 		Roster:             roster,
 	}, nil
 }
@@ -200,6 +204,9 @@ type FormJSON struct {
 
 	DecryptedBallots []types.Ballot
 
+	// VoterWeights maps a UserID to the weight of its ballot
+	VoterWeights map[string]uint32 `json:",omitempty"`
+
 	// roster is set when the form is created based on the current
 	// roster of the node stored in the global state. The roster will not change
 	// during a form and will be used for DKG and Neff. Its type is
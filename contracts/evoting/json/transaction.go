@@ -31,19 +31,86 @@ func (transactionFormat) Encode(ctx serde.Context, msg serde.Message) ([]byte, e
 		}
 
 		m = TransactionJSON{OpenForm: &oe}
+	case types.CreateFormFromTemplate:
+		cf := CreateFormFromTemplateJSON{
+			AdminID:         t.AdminID,
+			SourceFormID:    t.SourceFormID,
+			TemplateName:    t.TemplateName,
+			TemplateVersion: t.TemplateVersion,
+			Title:           t.Title,
+			AdditionalInfo:  t.AdditionalInfo,
+			Schedule:        t.Schedule,
+		}
+
+		m = TransactionJSON{CreateFormFromTemplate: &cf}
+	case types.SaveTemplate:
+		st := SaveTemplateJSON{
+			Name:          t.Name,
+			AdminID:       t.AdminID,
+			Configuration: t.Configuration,
+		}
+
+		m = TransactionJSON{SaveTemplate: &st}
+	case types.UpdateConfiguration:
+		uc := UpdateConfigurationJSON{
+			FormID:        t.FormID,
+			Configuration: t.Configuration,
+		}
+
+		m = TransactionJSON{UpdateConfiguration: &uc}
+	case types.SetVoterWeights:
+		sv := SetVoterWeightsJSON{
+			FormID:       t.FormID,
+			VoterWeights: t.VoterWeights,
+		}
+
+		m = TransactionJSON{SetVoterWeights: &sv}
 	case types.CastVote:
 		ballot, err := t.Ballot.Serialize(ctx)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to serialize ballot: %v", err)
 		}
 
+		weight, err := encodeWeight(t.Weight)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to encode weight: %v", err)
+		}
+
 		cv := CastVoteJSON{
 			FormID:     t.FormID,
 			UserID:     t.UserID,
 			Ciphervote: ballot,
+			Weight:     weight,
 		}
 
 		m = TransactionJSON{CastVote: &cv}
+	case types.CastVotes:
+		votes := make([]BatchVoteJSON, len(t.Votes))
+
+		for i, vote := range t.Votes {
+			ballot, err := vote.Ballot.Serialize(ctx)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to serialize ballot: %v", err)
+			}
+
+			weight, err := encodeWeight(vote.Weight)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to encode weight: %v", err)
+			}
+
+			votes[i] = BatchVoteJSON{
+				UserID:     vote.UserID,
+				Ciphervote: ballot,
+				Weight:     weight,
+			}
+		}
+
+		cv := CastVotesJSON{
+			FormID: t.FormID,
+			Votes:  votes,
+		}
+
+		m = TransactionJSON{CastVotes: &cv}
 	case types.CloseForm:
 		ce := CloseFormJSON{
 			FormID: t.FormID,
@@ -149,12 +216,45 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 		return types.OpenForm{
 			FormID: m.OpenForm.FormID,
 		}, nil
+	case m.CreateFormFromTemplate != nil:
+		return types.CreateFormFromTemplate{
+			AdminID:         m.CreateFormFromTemplate.AdminID,
+			SourceFormID:    m.CreateFormFromTemplate.SourceFormID,
+			TemplateName:    m.CreateFormFromTemplate.TemplateName,
+			TemplateVersion: m.CreateFormFromTemplate.TemplateVersion,
+			Title:           m.CreateFormFromTemplate.Title,
+			AdditionalInfo:  m.CreateFormFromTemplate.AdditionalInfo,
+			Schedule:        m.CreateFormFromTemplate.Schedule,
+		}, nil
+	case m.SaveTemplate != nil:
+		return types.SaveTemplate{
+			Name:          m.SaveTemplate.Name,
+			AdminID:       m.SaveTemplate.AdminID,
+			Configuration: m.SaveTemplate.Configuration,
+		}, nil
+	case m.UpdateConfiguration != nil:
+		return types.UpdateConfiguration{
+			FormID:        m.UpdateConfiguration.FormID,
+			Configuration: m.UpdateConfiguration.Configuration,
+		}, nil
+	case m.SetVoterWeights != nil:
+		return types.SetVoterWeights{
+			FormID:       m.SetVoterWeights.FormID,
+			VoterWeights: m.SetVoterWeights.VoterWeights,
+		}, nil
 	case m.CastVote != nil:
 		msg, err := decodeCastVote(ctx, *m.CastVote)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to decode cast vote: %v", err)
 		}
 
+		return msg, nil
+	case m.CastVotes != nil:
+		msg, err := decodeCastVotes(ctx, *m.CastVotes)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decode cast votes: %v", err)
+		}
+
 		return msg, nil
 	case m.CloseForm != nil:
 		return types.CloseForm{
@@ -197,15 +297,20 @@ func (transactionFormat) Decode(ctx serde.Context, data []byte) (serde.Message,
 // TransactionJSON is the JSON message that wraps the different kinds of
 // transactions.
 type TransactionJSON struct {
-	CreateForm        *CreateFormJSON        `json:",omitempty"`
-	OpenForm          *OpenFormJSON          `json:",omitempty"`
-	CastVote          *CastVoteJSON          `json:",omitempty"`
-	CloseForm         *CloseFormJSON         `json:",omitempty"`
-	ShuffleBallots    *ShuffleBallotsJSON    `json:",omitempty"`
-	RegisterPubShares *RegisterPubSharesJSON `json:",omitempty"`
-	CombineShares     *CombineSharesJSON     `json:",omitempty"`
-	CancelForm        *CancelFormJSON        `json:",omitempty"`
-	DeleteForm        *DeleteFormJSON        `json:",omitempty"`
+	CreateForm             *CreateFormJSON             `json:",omitempty"`
+	OpenForm               *OpenFormJSON               `json:",omitempty"`
+	CreateFormFromTemplate *CreateFormFromTemplateJSON `json:",omitempty"`
+	SaveTemplate           *SaveTemplateJSON           `json:",omitempty"`
+	UpdateConfiguration    *UpdateConfigurationJSON    `json:",omitempty"`
+	SetVoterWeights        *SetVoterWeightsJSON        `json:",omitempty"`
+	CastVote               *CastVoteJSON               `json:",omitempty"`
+	CastVotes              *CastVotesJSON              `json:",omitempty"`
+	CloseForm              *CloseFormJSON              `json:",omitempty"`
+	ShuffleBallots         *ShuffleBallotsJSON         `json:",omitempty"`
+	RegisterPubShares      *RegisterPubSharesJSON      `json:",omitempty"`
+	CombineShares          *CombineSharesJSON          `json:",omitempty"`
+	CancelForm             *CancelFormJSON             `json:",omitempty"`
+	DeleteForm             *DeleteFormJSON             `json:",omitempty"`
 }
 
 // CreateFormJSON is the JSON representation of a CreateForm transaction
@@ -219,11 +324,67 @@ type OpenFormJSON struct {
 	FormID string
 }
 
+// CreateFormFromTemplateJSON is the JSON representation of a
+// CreateFormFromTemplate transaction
+type CreateFormFromTemplateJSON struct {
+	AdminID         string
+	SourceFormID    string          `json:",omitempty"`
+	TemplateName    string          `json:",omitempty"`
+	TemplateVersion uint            `json:",omitempty"`
+	Title           *types.Title    `json:",omitempty"`
+	AdditionalInfo  types.LangMap   `json:",omitempty"`
+	Schedule        *types.Schedule `json:",omitempty"`
+}
+
+// SaveTemplateJSON is the JSON representation of a SaveTemplate transaction
+type SaveTemplateJSON struct {
+	Name          string
+	AdminID       string
+	Configuration types.Configuration
+}
+
+// UpdateConfigurationJSON is the JSON representation of an
+// UpdateConfiguration transaction
+type UpdateConfigurationJSON struct {
+	FormID        string
+	Configuration types.Configuration
+}
+
+// SetVoterWeightsJSON is the JSON representation of a SetVoterWeights
+// transaction
+type SetVoterWeightsJSON struct {
+	FormID       string
+	VoterWeights []types.VoterWeight
+}
+
 // CastVoteJSON is the JSON representation of a CastVote transaction
 type CastVoteJSON struct {
 	FormID     string
 	UserID     string
 	Ciphervote json.RawMessage
+	Weight     *EncryptedWeightJSON `json:",omitempty"`
+}
+
+// CastVotesJSON is the JSON representation of a CastVotes transaction
+type CastVotesJSON struct {
+	FormID string
+	Votes  []BatchVoteJSON
+}
+
+// BatchVoteJSON is the JSON representation of a vote of a CastVotes
+// transaction
+type BatchVoteJSON struct {
+	UserID     string
+	Ciphervote json.RawMessage
+	Weight     *EncryptedWeightJSON `json:",omitempty"`
+}
+
+// EncryptedWeightJSON is the JSON representation of the encrypted weight of a
+// voter
+type EncryptedWeightJSON struct {
+	K     []byte
+	C     []byte
+	Proof []byte
 }
 
 // CloseFormJSON is the JSON representation of a CloseForm transaction
@@ -284,10 +445,104 @@ func decodeCastVote(ctx serde.Context, m CastVoteJSON) (serde.Message, error) {
 		return nil, xerrors.Errorf("invalid ciphervote: '%T'", msg)
 	}
 
+	weight, err := decodeWeight(m.Weight)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode weight: %v", err)
+	}
+
 	return types.CastVote{
 		FormID: m.FormID,
 		UserID: m.UserID,
 		Ballot: ciphervote,
+		Weight: weight,
+	}, nil
+}
+
+func decodeCastVotes(ctx serde.Context, m CastVotesJSON) (serde.Message, error) {
+	factory := ctx.GetFactory(types.CiphervoteKey{})
+	if factory == nil {
+		return nil, xerrors.Errorf("missing ciphervote factory")
+	}
+
+	votes := make([]types.BatchVote, len(m.Votes))
+
+	for i, vote := range m.Votes {
+		msg, err := factory.Deserialize(ctx, vote.Ciphervote)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to deserialize ciphervote: %v", err)
+		}
+
+		ciphervote, ok := msg.(types.Ciphervote)
+		if !ok {
+			return nil, xerrors.Errorf("invalid ciphervote: '%T'", msg)
+		}
+
+		weight, err := decodeWeight(vote.Weight)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decode weight: %v", err)
+		}
+
+		votes[i] = types.BatchVote{
+			UserID: vote.UserID,
+			Ballot: ciphervote,
+			Weight: weight,
+		}
+	}
+
+	return types.CastVotes{
+		FormID: m.FormID,
+		Votes:  votes,
+	}, nil
+}
+
+// encodeWeight returns the JSON representation of an encrypted weight, which
+// is nil if there is no weight.
+func encodeWeight(weight *types.EncryptedWeight) (*EncryptedWeightJSON, error) {
+	if weight == nil {
+		return nil, nil
+	}
+
+	k, err := weight.Pair.K.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal k: %v", err)
+	}
+
+	c, err := weight.Pair.C.MarshalBinary()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal c: %v", err)
+	}
+
+	return &EncryptedWeightJSON{
+		K:     k,
+		C:     c,
+		Proof: weight.Proof,
+	}, nil
+}
+
+// decodeWeight returns the encrypted weight of its JSON representation, which
+// is nil if there is no weight.
+func decodeWeight(m *EncryptedWeightJSON) (*types.EncryptedWeight, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	k := suite.Point()
+
+	err := k.UnmarshalBinary(m.K)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal K: %v", err)
+	}
+
+	c := suite.Point()
+
+	err = c.UnmarshalBinary(m.C)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal C: %v", err)
+	}
+
+	return &types.EncryptedWeight{
+		Pair:  types.EGPair{K: k, C: c},
+		Proof: m.Proof,
 	}, nil
 }
 
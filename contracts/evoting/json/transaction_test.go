@@ -0,0 +1,83 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela/serde"
+	"go.dedis.ch/dela/serde/json"
+)
+
+func TestTransactionFormat_Decode_LegacyConfiguration(t *testing.T) {
+	legacy := `{"CreateForm":{"AdminID":"admin","Configuration":{` +
+		`"Title":{"En":"title","Fr":"titre","De":"","URL":""},` +
+		`"Scaffold":[{"ID":"aa","Title":{"En":"subject","Fr":"","De":"","URL":""},` +
+		`"Order":null,"Subjects":null,"Ranks":[],"Texts":null,"Selects":[{` +
+		`"ID":"bb","Title":{"En":"select","Fr":"","De":"","URL":""},"MaxN":1,"MinN":1,` +
+		`"Choices":[{"Choice":"snickers","URL":""}],"Hint":{"En":"","Fr":"","De":""}}]}],` +
+		`"AdditionalInfo":"info"}}}`
+
+	format := transactionFormat{}
+	ctx := json.NewContext()
+
+	msg, err := format.Decode(ctx, []byte(legacy))
+	require.NoError(t, err)
+
+	createForm, ok := msg.(types.CreateForm)
+	require.True(t, ok)
+
+	configuration := createForm.Configuration
+	require.Equal(t, "title", configuration.Title.Text.Get("en"))
+	require.Equal(t, "titre", configuration.Title.Text.Get("fr"))
+	require.Equal(t, "info", configuration.AdditionalInfo.Get("en"))
+	require.Equal(t, "snickers", configuration.Scaffold[0].Selects[0].Choices[0].Choice.Get("de"))
+	require.True(t, configuration.IsValid())
+
+	data, err := format.Encode(ctx, createForm)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"Choices":[{"Choice":"snickers","URL":""}]`)
+	require.Contains(t, string(data), `"AdditionalInfo":"info"`)
+
+	configuration.Languages = []string{"en", "fr"}
+	require.False(t, configuration.IsValid())
+}
+
+func TestTransactionFormat_CastVoteWeight(t *testing.T) {
+	format := transactionFormat{}
+	ctx := serde.WithFactory(json.NewContext(), types.CiphervoteKey{}, types.CiphervoteFactory{})
+
+	pubkey := suite.Point().Pick(suite.RandomStream())
+
+	weight, err := types.EncryptWeight(pubkey, 3)
+	require.NoError(t, err)
+
+	castVote := types.CastVote{
+		FormID: "aa",
+		UserID: "alice",
+		Ballot: types.Ciphervote{weight.Pair},
+		Weight: &weight,
+	}
+
+	data, err := format.Encode(ctx, castVote)
+	require.NoError(t, err)
+
+	msg, err := format.Decode(ctx, data)
+	require.NoError(t, err)
+
+	decoded, ok := msg.(types.CastVote)
+	require.True(t, ok)
+	require.NotNil(t, decoded.Weight)
+	require.NoError(t, decoded.Weight.Verify(pubkey, 3))
+
+	// the weight is omitted in an unweighted form
+	castVote.Weight = nil
+
+	data, err = format.Encode(ctx, castVote)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "Weight")
+
+	msg, err = format.Decode(ctx, data)
+	require.NoError(t, err)
+	require.Nil(t, msg.(types.CastVote).Weight)
+}
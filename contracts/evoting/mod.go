@@ -60,8 +60,9 @@ var (
 )
 
 const (
-	// FormsMetadataKey is the key at which form metadata are saved in
-	// the storage.
+	// FormsMetadataKey is the key at which the IDs of all the forms were
+	// saved in the storage before the forms index. It is only read to migrate
+	// existing chains to the index.
 	FormsMetadataKey = "FormsMetadataKey"
 )
 
@@ -91,8 +92,13 @@ const (
 // helps in testing.
 type commands interface {
 	createForm(snap store.Snapshot, step execution.Step) error
+	createFormFromTemplate(snap store.Snapshot, step execution.Step) error
+	saveTemplate(snap store.Snapshot, step execution.Step) error
 	openForm(snap store.Snapshot, step execution.Step) error
+	updateConfiguration(snap store.Snapshot, step execution.Step) error
+	setVoterWeights(snap store.Snapshot, step execution.Step) error
 	castVote(snap store.Snapshot, step execution.Step) error
+	castVotes(snap store.Snapshot, step execution.Step) error
 	closeForm(snap store.Snapshot, step execution.Step) error
 	shuffleBallots(snap store.Snapshot, step execution.Step) error
 	registerPubshares(snap store.Snapshot, step execution.Step) error
@@ -107,10 +113,22 @@ type Command string
 const (
 	// CmdCreateForm is the command to create a form
 	CmdCreateForm Command = "CREATE_FORM"
+	// CmdCreateFormFromTemplate is the command to create a form from the
+	// configuration of another form or of a template
+	CmdCreateFormFromTemplate Command = "CREATE_FORM_FROM_TEMPLATE"
+	// CmdSaveTemplate is the command to save a new version of a template
+	CmdSaveTemplate Command = "SAVE_TEMPLATE"
 	// CmdOpenForm is the command to open a form
 	CmdOpenForm Command = "OPEN_FORM"
+	// CmdUpdateConfiguration is the command to update the configuration of a
+	// form that is not open yet
+	CmdUpdateConfiguration Command = "UPDATE_CONFIGURATION"
+	// CmdSetVoterWeights is the command to set the weight of each voter
+	CmdSetVoterWeights Command = "SET_VOTER_WEIGHTS"
 	// CmdCastVote is the command to cast a vote
 	CmdCastVote Command = "CAST_VOTE"
+	// CmdCastVotes is the command to cast several votes in one transaction
+	CmdCastVotes Command = "CAST_VOTES"
 	// CmdCloseForm is the command to close a form
 	CmdCloseForm Command = "CLOSE_FORM"
 	// CmdShuffleBallots is the command to shuffle ballots
@@ -184,13 +202,25 @@ func NewContract(srvc access.Service,
 	return contract
 }
 
-// Execute implements native.Contract
+// Execute implements native.Contract. The message of an error starts with the
+// code of the rejection, if any, so that the clients don't depend on the
+// message.
 func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
+	err := c.execute(snap, step)
+	if err != nil {
+		return types.WithRejectionCode(err)
+	}
+
+	return nil
+}
+
+// execute performs the command of the transaction.
+func (c Contract) execute(snap store.Snapshot, step execution.Step) error {
 	creds := NewCreds()
 
 	err := c.access.Match(snap, creds, step.Current.GetIdentity())
 	if err != nil {
-		return xerrors.Errorf("identity not authorized: %v (%v)",
+		return types.NewRejection(types.CodeUnauthorized, "identity not authorized: %v (%v)",
 			step.Current.GetIdentity(), err)
 	}
 
@@ -205,47 +235,74 @@ func (c Contract) Execute(snap store.Snapshot, step execution.Step) error {
 	case CmdCreateForm:
 		err = c.cmd.createForm(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to create form: %v", err)
+			return xerrors.Errorf("failed to create form: %w", err)
+		}
+	case CmdCreateFormFromTemplate:
+		err := c.cmd.createFormFromTemplate(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to create form from template: %w", err)
+		}
+	case CmdSaveTemplate:
+		err := c.cmd.saveTemplate(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to save template: %w", err)
 		}
 	case CmdOpenForm:
 		err := c.cmd.openForm(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to open form: %v", err)
+			return xerrors.Errorf("failed to open form: %w", err)
+		}
+	case CmdUpdateConfiguration:
+		err := c.cmd.updateConfiguration(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to update configuration: %w", err)
+		}
+	case CmdSetVoterWeights:
+		err := c.cmd.setVoterWeights(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to set voter weights: %w", err)
 		}
 	case CmdCastVote:
 		err := c.cmd.castVote(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to cast vote: %v", err)
+			return xerrors.Errorf("failed to cast vote: %w", err)
+		}
+	case CmdCastVotes:
+		err := c.cmd.castVotes(snap, step)
+		if err != nil {
+			return xerrors.Errorf("failed to cast votes: %w", err)
 		}
 	case CmdCloseForm:
 		err := c.cmd.closeForm(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to close form: %v", err)
+			return xerrors.Errorf("failed to close form: %w", err)
 		}
 	case CmdShuffleBallots:
 		err := c.cmd.shuffleBallots(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to shuffle ballots: %v", err)
+			return types.NewRejection(types.CodeInvalidShuffle,
+				"failed to shuffle ballots: %w", err)
 		}
 	case CmdRegisterPubShares:
 		err := c.cmd.registerPubshares(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to register the pubShares: %v", err)
+			return types.NewRejection(types.CodeInvalidPubShares,
+				"failed to register the pubShares: %w", err)
 		}
 	case CmdCombineShares:
 		err := c.cmd.combineShares(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to decrypt ballots: %v", err)
+			return types.NewRejection(types.CodeInvalidDecryption, "failed to decrypt ballots: %w", err)
 		}
 	case CmdCancelForm:
 		err := c.cmd.cancelForm(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to cancel form: %v", err)
+			return xerrors.Errorf("failed to cancel form: %w", err)
 		}
 	case CmdDeleteForm:
 		err := c.cmd.deleteForm(snap, step)
 		if err != nil {
-			return xerrors.Errorf("failed to delete form: %v", err)
+			return xerrors.Errorf("failed to delete form: %w", err)
 		}
 	default:
 		return xerrors.Errorf("unknown command: %s", cmd)
@@ -3,6 +3,7 @@ package evoting
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -69,7 +70,8 @@ func TestExecute(t *testing.T) {
 	contract := NewContract(service, fakeDkg, rosterFac)
 
 	err := contract.Execute(fakeStore{}, makeStep(t))
-	require.EqualError(t, err, "identity not authorized: fake.PublicKey ("+fake.GetError().Error()+")")
+	require.EqualError(t, err, "[UNAUTHORIZED] identity not authorized: fake.PublicKey ("+
+		fake.GetError().Error()+")")
 
 	service = fakeAccess{}
 
@@ -82,17 +84,32 @@ func TestExecute(t *testing.T) {
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCreateForm)))
 	require.EqualError(t, err, fake.Err("failed to create form"))
 
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCreateFormFromTemplate)))
+	require.EqualError(t, err, fake.Err("failed to create form from template"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdSaveTemplate)))
+	require.EqualError(t, err, fake.Err("failed to save template"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdUpdateConfiguration)))
+	require.EqualError(t, err, fake.Err("failed to update configuration"))
+
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdSetVoterWeights)))
+	require.EqualError(t, err, fake.Err("failed to set voter weights"))
+
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCastVote)))
 	require.EqualError(t, err, fake.Err("failed to cast vote"))
 
+	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCastVotes)))
+	require.EqualError(t, err, fake.Err("failed to cast votes"))
+
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCloseForm)))
 	require.EqualError(t, err, fake.Err("failed to close form"))
 
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdShuffleBallots)))
-	require.EqualError(t, err, fake.Err("failed to shuffle ballots"))
+	require.EqualError(t, err, "[INVALID_SHUFFLE] "+fake.Err("failed to shuffle ballots"))
 
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCombineShares)))
-	require.EqualError(t, err, fake.Err("failed to decrypt ballots"))
+	require.EqualError(t, err, "[INVALID_DECRYPTION] "+fake.Err("failed to decrypt ballots"))
 
 	err = contract.Execute(fakeStore{}, makeStep(t, CmdArg, string(CmdCancelForm)))
 	require.EqualError(t, err, fake.Err("failed to cancel form"))
@@ -164,7 +181,351 @@ func TestCommand_CreateForm(t *testing.T) {
 	require.True(t, ok)
 
 	require.Equal(t, types.Initial, form.Status)
+	require.Equal(t, "dummyAdminID", form.AdminID)
 	require.Equal(t, float64(types.Initial), testutil.ToFloat64(PromFormStatus))
+
+	summaryBuf, err := snap.Get(types.FormSummaryKey(formIDBuff))
+	require.NoError(t, err)
+
+	var summary types.FormSummary
+
+	err = json.Unmarshal(summaryBuf, &summary)
+	require.NoError(t, err)
+	require.Equal(t, form.Summary(), summary)
+
+	entry, err := types.GetFormIndexEntry(snap, form.FormID)
+	require.NoError(t, err)
+	require.Equal(t, types.Initial, entry.Status)
+
+	err = cmd.createForm(snap, step)
+	require.EqualError(t, err, fmt.Sprintf("couldn't add new form: id %q already exist",
+		form.FormID))
+}
+
+func TestCommand_FormsIndexMigration(t *testing.T) {
+	dummyForm, contract := initFormAndContract()
+	dummyForm.Status = types.Open
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	snap := fake.NewSnapshot()
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	formsMetadata := types.FormsMetadata{FormsIDs: types.FormIDs{fakeFormID}}
+
+	formsMetadataBuf, err := json.Marshal(formsMetadata)
+	require.NoError(t, err)
+
+	err = snap.Set([]byte(FormsMetadataKey), formsMetadataBuf)
+	require.NoError(t, err)
+
+	deleteForm := types.DeleteForm{
+		FormID: fakeFormID,
+	}
+
+	data, err := deleteForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	// the form is migrated to the index before being deleted
+	err = cmd.deleteForm(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	formsMetadataBuf, err = snap.Get([]byte(FormsMetadataKey))
+	require.NoError(t, err)
+	require.Empty(t, formsMetadataBuf)
+
+	initialized, err := types.IsFormsIndexInitialized(snap)
+	require.NoError(t, err)
+	require.True(t, initialized)
+
+	entry, err := types.GetFormIndexEntry(snap, fakeFormID)
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	// the migration of a form that doesn't exist fails
+	snap = fake.NewSnapshot()
+
+	err = snap.Set([]byte(FormsMetadataKey), []byte(`{"FormsIDs":["`+fakeFormID+`"]}`))
+	require.NoError(t, err)
+
+	err = cmd.ensureFormsIndex(snap)
+	require.ErrorContains(t, err, "failed to migrate form")
+
+	// a migrated form is in the index with its summary
+	snap = fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = snap.Set([]byte(FormsMetadataKey), []byte(`{"FormsIDs":["`+fakeFormID+`"]}`))
+	require.NoError(t, err)
+
+	err = cmd.ensureFormsIndex(snap)
+	require.NoError(t, err)
+
+	entry, err = types.GetFormIndexEntry(snap, fakeFormID)
+	require.NoError(t, err)
+	require.Equal(t, types.Open, entry.Status)
+
+	summaryBuf, err := snap.Get(types.FormSummaryKey(dummyFormIDBuff))
+	require.NoError(t, err)
+	require.NotEmpty(t, summaryBuf)
+}
+
+func TestIterateForms(t *testing.T) {
+	var formIDs []string
+
+	collect := func(formID string) bool {
+		formIDs = append(formIDs, formID)
+		return true
+	}
+
+	err := IterateForms(fake.NewBadSnapshot(), collect)
+	require.ErrorContains(t, err, "failed to check index")
+
+	// no form was created yet
+	snap := fake.NewSnapshot()
+
+	err = IterateForms(snap, collect)
+	require.NoError(t, err)
+	require.Empty(t, formIDs)
+
+	// the legacy FormsMetadata is read until it is migrated
+	err = snap.Set([]byte(FormsMetadataKey), []byte(`{"FormsIDs":["aa","bb"]}`))
+	require.NoError(t, err)
+
+	err = IterateForms(snap, collect)
+	require.NoError(t, err)
+	require.Equal(t, []string{"aa", "bb"}, formIDs)
+
+	err = snap.Set([]byte(FormsMetadataKey), []byte(`{`))
+	require.NoError(t, err)
+
+	err = IterateForms(snap, collect)
+	require.ErrorContains(t, err, "failed to unmarshal FormsMetadata")
+
+	// the forms index is read once it exists
+	err = types.InitFormsIndex(snap)
+	require.NoError(t, err)
+
+	err = types.AddFormToIndex(snap, "cc", types.Open)
+	require.NoError(t, err)
+
+	err = types.AddFormToIndex(snap, "dd", types.Closed)
+	require.NoError(t, err)
+
+	formIDs = nil
+
+	err = IterateForms(snap, func(formID string) bool {
+		formIDs = append(formIDs, formID)
+		return false
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"cc"}, formIDs)
+}
+
+func TestCommand_SaveTemplate(t *testing.T) {
+	saveTemplate := types.SaveTemplate{
+		Name:    "referendum",
+		AdminID: "dummyAdminID",
+		Configuration: types.Configuration{
+			Title: types.Title{Text: types.LangMap{"en": "v1"}},
+		},
+	}
+
+	data, err := saveTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	_, contract := initFormAndContract()
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.saveTemplate(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.saveTemplate(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.saveTemplate(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.Contains(t, err.Error(), "failed to add template version")
+
+	snap := fake.NewSnapshot()
+
+	err = cmd.saveTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	saveTemplate.Configuration.Title = types.Title{Text: types.LangMap{"en": "v2"}}
+
+	data, err = saveTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.saveTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	versions, err := types.TemplateVersions(snap, "referendum")
+	require.NoError(t, err)
+	require.Equal(t, uint(2), versions)
+
+	latest, err := types.GetTemplateVersion(snap, "referendum", types.LatestTemplateVersion)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), latest.Version)
+	require.Equal(t, "v2", latest.Configuration.Title.Text.Get("en"))
+
+	first, err := types.GetTemplateVersion(snap, "referendum", 1)
+	require.NoError(t, err)
+	require.Equal(t, "v1", first.Configuration.Title.Text.Get("en"))
+	require.Equal(t, "dummyAdminID", first.AdminID)
+
+	missing, err := types.GetTemplateVersion(snap, "referendum", 3)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	saveTemplate.Name = ""
+
+	data, err = saveTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.saveTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "the template must have a name")
+}
+
+func TestCommand_CreateFormFromTemplate(t *testing.T) {
+	initMetrics()
+
+	dummyForm, contract := initFormAndContract()
+	dummyForm.Status = types.ResultAvailable
+	dummyForm.Configuration = types.Configuration{
+		Title:          types.Title{Text: types.LangMap{"en": "original"}},
+		AdditionalInfo: types.LangMap{"en": "info"},
+	}
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	createFromTemplate := types.CreateFormFromTemplate{
+		AdminID: "dummyAdminID",
+	}
+
+	data, err := createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "either the source form or the template must be set")
+
+	createFromTemplate.SourceFormID = fakeFormID
+	createFromTemplate.TemplateName = "referendum"
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "only one of the source form and the template can be set")
+
+	createFromTemplate.SourceFormID = ""
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "template \"referendum\" not found")
+
+	// clone the form, overriding its title
+	createFromTemplate.TemplateName = ""
+	createFromTemplate.SourceFormID = fakeFormID
+	createFromTemplate.Title = &types.Title{Text: types.LangMap{"en": "clone"}}
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	step := makeStep(t, FormArg, string(data))
+
+	err = cmd.createFormFromTemplate(snap, step)
+	require.NoError(t, err)
+
+	h := sha256.New()
+	h.Write(step.Current.GetID())
+	formID := hex.EncodeToString(h.Sum(nil))
+
+	form, _, err := cmd.getForm(formID, snap)
+	require.NoError(t, err)
+
+	require.Equal(t, types.Initial, form.Status)
+	require.Equal(t, "clone", form.Configuration.Title.Text.Get("en"))
+	require.Equal(t, "info", form.Configuration.AdditionalInfo.Get("en"))
+
+	entry, err := types.GetFormIndexEntry(snap, formID)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+
+	// create a form from a template
+	_, err = types.AddTemplateVersion(snap, "referendum", "dummyAdminID", types.Configuration{
+		Title: types.Title{Text: types.LangMap{"en": "template"}},
+	})
+	require.NoError(t, err)
+
+	createFromTemplate = types.CreateFormFromTemplate{
+		AdminID:         "dummyAdminID",
+		TemplateName:    "referendum",
+		TemplateVersion: 2,
+	}
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "template \"referendum\" has no version 2")
+
+	// the schedule of the form is set when it is created
+	createFromTemplate.TemplateVersion = types.LatestTemplateVersion
+	createFromTemplate.Schedule = &types.Schedule{OpenAt: 2000, CloseAt: 1000}
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.createFormFromTemplate(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "configuration of form is incoherent or has duplicated IDs")
+
+	createFromTemplate.Schedule = &types.Schedule{OpenAt: 1000, CloseAt: 2000}
+
+	data, err = createFromTemplate.Serialize(ctx)
+	require.NoError(t, err)
+
+	step = makeStep(t, FormArg, string(data))
+
+	err = cmd.createFormFromTemplate(snap, step)
+	require.NoError(t, err)
+
+	h = sha256.New()
+	h.Write(step.Current.GetID())
+
+	form, _, err = cmd.getForm(hex.EncodeToString(h.Sum(nil)), snap)
+	require.NoError(t, err)
+	require.Equal(t, "template", form.Configuration.Title.Text.Get("en"))
+	require.Equal(t, &types.Schedule{OpenAt: 1000, CloseAt: 2000}, form.Configuration.Schedule)
 }
 
 func TestCommand_OpenForm(t *testing.T) {
@@ -304,6 +665,406 @@ func TestCommand_CastVote(t *testing.T) {
 	require.Equal(t, float64(form.BallotCount), testutil.ToFloat64(PromFormBallots))
 }
 
+func TestCommand_UpdateConfiguration(t *testing.T) {
+	configuration := fake.BasicConfiguration
+
+	updateConfiguration := types.UpdateConfiguration{
+		FormID:        fakeFormID,
+		Configuration: configuration,
+	}
+
+	data, err := updateConfiguration.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract()
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.updateConfiguration(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.updateConfiguration(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.updateConfiguration(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.Contains(t, err.Error(), "failed to get key")
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.updateConfiguration(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	form, _, err := cmd.getForm(fakeFormID, snap)
+	require.NoError(t, err)
+
+	require.Equal(t, configuration.Title, form.Configuration.Title)
+	require.Equal(t, configuration.MaxBallotSize(), form.BallotSize)
+	require.NotZero(t, form.BallotSize)
+
+	// IDs must be unique
+	invalid := configuration
+	invalid.Scaffold = []types.Subject{configuration.Scaffold[0], configuration.Scaffold[0]}
+	updateConfiguration.Configuration = invalid
+
+	data, err = updateConfiguration.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.updateConfiguration(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "configuration of form is incoherent or has duplicated IDs")
+
+	dummyForm.Status = types.Open
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.updateConfiguration(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("the form must be in its initial "+
+		"state to update the configuration, current status: %d", types.Open))
+}
+
+func TestCommand_SetVoterWeights(t *testing.T) {
+	setVoterWeights := types.SetVoterWeights{
+		FormID: fakeFormID,
+		VoterWeights: []types.VoterWeight{
+			{UserID: "alice", Weight: 3},
+			{UserID: "carol", Weight: 1},
+		},
+	}
+
+	data, err := setVoterWeights.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract()
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.setVoterWeights(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.setVoterWeights(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	err = cmd.setVoterWeights(fake.NewBadSnapshot(), makeStep(t, FormArg, string(data)))
+	require.Contains(t, err.Error(), "failed to get key")
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.setVoterWeights(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	res, err := snap.Get(dummyFormIDBuff)
+	require.NoError(t, err)
+
+	message, err := formFac.Deserialize(ctx, res)
+	require.NoError(t, err)
+
+	form, ok := message.(types.Form)
+	require.True(t, ok)
+
+	require.True(t, form.IsWeighted())
+	require.Equal(t, uint32(3), form.VoterWeight("alice"))
+	require.Equal(t, uint32(1), form.VoterWeight("carol"))
+	// bob is not listed, so he can't vote
+	require.Equal(t, uint32(0), form.VoterWeight("bob"))
+
+	invalid := []struct {
+		weights []types.VoterWeight
+		err     string
+	}{
+		{
+			[]types.VoterWeight{{UserID: "alice", Weight: 3}, {UserID: "bob", Weight: 0}},
+			`invalid voter weight 1: the weight of user "bob" is 0`,
+		},
+		{
+			[]types.VoterWeight{{UserID: "alice", Weight: 3}, {UserID: "alice", Weight: 1}},
+			`invalid voter weight 1: user "alice" is listed twice`,
+		},
+		{
+			[]types.VoterWeight{{UserID: "", Weight: 3}},
+			"invalid voter weight 0: unknown voter, the user ID is empty",
+		},
+	}
+
+	for _, tc := range invalid {
+		data, err := types.SetVoterWeights{
+			FormID:       fakeFormID,
+			VoterWeights: tc.weights,
+		}.Serialize(ctx)
+		require.NoError(t, err)
+
+		err = cmd.setVoterWeights(snap, makeStep(t, FormArg, string(data)))
+		require.EqualError(t, types.WithRejectionCode(err), "[INVALID_VOTER_WEIGHTS] "+tc.err)
+	}
+
+	dummyForm.Status = types.Open
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.setVoterWeights(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("the form must be in its initial "+
+		"state to set the voter weights, current status: %d", types.Open))
+}
+
+func TestCommand_CastVotes(t *testing.T) {
+	initMetrics()
+
+	newBallot := func() types.Ciphervote {
+		return types.Ciphervote{types.EGPair{
+			K: suite.Point().Pick(suite.RandomStream()),
+			C: suite.Point().Pick(suite.RandomStream()),
+		}}
+	}
+
+	castVotes := types.CastVotes{
+		FormID: fakeFormID,
+		Votes: []types.BatchVote{
+			{UserID: "alice", Ballot: newBallot()},
+			{UserID: "bob", Ballot: newBallot()},
+		},
+	}
+
+	data, err := castVotes.Serialize(ctx)
+	require.NoError(t, err)
+
+	dummyForm, contract := initFormAndContract()
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	err = cmd.castVotes(fake.NewSnapshot(), makeStep(t))
+	require.EqualError(t, err, getTransactionErr)
+
+	err = cmd.castVotes(fake.NewSnapshot(), makeStep(t, FormArg, "dummy"))
+	require.EqualError(t, err, unmarshalTransactionErr)
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	err = cmd.castVotes(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, fmt.Sprintf("the form is not open, current status: %d", types.Initial))
+
+	dummyForm.Status = types.Open
+	dummyForm.BallotSize = 29
+
+	formBuf, err = dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	empty, err := types.CastVotes{FormID: fakeFormID}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVotes(snap, makeStep(t, FormArg, string(empty)))
+	require.EqualError(t, err, "the batch has no vote")
+
+	duplicate, err := types.CastVotes{
+		FormID: fakeFormID,
+		Votes: []types.BatchVote{
+			{UserID: "alice", Ballot: newBallot()},
+			{UserID: "alice", Ballot: newBallot()},
+		},
+	}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVotes(snap, makeStep(t, FormArg, string(duplicate)))
+	require.EqualError(t, err, "invalid vote 1: user \"alice\" already voted in the batch")
+
+	invalid, err := types.CastVotes{
+		FormID: fakeFormID,
+		Votes: []types.BatchVote{
+			{UserID: "alice", Ballot: newBallot()},
+			{UserID: "bob", Ballot: types.Ciphervote{}},
+		},
+	}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVotes(snap, makeStep(t, FormArg, string(invalid)))
+	require.EqualError(t, err, "invalid vote 1: the ballot has unexpected length: 0 != 1")
+
+	// no vote of the rejected batch is cast
+	form, _, err := cmd.getForm(fakeFormID, snap)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), form.BallotCount)
+
+	err = cmd.castVotes(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	form, _, err = cmd.getForm(fakeFormID, snap)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), form.BallotCount)
+
+	suff, err := form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "bob"}, suff.UserIDs)
+	require.True(t, castVotes.Votes[1].Ballot.Equal(suff.Ciphervotes[1]))
+	require.Equal(t, float64(2), testutil.ToFloat64(PromFormBallots))
+}
+
+func TestCommand_CastVoteWeighted(t *testing.T) {
+	initMetrics()
+
+	secret := suite.Scalar().Pick(suite.RandomStream())
+
+	dummyForm, contract := initFormAndContract()
+	dummyForm.Status = types.Open
+	dummyForm.BallotSize = 29
+	dummyForm.Pubkey = suite.Point().Mul(secret, nil)
+	dummyForm.VoterWeights = map[string]uint32{
+		"alice": 5,
+	}
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	weight, err := types.EncryptWeight(dummyForm.Pubkey, 5)
+	require.NoError(t, err)
+
+	castVote := types.CastVote{
+		FormID: fakeFormID,
+		UserID: "bob",
+		Ballot: types.Ciphervote{types.EGPair{
+			K: suite.Point().Pick(suite.RandomStream()),
+			C: suite.Point().Pick(suite.RandomStream()),
+		}},
+		Weight: &weight,
+	}
+
+	data, err := castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, err, "user \"bob\" is not allowed to vote")
+
+	castVote.UserID = "alice"
+	castVote.Weight = nil
+
+	data, err = castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, types.WithRejectionCode(err),
+		"[INVALID_BALLOT] the form is weighted, the ballot must have a weight")
+
+	// the weight of another voter doesn't match the weight of alice
+	otherWeight, err := types.EncryptWeight(dummyForm.Pubkey, 4)
+	require.NoError(t, err)
+
+	castVote.Weight = &otherWeight
+
+	data, err = castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.Error(t, err)
+	require.Regexp(t, `^\[INVALID_BALLOT\] invalid weight of user "alice": `,
+		types.WithRejectionCode(err).Error())
+
+	castVote.Weight = &weight
+
+	data, err = castVote.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.NoError(t, err)
+
+	form, _, err := cmd.getForm(fakeFormID, snap)
+	require.NoError(t, err)
+
+	suff, err := form.Suffragia(ctx, snap)
+	require.NoError(t, err)
+	require.Len(t, suff.Ciphervotes, 1)
+	require.Len(t, suff.Ciphervotes[0], form.CiphervoteSize())
+	require.True(t, castVote.Ballot[0].K.Equal(suff.Ciphervotes[0][0].K))
+	require.True(t, weight.Pair.K.Equal(suff.Ciphervotes[0][1].K))
+
+	// the weight pair can be decrypted with the secret key
+	weightPair := suff.Ciphervotes[0][1]
+	S := suite.Point().Mul(secret, weightPair.K)
+	M := suite.Point().Sub(weightPair.C, S)
+
+	weightBuf, err := M.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 5}, weightBuf)
+}
+
+func TestCommand_CastVoteUnexpectedWeight(t *testing.T) {
+	initMetrics()
+
+	dummyForm, contract := initFormAndContract()
+	dummyForm.Status = types.Open
+	dummyForm.BallotSize = 29
+	dummyForm.Pubkey = suite.Point().Pick(suite.RandomStream())
+
+	formBuf, err := dummyForm.Serialize(ctx)
+	require.NoError(t, err)
+
+	snap := fake.NewSnapshot()
+
+	err = snap.Set(dummyFormIDBuff, formBuf)
+	require.NoError(t, err)
+
+	cmd := evotingCommand{
+		Contract: &contract,
+	}
+
+	weight, err := types.EncryptWeight(dummyForm.Pubkey, 1)
+	require.NoError(t, err)
+
+	data, err := types.CastVote{
+		FormID: fakeFormID,
+		UserID: "alice",
+		Ballot: types.Ciphervote{types.EGPair{
+			K: suite.Point().Pick(suite.RandomStream()),
+			C: suite.Point().Pick(suite.RandomStream()),
+		}},
+		Weight: &weight,
+	}.Serialize(ctx)
+	require.NoError(t, err)
+
+	err = cmd.castVote(snap, makeStep(t, FormArg, string(data)))
+	require.EqualError(t, types.WithRejectionCode(err),
+		"[INVALID_BALLOT] the form is not weighted, the ballot must have no weight")
+}
+
 func TestCommand_CloseForm(t *testing.T) {
 	initMetrics()
 
@@ -1335,14 +2096,34 @@ func (c fakeCmd) createForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
 
+func (c fakeCmd) createFormFromTemplate(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) saveTemplate(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) openForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
 
+func (c fakeCmd) updateConfiguration(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
+func (c fakeCmd) setVoterWeights(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) castVote(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
 
+func (c fakeCmd) castVotes(snap store.Snapshot, step execution.Step) error {
+	return c.err
+}
+
 func (c fakeCmd) closeForm(snap store.Snapshot, step execution.Step) error {
 	return c.err
 }
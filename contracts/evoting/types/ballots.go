@@ -38,6 +38,10 @@ type Ballot struct {
 	// used to map a question ID to its index in the TextResult slice
 	TextResultIDs []ID
 	TextResult    [][]string
+
+	// Weight is the weight of the voter who cast the ballot in a weighted
+	// form. It is 0 if the form is not weighted.
+	Weight uint32 `json:",omitempty"`
 }
 
 // Unmarshal decodes the given string according to the format described in
@@ -80,6 +84,13 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 			return fmt.Errorf("wrong question ID: the question doesn't exist")
 		}
 
+		// The minimum number of answers of a conditional question is only
+		// enforced once we know if its condition is met, see checkConditions.
+		minN := q.GetMinN()
+		if q.GetCondition() != nil {
+			minN = 0
+		}
+
 		switch question[0] {
 
 		case selectID:
@@ -88,7 +99,7 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 			selectQ := Select{
 				ID:      ID(questionID),
 				MaxN:    q.GetMaxN(),
-				MinN:    q.GetMinN(),
+				MinN:    minN,
 				Choices: make([]Choice, q.GetChoicesLength()),
 			}
 
@@ -107,7 +118,7 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 			rankQ := Rank{
 				ID:      ID(questionID),
 				MaxN:    q.GetMaxN(),
-				MinN:    q.GetMinN(),
+				MinN:    minN,
 				Choices: make([]Choice, q.GetChoicesLength()),
 			}
 
@@ -125,7 +136,7 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 			textQ := Text{
 				ID:        ID(questionID),
 				MaxN:      q.GetMaxN(),
-				MinN:      q.GetMinN(),
+				MinN:      minN,
 				MaxLength: 0, // TODO: Should the length check be also done at decryption?
 				Choices:   make([]Choice, q.GetChoicesLength()),
 			}
@@ -145,9 +156,92 @@ func (b *Ballot) Unmarshal(marshalledBallot string, form Form) error {
 
 	}
 
+	err := b.checkConditions(form.Configuration)
+	if err != nil {
+		b.invalidate()
+		return fmt.Errorf("failed to check conditions: %v", err)
+	}
+
 	return nil
 }
 
+// checkConditions verifies the answers of the conditional questions. A
+// question whose condition is met must have a valid number of answers, even
+// if it is omitted from the ballot, and a question whose condition is not met
+// must not be answered at all. Since an unanswered select question has no
+// selected choice, the questions depending on it are not expected either.
+//
+// Unlike a conditional question, an unconditional question omitted from the
+// ballot is not checked, so that the ballots accepted before the conditions
+// existed stay valid.
+func (b *Ballot) checkConditions(config Configuration) error {
+	answered := make(map[ID]uint)
+
+	for i, id := range b.SelectResultIDs {
+		for _, selected := range b.SelectResult[i] {
+			if selected {
+				answered[id]++
+			}
+		}
+	}
+
+	for i, id := range b.RankResultIDs {
+		for _, rank := range b.RankResult[i] {
+			if rank >= 0 {
+				answered[id]++
+			}
+		}
+	}
+
+	for i, id := range b.TextResultIDs {
+		for _, text := range b.TextResult[i] {
+			if text != "" {
+				answered[id]++
+			}
+		}
+	}
+
+	// all the conditional questions are checked, as a question whose
+	// condition is met must be answered even if the ballot omits it
+	for _, question := range config.questions() {
+		q, id := question.Question, question.ID
+
+		condition := q.GetCondition()
+		if condition == nil {
+			continue
+		}
+
+		if !b.isSelected(condition.SelectID, condition.ChoiceIndex) {
+			if answered[id] > 0 {
+				return fmt.Errorf("question %s is answered but its condition "+
+					"on %s is not met", id, condition.SelectID)
+			}
+
+			continue
+		}
+
+		err := checkNumberOfAnswers(q.GetMaxN(), q.GetMinN(), answered[id], id)
+		if err != nil {
+			return fmt.Errorf("failed to check number of answers: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// isSelected returns true if the choice at the given index of the given select
+// question has been selected.
+func (b *Ballot) isSelected(selectID ID, choiceIndex uint) bool {
+	for i, id := range b.SelectResultIDs {
+		if id == selectID {
+			return choiceIndex < uint(len(b.SelectResult[i])) &&
+				b.SelectResult[i][choiceIndex]
+		}
+	}
+
+	return false
+}
+
 // checkNumberOfAnswers checks if the given amount of answers is in the accepted
 // range for the given question
 func checkNumberOfAnswers(maxN uint, minN uint, nbrOfAnswers uint, questionID ID) error {
@@ -172,6 +266,10 @@ func (b *Ballot) invalidate() {
 
 // Equal performs a loose comparison of a ballot.
 func (b *Ballot) Equal(other Ballot) bool {
+	if b.Weight != other.Weight {
+		return false
+	}
+
 	if len(b.SelectResultIDs) != len(other.SelectResultIDs) {
 		return false
 	}
@@ -253,27 +351,33 @@ func (b *Ballot) Equal(other Ballot) bool {
 	return true
 }
 
-// Title contains the titles in different languages.
+// Title contains the titles in different languages and an optional URL.
 type Title struct {
-	En  string
-	Fr  string
-	De  string
-	URL string
+	Text LangMap
+	URL  string
 }
 
 // Hint contains explanations in different languages.
 type Hint struct {
-	En string
-	Fr string
-	De string
+	Text LangMap
 }
 
-// Choice contains a choice and an optional URL
+// Choice contains a choice, possibly translated, and an optional URL
 type Choice struct {
-	Choice string
+	Choice LangMap
 	URL    string
 }
 
+// Condition makes a question depend on the answer to a select question declared
+// before it in the scaffold. The question is only expected if the choice at
+// ChoiceIndex has been selected, for example "if yes on Q1, answer Q2".
+type Condition struct {
+	// SelectID is the ID of the select question the condition depends on
+	SelectID ID
+	// ChoiceIndex is the index of the choice that must be selected
+	ChoiceIndex uint
+}
+
 // Subject is a wrapper around multiple questions that can be of type "select",
 // "rank", or "text".
 type Subject struct {
@@ -322,6 +426,36 @@ func (s *Subject) GetQuestion(ID ID) Question {
 	return nil
 }
 
+// identifiedQuestion is a question with its ID
+type identifiedQuestion struct {
+	Question
+	ID ID
+}
+
+// questions returns all the questions of the subject and of its subjects, in
+// a deterministic order.
+func (s *Subject) questions() []identifiedQuestion {
+	var questions []identifiedQuestion
+
+	for _, subject := range s.Subjects {
+		questions = append(questions, subject.questions()...)
+	}
+
+	for _, selects := range s.Selects {
+		questions = append(questions, identifiedQuestion{Question: selects, ID: selects.ID})
+	}
+
+	for _, rank := range s.Ranks {
+		questions = append(questions, identifiedQuestion{Question: rank, ID: rank.ID})
+	}
+
+	for _, text := range s.Texts {
+		questions = append(questions, identifiedQuestion{Question: text, ID: text.ID})
+	}
+
+	return questions
+}
+
 // MaxEncodedSize returns the maximum amount of bytes taken to store the
 // questions in this subject once encoded in a ballot
 func (s *Subject) MaxEncodedSize() int {
@@ -382,24 +516,28 @@ func (s *Subject) MaxEncodedSize() int {
 }
 
 // isValid verifies that all IDs are unique and the questions have coherent
-// characteristics
-func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
+// characteristics. selects maps the ID of each select question declared so far
+// to its number of choices, so that conditions can only refer to a prior select
+// question.
+func (s *Subject) isValid(uniqueIDs map[ID]bool, selects map[ID]int) bool {
 	prevMapSize := len(uniqueIDs)
 
 	uniqueIDs[s.ID] = true
 
-	for _, rank := range s.Ranks {
-		uniqueIDs[rank.ID] = true
+	for _, sform := range s.Selects {
+		uniqueIDs[sform.ID] = true
 
-		if !isValid(rank) {
+		if !isValid(sform) || !isValidCondition(sform, selects) {
 			return false
 		}
+
+		selects[sform.ID] = len(sform.Choices)
 	}
 
-	for _, sform := range s.Selects {
-		uniqueIDs[sform.ID] = true
+	for _, rank := range s.Ranks {
+		uniqueIDs[rank.ID] = true
 
-		if !isValid(sform) {
+		if !isValid(rank) || !isValidCondition(rank, selects) {
 			return false
 		}
 	}
@@ -407,7 +545,7 @@ func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
 	for _, text := range s.Texts {
 		uniqueIDs[text.ID] = true
 
-		if !isValid(text) {
+		if !isValid(text) || !isValidCondition(text, selects) {
 			return false
 		}
 	}
@@ -419,7 +557,7 @@ func (s *Subject) isValid(uniqueIDs map[ID]bool) bool {
 	}
 
 	for _, subject := range s.Subjects {
-		if !subject.isValid(uniqueIDs) {
+		if !subject.isValid(uniqueIDs, selects) {
 			return false
 		}
 	}
@@ -441,12 +579,26 @@ type Question interface {
 	GetMinN() uint
 	GetChoicesLength() int
 	GetID() string
+	GetCondition() *Condition
 }
 
 func isValid(q Question) bool {
 	return (q.GetMinN() <= q.GetMaxN()) && (q.GetMaxN() <= uint(q.GetChoicesLength()))
 }
 
+// isValidCondition checks that the condition of the question, if any, refers
+// to an existing choice of a prior select question.
+func isValidCondition(q Question, selects map[ID]int) bool {
+	condition := q.GetCondition()
+	if condition == nil {
+		return true
+	}
+
+	nbrChoices, found := selects[condition.SelectID]
+
+	return found && condition.ChoiceIndex < uint(nbrChoices)
+}
+
 // Select describes a "select" question, which requires the user to select one
 // or multiple choices. implements Question
 type Select struct {
@@ -457,6 +609,9 @@ type Select struct {
 	MinN    uint
 	Choices []Choice
 	Hint    Hint
+
+	// Condition is nil if the question is always expected
+	Condition *Condition `json:",omitempty"`
 }
 
 // GetID implements Question
@@ -479,6 +634,11 @@ func (s Select) GetChoicesLength() int {
 	return len(s.Choices)
 }
 
+// GetCondition implements Question
+func (s Select) GetCondition() *Condition {
+	return s.Condition
+}
+
 // unmarshalAnswers interprets the given raw answers into a slice of bool with
 // the answer for each choice and ensure the answers are correctly formatted
 func (s Select) unmarshalAnswers(sforms []string) ([]bool, error) {
@@ -523,6 +683,9 @@ type Rank struct {
 	MinN    uint
 	Choices []Choice
 	Hint    Hint
+
+	// Condition is nil if the question is always expected
+	Condition *Condition `json:",omitempty"`
 }
 
 func (r Rank) GetID() string {
@@ -544,6 +707,11 @@ func (r Rank) GetChoicesLength() int {
 	return len(r.Choices)
 }
 
+// GetCondition implements Question
+func (r Rank) GetCondition() *Condition {
+	return r.Condition
+}
+
 // unmarshalAnswers interprets the given raw answers into a slice of integer
 // representing the ranking of each choice and ensures the answers are correctly
 // formatted
@@ -598,6 +766,9 @@ type Text struct {
 	Regex     string
 	Choices   []Choice
 	Hint      Hint
+
+	// Condition is nil if the question is always expected
+	Condition *Condition `json:",omitempty"`
 }
 
 func (t Text) GetID() string {
@@ -619,6 +790,11 @@ func (t Text) GetChoicesLength() int {
 	return len(t.Choices)
 }
 
+// GetCondition implements Question
+func (t Text) GetCondition() *Condition {
+	return t.Condition
+}
+
 // unmarshalAnswers interprets the given raw answers into a slice with the
 // decoded answer corresponding to each choice and ensure the answers are
 // correctly formatted
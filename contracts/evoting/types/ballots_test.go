@@ -56,13 +56,13 @@ func TestBallot_Unmarshal(t *testing.T) {
 
 		Selects: []Select{{
 			ID:      decodedQuestionID(1),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    2,
 			MinN:    2,
 			Choices: make([]Choice, 3),
 		}, {
 			ID:      decodedQuestionID(2),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    3,
 			MinN:    3,
 			Choices: make([]Choice, 5),
@@ -70,7 +70,7 @@ func TestBallot_Unmarshal(t *testing.T) {
 
 		Ranks: []Rank{{
 			ID:      decodedQuestionID(3),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    4,
 			MinN:    0,
 			Choices: make([]Choice, 4),
@@ -78,7 +78,7 @@ func TestBallot_Unmarshal(t *testing.T) {
 
 		Texts: []Text{{
 			ID:        decodedQuestionID(4),
-			Title:     Title{En: "", Fr: "", De: "", URL: ""},
+			Title:     Title{},
 			MaxN:      2,
 			MinN:      2,
 			MaxLength: 10,
@@ -301,11 +301,86 @@ func TestBallot_Unmarshal(t *testing.T) {
 	require.EqualError(t, err, "question type is unknown")
 }
 
+func TestBallot_UnmarshalConditional(t *testing.T) {
+	form := Form{Configuration: Configuration{Scaffold: []Subject{{
+		Selects: []Select{{
+			ID:      decodedQuestionID(1),
+			MaxN:    1,
+			MinN:    1,
+			Choices: make([]Choice, 2),
+		}},
+		Texts: []Text{{
+			ID:        decodedQuestionID(2),
+			MaxN:      1,
+			MinN:      1,
+			MaxLength: 10,
+			Choices:   make([]Choice, 1),
+			Condition: &Condition{SelectID: decodedQuestionID(1), ChoiceIndex: 0},
+		}},
+	}}}}
+
+	b := Ballot{}
+
+	// condition met and question answered
+	ballot := string(selectIDTest + encodedQuestionID(1) + ":1,0\n" +
+		textIDTest + encodedQuestionID(2) + ":eWVz\n\n")
+
+	err := b.Unmarshal(ballot, form)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"yes"}}, b.TextResult)
+
+	// condition met but question not answered
+	ballot = string(selectIDTest + encodedQuestionID(1) + ":1,0\n" +
+		textIDTest + encodedQuestionID(2) + ":\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.EqualError(t, err, "failed to check conditions: failed to check "+
+		"number of answers: question Q2 has not enough selected answers")
+
+	// condition met but question omitted
+	ballot = string(selectIDTest + encodedQuestionID(1) + ":1,0\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.EqualError(t, err, "failed to check conditions: failed to check "+
+		"number of answers: question Q2 has not enough selected answers")
+	require.Nil(t, b.SelectResult)
+
+	// condition not met and question not answered
+	ballot = string(selectIDTest + encodedQuestionID(1) + ":0,1\n" +
+		textIDTest + encodedQuestionID(2) + ":\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.NoError(t, err)
+
+	// condition not met and question omitted
+	ballot = string(selectIDTest + encodedQuestionID(1) + ":0,1\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.NoError(t, err)
+
+	// condition not met but question answered
+	ballot = string(selectIDTest + encodedQuestionID(1) + ":0,1\n" +
+		textIDTest + encodedQuestionID(2) + ":eWVz\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.EqualError(t, err, "failed to check conditions: question Q2 is "+
+		"answered but its condition on Q1 is not met")
+	require.Nil(t, b.TextResult)
+
+	// unconditional question omitted: its minimum is not enforced, as before
+	// conditional questions existed, and the condition on it is not met
+	ballot = string(textIDTest + encodedQuestionID(2) + ":\n\n")
+
+	err = b.Unmarshal(ballot, form)
+	require.NoError(t, err)
+	require.Empty(t, b.SelectResult)
+}
+
 func TestSubject_MaxEncodedSize(t *testing.T) {
 	subject := Subject{
 		Subjects: []Subject{{
 			ID:       "",
-			Title:    Title{En: "", Fr: "", De: "", URL: ""},
+			Title:    Title{},
 			Order:    nil,
 			Subjects: []Subject{},
 			Selects:  []Select{},
@@ -315,13 +390,13 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 
 		Selects: []Select{{
 			ID:      decodedQuestionID(1),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    3,
 			MinN:    0,
 			Choices: make([]Choice, 3),
 		}, {
 			ID:      decodedQuestionID(2),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    5,
 			MinN:    0,
 			Choices: make([]Choice, 5),
@@ -329,7 +404,7 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 
 		Ranks: []Rank{{
 			ID:      decodedQuestionID(3),
-			Title:   Title{En: "", Fr: "", De: "", URL: ""},
+			Title:   Title{},
 			MaxN:    4,
 			MinN:    0,
 			Choices: make([]Choice, 4),
@@ -337,7 +412,7 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 
 		Texts: []Text{{
 			ID:        decodedQuestionID(4),
-			Title:     Title{En: "", Fr: "", De: "", URL: ""},
+			Title:     Title{},
 			MaxN:      2,
 			MinN:      0,
 			MaxLength: 10,
@@ -345,7 +420,7 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 			Choices:   make([]Choice, 2),
 		}, {
 			ID:        decodedQuestionID(5),
-			Title:     Title{En: "", Fr: "", De: "", URL: ""},
+			Title:     Title{},
 			MaxN:      1,
 			MinN:      0,
 			MaxLength: 10,
@@ -355,7 +430,7 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 	}
 
 	conf := Configuration{
-		Title:    Title{En: "", Fr: "", De: "", URL: ""},
+		Title:    Title{},
 		Scaffold: []Subject{subject},
 	}
 
@@ -368,7 +443,7 @@ func TestSubject_MaxEncodedSize(t *testing.T) {
 func TestSubject_IsValid(t *testing.T) {
 	mainSubject := &Subject{
 		ID:       ID(base64.StdEncoding.EncodeToString([]byte("S1"))),
-		Title:    Title{En: "", Fr: "", De: "", URL: ""},
+		Title:    Title{},
 		Order:    []ID{},
 		Subjects: []Subject{},
 		Selects:  []Select{},
@@ -378,7 +453,7 @@ func TestSubject_IsValid(t *testing.T) {
 
 	subSubject := &Subject{
 		ID:       ID(base64.StdEncoding.EncodeToString([]byte("S2"))),
-		Title:    Title{En: "", Fr: "", De: "", URL: ""},
+		Title:    Title{},
 		Order:    []ID{},
 		Subjects: []Subject{},
 		Selects:  []Select{},
@@ -387,7 +462,7 @@ func TestSubject_IsValid(t *testing.T) {
 	}
 
 	configuration := Configuration{
-		Title:    Title{En: "", Fr: "", De: "", URL: ""},
+		Title:    Title{},
 		Scaffold: []Subject{*mainSubject, *subSubject},
 	}
 
@@ -400,7 +475,7 @@ func TestSubject_IsValid(t *testing.T) {
 
 	mainSubject.Selects = []Select{{
 		ID:      encodedQuestionID(1),
-		Title:   Title{En: "", Fr: "", De: "", URL: ""},
+		Title:   Title{},
 		MaxN:    0,
 		MinN:    0,
 		Choices: make([]Choice, 0),
@@ -408,7 +483,7 @@ func TestSubject_IsValid(t *testing.T) {
 
 	mainSubject.Ranks = []Rank{{
 		ID:      encodedQuestionID(1),
-		Title:   Title{En: "", Fr: "", De: "", URL: ""},
+		Title:   Title{},
 		MaxN:    0,
 		MinN:    0,
 		Choices: make([]Choice, 0),
@@ -423,7 +498,7 @@ func TestSubject_IsValid(t *testing.T) {
 
 	mainSubject.Ranks[0] = Rank{
 		ID:      encodedQuestionID(2),
-		Title:   Title{En: "", Fr: "", De: "", URL: ""},
+		Title:   Title{},
 		MaxN:    0,
 		MinN:    2,
 		Choices: make([]Choice, 0),
@@ -439,7 +514,7 @@ func TestSubject_IsValid(t *testing.T) {
 	mainSubject.Ranks = []Rank{}
 	mainSubject.Selects[0] = Select{
 		ID:      encodedQuestionID(1),
-		Title:   Title{En: "", Fr: "", De: "", URL: ""},
+		Title:   Title{},
 		MaxN:    1,
 		MinN:    0,
 		Choices: make([]Choice, 0),
@@ -455,7 +530,7 @@ func TestSubject_IsValid(t *testing.T) {
 	mainSubject.Selects = []Select{}
 	mainSubject.Texts = []Text{{
 		ID:        encodedQuestionID(3),
-		Title:     Title{En: "", Fr: "", De: "", URL: ""},
+		Title:     Title{},
 		MaxN:      2,
 		MinN:      4,
 		MaxLength: 0,
@@ -484,6 +559,61 @@ func TestSubject_IsValid(t *testing.T) {
 	valid = configuration.IsValid()
 	require.False(t, valid)
 
+	// with a condition on a prior select question
+
+	conditional := *mainSubject
+	conditional.Order = nil
+	conditional.Subjects = []Subject{}
+	conditional.Texts = []Text{}
+	conditional.Selects = []Select{{
+		ID:      encodedQuestionID(1),
+		MaxN:    1,
+		MinN:    0,
+		Choices: make([]Choice, 2),
+	}}
+	conditional.Ranks = []Rank{{
+		ID:        encodedQuestionID(2),
+		MaxN:      1,
+		MinN:      1,
+		Choices:   make([]Choice, 1),
+		Condition: &Condition{SelectID: encodedQuestionID(1), ChoiceIndex: 1},
+	}}
+
+	configuration.Scaffold = []Subject{conditional}
+
+	valid = configuration.IsValid()
+	require.True(t, valid)
+
+	// with a condition on a choice that doesn't exist
+
+	conditional.Ranks[0].Condition = &Condition{SelectID: encodedQuestionID(1), ChoiceIndex: 2}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	// with a condition on a question that is not a select
+
+	conditional.Ranks[0].Condition = &Condition{SelectID: encodedQuestionID(2)}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
+	// with a condition on a select declared after the question
+
+	conditional.Ranks[0].Condition = nil
+	conditional.Selects[0].Condition = &Condition{SelectID: encodedQuestionID(4)}
+	conditional.Subjects = []Subject{{
+		ID: encodedQuestionID(3),
+		Selects: []Select{{
+			ID:      encodedQuestionID(4),
+			MaxN:    1,
+			Choices: make([]Choice, 1),
+		}},
+	}}
+
+	valid = configuration.IsValid()
+	require.False(t, valid)
+
 	// with unknown ID in Order
 
 	mainSubject.Subjects = []Subject{}
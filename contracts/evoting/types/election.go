@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -71,8 +72,10 @@ type Form struct {
 	// the form
 	FormID string
 
-	Status Status
-	Pubkey kyber.Point
+	// AdminID is the ID of the admin who created the form
+	AdminID string
+	Status  Status
+	Pubkey  kyber.Point
 
 	// BallotSize represents the total size in bytes of one ballot. It is used
 	// to pad smaller ballots such that all  ballots cast have the same size
@@ -105,6 +108,11 @@ type Form struct {
 
 	DecryptedBallots []Ballot
 
+	// VoterWeights maps a UserID to the weight of its ballot. It is set by the
+	// admin before the form is opened. An empty table means that all ballots
+	// count equally, otherwise only the listed users can vote.
+	VoterWeights map[string]uint32
+
 	// roster is set when the form is created based on the current
 	// roster of the node stored in the global state. The roster will not change
 	// during a form and will be used for DKG and Neff. Its type is
@@ -200,6 +208,110 @@ func (e *Form) ChunksPerBallot() int {
 	return e.BallotSize/29 + 1
 }
 
+// FormSummary is a lightweight view of a form that is stored next to the form,
+// so that forms can be listed without deserializing their ballots and
+// shuffles.
+type FormSummary struct {
+	FormID string
+	Title  Title
+	Status Status
+	// Pubkey is hex-encoded, empty if the form is not open yet
+	Pubkey      string
+	AdminID     string
+	BallotCount uint32
+	// ShuffleRounds is the number of shuffles done so far
+	ShuffleRounds int
+	// PubsharesSubmissions is the number of nodes that submitted their
+	// public shares so far
+	PubsharesSubmissions int
+}
+
+// formSummaryPrefix is prepended to the form ID to get the key of the summary
+const formSummaryPrefix = "FormSummary:"
+
+// FormSummaryKey returns the key at which the summary of the form is stored.
+func FormSummaryKey(formID []byte) []byte {
+	return append([]byte(formSummaryPrefix), formID...)
+}
+
+// Summary returns the summary of the form.
+func (e *Form) Summary() FormSummary {
+	pubkey := ""
+
+	if e.Pubkey != nil {
+		buf, err := e.Pubkey.MarshalBinary()
+		if err == nil {
+			pubkey = hex.EncodeToString(buf)
+		}
+	}
+
+	return FormSummary{
+		FormID:      e.FormID,
+		Title:       e.Configuration.Title,
+		Status:      e.Status,
+		Pubkey:      pubkey,
+		AdminID:     e.AdminID,
+		BallotCount: e.BallotCount,
+
+		ShuffleRounds:        len(e.ShuffleInstances),
+		PubsharesSubmissions: len(e.PubsharesUnits.Pubshares),
+	}
+}
+
+// FormSummaryFromStore returns the summary of a form from the store given the
+// formIDHex. Forms saved before summaries were introduced don't have one, in
+// which case the summary is computed from the whole form.
+func FormSummaryFromStore(ctx serde.Context, formFac serde.Factory, formIDHex string,
+	store store.Readable) (FormSummary, error) {
+
+	var summary FormSummary
+
+	formIDBuf, err := hex.DecodeString(formIDHex)
+	if err != nil {
+		return summary, xerrors.Errorf("failed to decode formIDHex: %v", err)
+	}
+
+	buf, err := store.Get(FormSummaryKey(formIDBuf))
+	if err == nil && len(buf) != 0 {
+		err = json.Unmarshal(buf, &summary)
+		if err != nil {
+			return summary, xerrors.Errorf("failed to unmarshal FormSummary: %v", err)
+		}
+
+		return summary, nil
+	}
+
+	form, err := FormFromStore(ctx, formFac, formIDHex, store)
+	if err != nil {
+		return summary, xerrors.Errorf("failed to get form: %v", err)
+	}
+
+	return form.Summary(), nil
+}
+
+// IsWeighted returns true if the ballots of the form are weighted by the weight
+// of their voter.
+func (e *Form) IsWeighted() bool {
+	return len(e.VoterWeights) > 0
+}
+
+// VoterWeight returns the weight of the given user in a weighted form. It is 0
+// if the user is not listed, and thus not allowed to vote.
+func (e *Form) VoterWeight(userID string) uint32 {
+	return e.VoterWeights[userID]
+}
+
+// CiphervoteSize returns the number of El Gamal pairs of a cast ciphervote. In
+// a weighted form, the contract appends one pair holding the encrypted weight
+// of the voter to the ChunksPerBallot pairs of the ballot.
+func (e *Form) CiphervoteSize() int {
+	if e.IsWeighted() {
+		return e.ChunksPerBallot() + 1
+	}
+
+	return e.ChunksPerBallot()
+}
+
 // CastVote stores the new vote in the memory.
 func (s *Form) CastVote(ctx serde.Context, st store.Snapshot, userID string, ciphervote Ciphervote) error {
 	var suff Suffragia
@@ -336,7 +448,35 @@ type ShuffleInstance struct {
 type Configuration struct {
 	Title          Title
 	Scaffold       []Subject
-	AdditionalInfo string
+	AdditionalInfo LangMap
+
+	// Languages are the BCP-47 tags of the languages every element of the
+	// form must be translated into. No check is done if it is empty.
+	Languages []string `json:",omitempty"`
+
+	// Schedule is the planned opening and closing of the form, if any
+	Schedule *Schedule `json:",omitempty"`
+}
+
+// Schedule is the planned opening and closing of a form, as UNIX times in
+// seconds, where 0 means that it is not planned. The contract has no clock, so
+// it doesn't enforce the schedule: the administrators open and close the form.
+type Schedule struct {
+	OpenAt  int64 `json:",omitempty"`
+	CloseAt int64 `json:",omitempty"`
+}
+
+// isValid returns false if the form is planned to close before it opens.
+func (s *Schedule) isValid() bool {
+	if s == nil {
+		return true
+	}
+
+	if s.OpenAt < 0 || s.CloseAt < 0 {
+		return false
+	}
+
+	return s.OpenAt == 0 || s.CloseAt == 0 || s.OpenAt < s.CloseAt
 }
 
 // MaxBallotSize returns the maximum number of bytes required to store a ballot
@@ -362,14 +502,61 @@ func (c *Configuration) GetQuestion(ID ID) Question {
 	return nil
 }
 
+// questions returns all the questions of the configuration, in a
+// deterministic order.
+func (c *Configuration) questions() []identifiedQuestion {
+	var questions []identifiedQuestion
+
+	for _, subject := range c.Scaffold {
+		questions = append(questions, subject.questions()...)
+	}
+
+	return questions
+}
+
 // IsValid returns true if and only if the whole configuration is coherent and
 // valid.
 func (c *Configuration) IsValid() bool {
 	// serves as a set to check each ID is unique
 	uniqueIDs := make(map[ID]bool)
 
+	// select questions that conditional questions can depend on
+	selects := make(map[ID]int)
+
+	if !c.Schedule.isValid() {
+		return false
+	}
+
+	for _, subject := range c.Scaffold {
+		if !subject.isValid(uniqueIDs, selects) {
+			return false
+		}
+	}
+
+	return c.isTranslated()
+}
+
+// isTranslated checks that every element of the configuration is translated
+// into the declared languages.
+func (c *Configuration) isTranslated() bool {
+	if !isValidLanguages(c.Languages) {
+		return false
+	}
+
+	if len(c.Languages) == 0 {
+		return true
+	}
+
+	if !c.Title.Text.IsTranslated(c.Languages) {
+		return false
+	}
+
+	if !c.AdditionalInfo.IsEmpty() && !c.AdditionalInfo.IsTranslated(c.Languages) {
+		return false
+	}
+
 	for _, subject := range c.Scaffold {
-		if !subject.isValid(uniqueIDs) {
+		if !subject.isTranslated(c.Languages) {
 			return false
 		}
 	}
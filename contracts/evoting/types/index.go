@@ -0,0 +1,308 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// The forms index stores one key per form under the formsIndexPrefix
+// namespace, so that creating or deleting a form does not rewrite the list of
+// all forms. Since the storage can't be iterated, the forms are also stored at
+// their position in the creation order, and at their position in the index of
+// their status. A deleted form leaves an empty position behind in the creation
+// order, and a form leaving a status leaves one behind in the index of this
+// status. The positions never change, as they are the cursors of the listing.
+// Since a form goes through a handful of statuses, the status indexes have at
+// most a few positions per form.
+//
+// There is no secondary key on the block that created the form, as a contract
+// doesn't know the index of the block it is executed in. The creation order is
+// the order of the blocks.
+//
+//	FormsIndex:count             -> number of positions in the creation order
+//	FormsIndex:pos:<n>           -> form ID at position n
+//	FormsIndex:form:<formID>     -> FormIndexEntry
+//	FormsIndex:status:<s>:count  -> number of positions in the status index
+//	FormsIndex:status:<s>:<n>    -> form ID at position n of the status index
+const formsIndexPrefix = "FormsIndex:"
+
+// FormIndexEntry is the entry of a form in the forms index.
+type FormIndexEntry struct {
+	FormID string
+	// Position is the position of the form in the creation order
+	Position uint64
+	Status   Status
+	// StatusPosition is the position of the form in the index of its status
+	StatusPosition uint64
+}
+
+func formsIndexCountKey() []byte {
+	return []byte(formsIndexPrefix + "count")
+}
+
+func formsIndexPositionKey(position uint64) []byte {
+	return []byte(formsIndexPrefix + "pos:" + strconv.FormatUint(position, 10))
+}
+
+func formsIndexEntryKey(formID string) []byte {
+	return []byte(formsIndexPrefix + "form:" + formID)
+}
+
+func formsIndexStatusCountKey(status Status) []byte {
+	return []byte(formsIndexPrefix + "status:" + strconv.Itoa(int(status)) + ":count")
+}
+
+func formsIndexStatusPositionKey(status Status, position uint64) []byte {
+	return []byte(formsIndexPrefix + "status:" + strconv.Itoa(int(status)) + ":" +
+		strconv.FormatUint(position, 10))
+}
+
+// IsFormsIndexInitialized returns true if the forms index exists. It doesn't
+// exist on a chain where forms were only listed in the legacy FormsMetadata.
+func IsFormsIndexInitialized(rd store.Readable) (bool, error) {
+	buf, err := rd.Get(formsIndexCountKey())
+	if err != nil {
+		return false, xerrors.Errorf("failed to get count: %v", err)
+	}
+
+	return len(buf) != 0, nil
+}
+
+// InitFormsIndex creates an empty forms index.
+func InitFormsIndex(snap store.Snapshot) error {
+	return setCounter(snap, formsIndexCountKey(), 0)
+}
+
+// GetFormIndexEntry returns the entry of the form, or nil if the form is not
+// in the index.
+func GetFormIndexEntry(rd store.Readable, formID string) (*FormIndexEntry, error) {
+	buf, err := rd.Get(formsIndexEntryKey(formID))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get entry: %v", err)
+	}
+
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	var entry FormIndexEntry
+
+	err = json.Unmarshal(buf, &entry)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal entry: %v", err)
+	}
+
+	return &entry, nil
+}
+
+// AddFormToIndex adds the form at the end of the creation order and of the
+// index of its status.
+func AddFormToIndex(snap store.Snapshot, formID string, status Status) error {
+	position, err := appendPosition(snap, formsIndexCountKey(), formsIndexPositionKey, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to add to creation order: %v", err)
+	}
+
+	statusPosition, err := appendStatusPosition(snap, status, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to add to status index: %v", err)
+	}
+
+	entry := FormIndexEntry{
+		FormID:         formID,
+		Position:       position,
+		Status:         status,
+		StatusPosition: statusPosition,
+	}
+
+	return setEntry(snap, entry)
+}
+
+// UpdateFormStatusInIndex moves the form to the index of its new status. A
+// form that is not in the index yet is added to it.
+func UpdateFormStatusInIndex(snap store.Snapshot, formID string, status Status) error {
+	entry, err := GetFormIndexEntry(snap, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to get entry: %v", err)
+	}
+
+	if entry == nil {
+		return AddFormToIndex(snap, formID, status)
+	}
+
+	if entry.Status == status {
+		return nil
+	}
+
+	err = removeStatusPosition(snap, entry.Status, entry.StatusPosition)
+	if err != nil {
+		return xerrors.Errorf("failed to remove from status index: %v", err)
+	}
+
+	entry.Status = status
+
+	entry.StatusPosition, err = appendStatusPosition(snap, status, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to add to status index: %v", err)
+	}
+
+	return setEntry(snap, *entry)
+}
+
+// RemoveFormFromIndex removes the form from the index. It does nothing if the
+// form is not in the index.
+func RemoveFormFromIndex(snap store.Snapshot, formID string) error {
+	entry, err := GetFormIndexEntry(snap, formID)
+	if err != nil {
+		return xerrors.Errorf("failed to get entry: %v", err)
+	}
+
+	if entry == nil {
+		return nil
+	}
+
+	err = removeStatusPosition(snap, entry.Status, entry.StatusPosition)
+	if err != nil {
+		return xerrors.Errorf("failed to remove from status index: %v", err)
+	}
+
+	keys := [][]byte{
+		formsIndexPositionKey(entry.Position),
+		formsIndexEntryKey(formID),
+	}
+
+	for _, key := range keys {
+		err = snap.Delete(key)
+		if err != nil {
+			return xerrors.Errorf("failed to delete %q: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// IterateFormsIndex calls fn with the position and the ID of the forms in the
+// creation order, starting at the given position. If status is not nil, only
+// the forms with this status are iterated, in the order of the status index,
+// and the positions are the ones of this index. The iteration stops when fn
+// returns false.
+func IterateFormsIndex(rd store.Readable, status *Status, start uint64,
+	fn func(position uint64, formID string) bool) error {
+
+	countKey := formsIndexCountKey()
+	positionKey := formsIndexPositionKey
+
+	if status != nil {
+		countKey = formsIndexStatusCountKey(*status)
+		positionKey = func(position uint64) []byte {
+			return formsIndexStatusPositionKey(*status, position)
+		}
+	}
+
+	count, err := getCounter(rd, countKey)
+	if err != nil {
+		return xerrors.Errorf("failed to get count: %v", err)
+	}
+
+	for position := start; position < count; position++ {
+		formID, err := rd.Get(positionKey(position))
+		if err != nil {
+			return xerrors.Errorf("failed to get position %d: %v", position, err)
+		}
+
+		// the form at this position has been deleted
+		if len(formID) == 0 {
+			continue
+		}
+
+		if !fn(position, string(formID)) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func appendStatusPosition(snap store.Snapshot, status Status, formID string) (uint64, error) {
+	positionKey := func(position uint64) []byte {
+		return formsIndexStatusPositionKey(status, position)
+	}
+
+	return appendPosition(snap, formsIndexStatusCountKey(status), positionKey, formID)
+}
+
+// removeStatusPosition removes a position from the index of a status. The
+// position is left empty, so that the other forms keep their position.
+func removeStatusPosition(snap store.Snapshot, status Status, position uint64) error {
+	err := snap.Delete(formsIndexStatusPositionKey(status, position))
+	if err != nil {
+		return xerrors.Errorf("failed to delete position: %v", err)
+	}
+
+	return nil
+}
+
+// appendPosition stores the ID at the next position of a list and returns
+// this position.
+func appendPosition(snap store.Snapshot, countKey []byte,
+	positionKey func(uint64) []byte, id string) (uint64, error) {
+
+	position, err := getCounter(snap, countKey)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to get count: %v", err)
+	}
+
+	err = snap.Set(positionKey(position), []byte(id))
+	if err != nil {
+		return 0, xerrors.Errorf("failed to set position: %v", err)
+	}
+
+	err = setCounter(snap, countKey, position+1)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to set count: %v", err)
+	}
+
+	return position, nil
+}
+
+func setEntry(snap store.Snapshot, entry FormIndexEntry) error {
+	buf, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal entry: %v", err)
+	}
+
+	err = snap.Set(formsIndexEntryKey(entry.FormID), buf)
+	if err != nil {
+		return xerrors.Errorf("failed to set entry: %v", err)
+	}
+
+	return nil
+}
+
+func getCounter(rd store.Readable, key []byte) (uint64, error) {
+	buf, err := rd.Get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	if len(buf) != 8 {
+		return 0, xerrors.Errorf("invalid counter length: %d", len(buf))
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+func setCounter(snap store.Snapshot, key []byte, value uint64) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, value)
+
+	return snap.Set(key, buf)
+}
@@ -0,0 +1,176 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+func TestFormsIndex(t *testing.T) {
+	snap := newFakeSnapshot()
+
+	initialized, err := IsFormsIndexInitialized(snap)
+	require.NoError(t, err)
+	require.False(t, initialized)
+
+	err = InitFormsIndex(snap)
+	require.NoError(t, err)
+
+	initialized, err = IsFormsIndexInitialized(snap)
+	require.NoError(t, err)
+	require.True(t, initialized)
+
+	require.NoError(t, AddFormToIndex(snap, "aa", Initial))
+	require.NoError(t, AddFormToIndex(snap, "bb", Initial))
+	require.NoError(t, AddFormToIndex(snap, "cc", Open))
+
+	entry, err := GetFormIndexEntry(snap, "bb")
+	require.NoError(t, err)
+	require.Equal(t, FormIndexEntry{FormID: "bb", Position: 1, Status: Initial,
+		StatusPosition: 1}, *entry)
+
+	entry, err = GetFormIndexEntry(snap, "dd")
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	// unchanged status
+	require.NoError(t, UpdateFormStatusInIndex(snap, "bb", Initial))
+	// moves to the Open index
+	require.NoError(t, UpdateFormStatusInIndex(snap, "aa", Open))
+	// added to the index
+	require.NoError(t, UpdateFormStatusInIndex(snap, "dd", Closed))
+
+	require.NoError(t, RemoveFormFromIndex(snap, "cc"))
+	require.NoError(t, RemoveFormFromIndex(snap, "unknown"))
+
+	// the other forms keep their position in the status indexes
+	entry, err = GetFormIndexEntry(snap, "bb")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), entry.StatusPosition)
+
+	entry, err = GetFormIndexEntry(snap, "aa")
+	require.NoError(t, err)
+	require.Equal(t, FormIndexEntry{FormID: "aa", Position: 0, Status: Open,
+		StatusPosition: 1}, *entry)
+
+	require.Equal(t, []string{"aa", "bb", "dd"}, iterate(t, snap, nil, 0))
+	require.Equal(t, []string{"bb", "dd"}, iterate(t, snap, nil, 1))
+
+	status := Open
+	require.Equal(t, []string{"aa"}, iterate(t, snap, &status, 0))
+
+	status = Initial
+	require.Equal(t, []string{"bb"}, iterate(t, snap, &status, 0))
+
+	status = Closed
+	require.Equal(t, []string{"dd"}, iterate(t, snap, &status, 0))
+
+	// stops when the function returns false
+	var ids []string
+
+	err = IterateFormsIndex(snap, nil, 0, func(position uint64, formID string) bool {
+		ids = append(ids, formID)
+		return false
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"aa"}, ids)
+
+	// a form going through all the statuses leaves one position behind in
+	// each of them
+	for _, status := range []Status{Closed, ShuffledBallots, PubSharesSubmitted,
+		ResultAvailable} {
+
+		require.NoError(t, UpdateFormStatusInIndex(snap, "bb", status))
+		require.NoError(t, UpdateFormStatusInIndex(snap, "dd", status))
+	}
+
+	status = ResultAvailable
+	require.Equal(t, []string{"bb", "dd"}, iterate(t, snap, &status, 0))
+
+	for _, status := range []Status{Initial, Closed, ShuffledBallots, PubSharesSubmitted} {
+		require.Empty(t, iterate(t, snap, &status, 0))
+	}
+
+	snap.err = xerrors.New("oops")
+
+	err = IterateFormsIndex(snap, nil, 0, nil)
+	require.EqualError(t, err, "failed to get count: oops")
+}
+
+func TestFormsIndex_StatusChangeBetweenPages(t *testing.T) {
+	snap := newFakeSnapshot()
+
+	for _, formID := range []string{"aa", "bb", "cc", "dd"} {
+		require.NoError(t, AddFormToIndex(snap, formID, Open))
+	}
+
+	status := Open
+
+	// first page of two forms
+	var page []string
+	var cursor uint64
+
+	err := IterateFormsIndex(snap, &status, 0, func(position uint64, formID string) bool {
+		page = append(page, formID)
+		cursor = position + 1
+		return len(page) < 2
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"aa", "bb"}, page)
+
+	// a form of the first page is closed before the second page is read, and
+	// the forms of the second page keep their position
+	require.NoError(t, UpdateFormStatusInIndex(snap, "aa", Closed))
+
+	require.Equal(t, []string{"cc", "dd"}, iterate(t, snap, &status, cursor))
+
+	// a form of the second page is closed before it is read, so it is not
+	// listed, and the other ones are neither skipped nor listed twice
+	require.NoError(t, UpdateFormStatusInIndex(snap, "cc", Closed))
+
+	require.Equal(t, []string{"dd"}, iterate(t, snap, &status, cursor))
+	require.Equal(t, []string{"bb", "dd"}, iterate(t, snap, &status, 0))
+}
+
+func iterate(t *testing.T, snap store.Readable, status *Status, start uint64) []string {
+	var ids []string
+
+	err := IterateFormsIndex(snap, status, start, func(position uint64, formID string) bool {
+		ids = append(ids, formID)
+		return true
+	})
+	require.NoError(t, err)
+
+	return ids
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// fakeSnapshot is an in-memory snapshot. The one of the fake package can't be
+// used because it imports this package.
+type fakeSnapshot struct {
+	store.Snapshot
+	values map[string][]byte
+	err    error
+}
+
+func newFakeSnapshot() *fakeSnapshot {
+	return &fakeSnapshot{values: make(map[string][]byte)}
+}
+
+func (s *fakeSnapshot) Get(key []byte) ([]byte, error) {
+	return s.values[string(key)], s.err
+}
+
+func (s *fakeSnapshot) Set(key, value []byte) error {
+	s.values[string(key)] = value
+	return s.err
+}
+
+func (s *fakeSnapshot) Delete(key []byte) error {
+	delete(s.values, string(key))
+	return s.err
+}
@@ -0,0 +1,309 @@
+package types
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// neutralLang is the key of a text that does not depend on the language, such
+// as the name of a candidate. Legacy choices and additional infos, which were
+// plain strings, are decoded under this key.
+const neutralLang = ""
+
+// urlKey is the key of the URL in the legacy JSON representation of a title
+const urlKey = "URL"
+
+// textKey is the key of the translations in the JSON representation of a
+// title
+const textKey = "Text"
+
+// langTagRegex is a loose check of the BCP-47 syntax, for example "en",
+// "fr-CH" or "zh-Hant-TW".
+var langTagRegex = regexp.MustCompile(`^[a-zA-Z]{2,8}(-[a-zA-Z0-9]{1,8})*$`)
+
+// LangMap maps a BCP-47 language tag to a text in this language. Tags are
+// compared case-insensitively, so that legacy keys such as "En" match "en".
+type LangMap map[string]string
+
+// Get returns the text in the given language. It falls back to the
+// language-neutral text, or an empty string if there is none. If several tags
+// only differ by their case, the first one in the sorted order is used, so
+// that every node gets the same text.
+func (m LangMap) Get(lang string) string {
+	text, found := m[lang]
+	if found {
+		return text
+	}
+
+	tags := make([]string, 0, len(m))
+	for tag := range m {
+		if tag != neutralLang && strings.EqualFold(tag, lang) {
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		return m[tags[0]]
+	}
+
+	return m[neutralLang]
+}
+
+// IsEmpty returns true if the map holds no text.
+func (m LangMap) IsEmpty() bool {
+	for _, text := range m {
+		if text != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsTranslated returns true if there is a non-empty text for each of the
+// given languages. A language-neutral text is valid for all languages.
+func (m LangMap) IsTranslated(langs []string) bool {
+	for _, lang := range langs {
+		if m.Get(lang) == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalJSON implements json.Marshaler. A map that only holds a
+// language-neutral text is encoded as a plain string, as it was before
+// translations were introduced.
+func (m LangMap) MarshalJSON() ([]byte, error) {
+	if len(m) == 0 || (len(m) == 1 && m[neutralLang] != "") {
+		return json.Marshal(m[neutralLang])
+	}
+
+	return json.Marshal(map[string]string(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts both a plain string,
+// decoded as a language-neutral text, and an object of translations.
+func (m *LangMap) UnmarshalJSON(data []byte) error {
+	var text string
+
+	err := json.Unmarshal(data, &text)
+	if err == nil {
+		*m = nil
+
+		if text != "" {
+			*m = LangMap{neutralLang: text}
+		}
+
+		return nil
+	}
+
+	var translations map[string]string
+
+	err = json.Unmarshal(data, &translations)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal translations: %v", err)
+	}
+
+	*m = translations
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The title is encoded in the legacy
+// flat format, such as {"en": "", "fr": "", "URL": ""}, so that the existing
+// readers still understand it. The translations whose tag would clash with
+// the keys of the title can't be flattened, so the title is then encoded as
+// {"Text": <LangMap>, "URL": ""}.
+func (t Title) MarshalJSON() ([]byte, error) {
+	flat := make(map[string]string, len(t.Text)+1)
+
+	for tag, text := range t.Text {
+		if strings.EqualFold(tag, urlKey) || strings.EqualFold(tag, textKey) {
+			// title is an alias without the marshaler, to avoid the recursion
+			type title Title
+
+			return json.Marshal(title(t))
+		}
+
+		flat[tag] = text
+	}
+
+	flat[urlKey] = t.URL
+
+	return json.Marshal(flat)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the flat format, such
+// as the legacy {"En": "", "Fr": "", "De": "", "URL": ""}, and the nested
+// {"Text": <LangMap>, "URL": ""} one.
+func (t *Title) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal title: %v", err)
+	}
+
+	_, nested := fields[textKey]
+	if nested {
+		// title is an alias without the unmarshaler, to avoid the recursion
+		type title Title
+
+		err = json.Unmarshal(data, (*title)(t))
+		if err != nil {
+			return xerrors.Errorf("failed to unmarshal title: %v", err)
+		}
+
+		return nil
+	}
+
+	var flat map[string]string
+
+	err = json.Unmarshal(data, &flat)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal legacy title: %v", err)
+	}
+
+	t.URL = flat[urlKey]
+	delete(flat, urlKey)
+
+	t.Text = nil
+	if len(flat) > 0 {
+		t.Text = flat
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the legacy choices
+// of the frontend, whose translations were encoded as a JSON object in a
+// string, such as "{\"en\": \"yes\"}".
+func (c *Choice) UnmarshalJSON(data []byte) error {
+	// choice is an alias without the unmarshaler, to avoid the recursion
+	type choice Choice
+
+	err := json.Unmarshal(data, (*choice)(c))
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal choice: %v", err)
+	}
+
+	text := c.Choice[neutralLang]
+	if len(c.Choice) != 1 || !strings.HasPrefix(text, "{") {
+		return nil
+	}
+
+	var translations map[string]string
+
+	err = json.Unmarshal([]byte(text), &translations)
+	if err == nil {
+		c.Choice = translations
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The translations are encoded in a
+// flat object, such as {"en": "", "fr": ""}.
+func (h Hint) MarshalJSON() ([]byte, error) {
+	if h.Text == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(map[string]string(h.Text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the legacy
+// {"En": "", "Fr": "", "De": ""} format.
+func (h *Hint) UnmarshalJSON(data []byte) error {
+	var flat map[string]string
+
+	err := json.Unmarshal(data, &flat)
+	if err != nil {
+		return xerrors.Errorf("failed to unmarshal hint: %v", err)
+	}
+
+	h.Text = nil
+	if len(flat) > 0 {
+		h.Text = flat
+	}
+
+	return nil
+}
+
+// isValidLanguages checks that the declared languages are well-formed and
+// unique.
+func isValidLanguages(langs []string) bool {
+	unique := make(map[string]bool)
+
+	for _, lang := range langs {
+		if !langTagRegex.MatchString(lang) {
+			return false
+		}
+
+		lower := strings.ToLower(lang)
+		if unique[lower] {
+			return false
+		}
+
+		unique[lower] = true
+	}
+
+	return true
+}
+
+// isTranslated checks that the texts of a question are translated. The hint
+// is optional.
+func isTranslated(title Title, choices []Choice, hint Hint, langs []string) bool {
+	if !title.Text.IsTranslated(langs) {
+		return false
+	}
+
+	for _, choice := range choices {
+		if !choice.Choice.IsTranslated(langs) {
+			return false
+		}
+	}
+
+	return hint.Text.IsEmpty() || hint.Text.IsTranslated(langs)
+}
+
+// isTranslated checks that every element of the subject, including its
+// sub-subjects, is translated into the given languages.
+func (s *Subject) isTranslated(langs []string) bool {
+	if !s.Title.Text.IsTranslated(langs) {
+		return false
+	}
+
+	for _, sel := range s.Selects {
+		if !isTranslated(sel.Title, sel.Choices, sel.Hint, langs) {
+			return false
+		}
+	}
+
+	for _, rank := range s.Ranks {
+		if !isTranslated(rank.Title, rank.Choices, rank.Hint, langs) {
+			return false
+		}
+	}
+
+	for _, text := range s.Texts {
+		if !isTranslated(text.Title, text.Choices, text.Hint, langs) {
+			return false
+		}
+	}
+
+	for _, subject := range s.Subjects {
+		if !subject.isTranslated(langs) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangMap_Get(t *testing.T) {
+	m := LangMap{"En": "title", "fr": "titre"}
+
+	require.Equal(t, "title", m.Get("en"))
+	require.Equal(t, "titre", m.Get("fr"))
+	require.Equal(t, "", m.Get("de"))
+
+	m[neutralLang] = "neutral"
+	require.Equal(t, "neutral", m.Get("de"))
+
+	// the tags that only differ by their case are resolved in the sorted order,
+	// whatever the order of the map
+	m = LangMap{"eN": "c", "En": "b", "EN": "a"}
+
+	for i := 0; i < 100; i++ {
+		require.Equal(t, "a", m.Get("en"))
+	}
+}
+
+func TestLangMap_IsTranslated(t *testing.T) {
+	m := LangMap{"en": "title", "fr": ""}
+
+	require.True(t, m.IsTranslated(nil))
+	require.True(t, m.IsTranslated([]string{"en"}))
+	require.False(t, m.IsTranslated([]string{"en", "fr"}))
+
+	require.True(t, LangMap{neutralLang: "Alice"}.IsTranslated([]string{"en", "fr"}))
+	require.True(t, LangMap{"en": ""}.IsEmpty())
+}
+
+func TestLangMap_JSON(t *testing.T) {
+	var m LangMap
+
+	err := json.Unmarshal([]byte(`"snickers"`), &m)
+	require.NoError(t, err)
+	require.Equal(t, LangMap{neutralLang: "snickers"}, m)
+
+	buf, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `"snickers"`, string(buf))
+
+	err = json.Unmarshal([]byte(`""`), &m)
+	require.NoError(t, err)
+	require.Nil(t, m)
+
+	buf, err = json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `""`, string(buf))
+
+	err = json.Unmarshal([]byte(`{"en":"yes","fr":"oui"}`), &m)
+	require.NoError(t, err)
+	require.Equal(t, LangMap{"en": "yes", "fr": "oui"}, m)
+
+	buf, err = json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"en":"yes","fr":"oui"}`, string(buf))
+
+	err = json.Unmarshal([]byte(`1`), &m)
+	require.EqualError(t, err, "failed to unmarshal translations: json: "+
+		"cannot unmarshal number into Go value of type map[string]string")
+}
+
+func TestTitle_JSON(t *testing.T) {
+	legacy := `{"De":"","En":"title","Fr":"titre","URL":"url"}`
+
+	var title Title
+
+	err := json.Unmarshal([]byte(legacy), &title)
+	require.NoError(t, err)
+	require.Equal(t, "url", title.URL)
+	require.Equal(t, "title", title.Text.Get("en"))
+	require.Equal(t, "titre", title.Text.Get("fr"))
+
+	// the legacy format is kept for the existing readers
+	buf, err := json.Marshal(title)
+	require.NoError(t, err)
+	require.Equal(t, legacy, string(buf))
+
+	var decoded Title
+
+	err = json.Unmarshal(buf, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, title, decoded)
+
+	// a language that clashes with the keys of the title is nested
+	clash := Title{Text: LangMap{"en": "title", "url": "link"}, URL: "url"}
+
+	buf, err = json.Marshal(clash)
+	require.NoError(t, err)
+	require.Equal(t, `{"Text":{"en":"title","url":"link"},"URL":"url"}`, string(buf))
+
+	err = json.Unmarshal(buf, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, clash, decoded)
+
+	err = json.Unmarshal([]byte(`{"Text":"name","URL":""}`), &decoded)
+	require.NoError(t, err)
+	require.Equal(t, "name", decoded.Text.Get("fr"))
+
+	err = json.Unmarshal([]byte(`{"Text":1}`), &decoded)
+	require.EqualError(t, err, "failed to unmarshal title: failed to unmarshal "+
+		"translations: json: cannot unmarshal number into Go value of type map[string]string")
+
+	err = json.Unmarshal([]byte(`{"URL":""}`), &title)
+	require.NoError(t, err)
+	require.Nil(t, title.Text)
+
+	var hint Hint
+
+	err = json.Unmarshal([]byte(`{"En":"hint","Fr":"","De":""}`), &hint)
+	require.NoError(t, err)
+	require.Equal(t, "hint", hint.Text.Get("en"))
+
+	buf, err = json.Marshal(Hint{})
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(buf))
+}
+
+func TestChoice_JSON(t *testing.T) {
+	var choice Choice
+
+	// legacy choices of the frontend
+	err := json.Unmarshal([]byte(`{"Choice":"{\"en\":\"yes\"}","URL":""}`), &choice)
+	require.NoError(t, err)
+	require.Equal(t, LangMap{"en": "yes"}, choice.Choice)
+
+	err = json.Unmarshal([]byte(`{"Choice":{"en":"yes","fr":"oui"},"URL":"url"}`), &choice)
+	require.NoError(t, err)
+	require.Equal(t, Choice{Choice: LangMap{"en": "yes", "fr": "oui"}, URL: "url"}, choice)
+
+	err = json.Unmarshal([]byte(`{"Choice":"{not json"}`), &choice)
+	require.NoError(t, err)
+	require.Equal(t, "{not json", choice.Choice.Get("en"))
+
+	err = json.Unmarshal([]byte(`{"Choice":1}`), &choice)
+	require.Error(t, err)
+}
+
+func TestConfiguration_IsTranslated(t *testing.T) {
+	translated := LangMap{"en": "text", "fr": "texte"}
+
+	configuration := Configuration{
+		Title: Title{Text: translated},
+		Scaffold: []Subject{{
+			ID:    "subject",
+			Title: Title{Text: translated},
+			Selects: []Select{{
+				ID:      "select",
+				Title:   Title{Text: translated},
+				MaxN:    1,
+				MinN:    1,
+				Choices: []Choice{{Choice: LangMap{neutralLang: "Alice"}}},
+			}},
+		}},
+		Languages: []string{"en", "fr-CH"},
+	}
+
+	// "fr-CH" is not translated
+	require.False(t, configuration.IsValid())
+
+	configuration.Languages = []string{"en", "fr"}
+	require.True(t, configuration.IsValid())
+
+	configuration.Languages = []string{"en", "EN"}
+	require.False(t, configuration.IsValid())
+
+	configuration.Languages = []string{"en_US"}
+	require.False(t, configuration.IsValid())
+
+	configuration.Languages = []string{"en", "fr"}
+
+	configuration.AdditionalInfo = LangMap{"en": "info"}
+	require.False(t, configuration.IsValid())
+
+	configuration.AdditionalInfo = translated
+	configuration.Scaffold[0].Selects[0].Hint = Hint{Text: LangMap{"fr": "aide"}}
+	require.False(t, configuration.IsValid())
+
+	configuration.Scaffold[0].Selects[0].Hint = Hint{}
+	configuration.Scaffold[0].Selects[0].Choices[0].Choice = LangMap{"en": "yes"}
+	require.False(t, configuration.IsValid())
+
+	configuration.Scaffold[0].Selects[0].Choices[0].Choice = translated
+	configuration.Scaffold[0].Subjects = []Subject{{ID: "sub", Title: Title{}}}
+	require.False(t, configuration.IsValid())
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+
+	"golang.org/x/xerrors"
+)
+
+// RejectionCode is a stable code identifying why the evoting contract rejected
+// a transaction. The message of the rejection can change between versions, the
+// code does not.
+type RejectionCode string
+
+const (
+	// CodeUnauthorized is used when the identity of the transaction is not
+	// allowed to use the contract
+	CodeUnauthorized RejectionCode = "UNAUTHORIZED"
+	// CodeFormNotFound is used when the form of the transaction doesn't exist
+	CodeFormNotFound RejectionCode = "FORM_NOT_FOUND"
+	// CodeFormAlreadyExists is used when a form with the same ID exists
+	CodeFormAlreadyExists RejectionCode = "FORM_ALREADY_EXISTS"
+	// CodeTemplateNotFound is used when the template of the transaction
+	// doesn't exist
+	CodeTemplateNotFound RejectionCode = "TEMPLATE_NOT_FOUND"
+	// CodeInvalidConfiguration is used when the configuration of a form or a
+	// template is incoherent
+	CodeInvalidConfiguration RejectionCode = "INVALID_CONFIGURATION"
+	// CodeFormNotInitial is used when the form must be in its initial state
+	CodeFormNotInitial RejectionCode = "FORM_NOT_INITIAL"
+	// CodeFormNotOpen is used when the form must be open, for example to cast
+	// a vote
+	CodeFormNotOpen RejectionCode = "FORM_NOT_OPEN"
+	// CodeFormNotClosed is used when the form must be closed, for example to
+	// shuffle the ballots
+	CodeFormNotClosed RejectionCode = "FORM_NOT_CLOSED"
+	// CodeInvalidVoterWeights is used when the weights of the voters of a form
+	// are invalid
+	CodeInvalidVoterWeights RejectionCode = "INVALID_VOTER_WEIGHTS"
+	// CodeInvalidBallot is used when the ballot doesn't match the form
+	CodeInvalidBallot RejectionCode = "INVALID_BALLOT"
+	// CodeInvalidBatch is used when a batch of votes is empty, too big or
+	// has several votes of the same voter
+	CodeInvalidBatch RejectionCode = "INVALID_BATCH"
+	// CodeVoterNotAllowed is used when the voter is not allowed to vote on a
+	// weighted form
+	CodeVoterNotAllowed RejectionCode = "VOTER_NOT_ALLOWED"
+	// CodeNotEnoughBallots is used when the form doesn't have enough ballots
+	// to be shuffled
+	CodeNotEnoughBallots RejectionCode = "NOT_ENOUGH_BALLOTS"
+	// CodeInvalidShuffle is used when a shuffle is rejected
+	CodeInvalidShuffle RejectionCode = "INVALID_SHUFFLE"
+	// CodeInvalidPubShares is used when a submission of public shares is
+	// rejected
+	CodeInvalidPubShares RejectionCode = "INVALID_PUBSHARES"
+	// CodeInvalidDecryption is used when the ballots can't be decrypted
+	CodeInvalidDecryption RejectionCode = "INVALID_DECRYPTION"
+)
+
+// rejectionPrefix matches the code at the beginning of the message of a
+// rejected transaction.
+var rejectionPrefix = regexp.MustCompile(`^\[([A-Z_]+)\] `)
+
+// Rejection is an error of the evoting contract with the code of its reason.
+// The code is found as long as the error is wrapped with %w.
+//
+// - implements error
+type Rejection struct {
+	Code RejectionCode
+	err  error
+}
+
+// NewRejection returns a new rejection with the code and the formatted message.
+func NewRejection(code RejectionCode, format string, args ...interface{}) error {
+	return Rejection{
+		Code: code,
+		err:  xerrors.Errorf(format, args...),
+	}
+}
+
+// Error implements error. It returns the message without the code.
+func (r Rejection) Error() string {
+	return r.err.Error()
+}
+
+// Unwrap returns the error of the message.
+func (r Rejection) Unwrap() error {
+	return r.err
+}
+
+// WithRejectionCode returns an error whose message is the one of err prefixed
+// with the code of the innermost rejection wrapped by err, which is the most
+// specific, for example "[FORM_NOT_OPEN] failed to cast vote: ...". It returns
+// err if it doesn't wrap a rejection.
+func WithRejectionCode(err error) error {
+	var code RejectionCode
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		rejection, ok := e.(Rejection)
+		if ok {
+			code = rejection.Code
+		}
+	}
+
+	if code == "" {
+		return err
+	}
+
+	return xerrors.Errorf("[%s] %v", code, err)
+}
+
+// ParseRejection returns the code and the message of a rejected transaction
+// whose message was returned by WithRejectionCode. The code is empty if the
+// message has none.
+func ParseRejection(message string) (RejectionCode, string) {
+	match := rejectionPrefix.FindStringSubmatch(message)
+	if match == nil {
+		return "", message
+	}
+
+	return RejectionCode(match[1]), message[len(match[0]):]
+}
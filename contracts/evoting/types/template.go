@@ -0,0 +1,180 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"go.dedis.ch/dela/core/store"
+	"golang.org/x/xerrors"
+)
+
+// LatestTemplateVersion is the version to use to get the latest version of a
+// template.
+const LatestTemplateVersion = uint(0)
+
+// The templates are stored with one key per version under the templatesPrefix
+// namespace, so that saving a template doesn't rewrite the other templates.
+// The names of the templates are also stored at their position in the
+// creation order, so that they can be listed. The names are hex-encoded in the
+// keys.
+//
+//	Templates:count                -> number of templates
+//	Templates:pos:<n>              -> name of the template at position n
+//	Templates:template:<name>      -> number of versions of the template
+//	Templates:template:<name>:<v>  -> TemplateVersion v of the template
+const templatesPrefix = "Templates:"
+
+// TemplateVersion is one version of a template, which is a named, reusable form
+// configuration. Saving a template with an existing name adds a new version
+// instead of replacing it, so that forms created from a previous version can
+// still be traced back to it. Versions start at 1.
+type TemplateVersion struct {
+	Version       uint
+	AdminID       string
+	Configuration Configuration
+}
+
+func templatesCountKey() []byte {
+	return []byte(templatesPrefix + "count")
+}
+
+func templatesPositionKey(position uint64) []byte {
+	return []byte(templatesPrefix + "pos:" + strconv.FormatUint(position, 10))
+}
+
+func templateKey(name string) []byte {
+	return []byte(templatesPrefix + "template:" + hex.EncodeToString([]byte(name)))
+}
+
+func templateVersionKey(name string, version uint) []byte {
+	return append(templateKey(name), []byte(":"+strconv.FormatUint(uint64(version), 10))...)
+}
+
+// TemplateVersions returns the number of versions of the template, which is 0
+// if there is no template with this name.
+func TemplateVersions(rd store.Readable, name string) (uint, error) {
+	count, err := getCounter(rd, templateKey(name))
+	if err != nil {
+		return 0, xerrors.Errorf("failed to get versions: %v", err)
+	}
+
+	return uint(count), nil
+}
+
+// GetTemplateVersion returns the given version of the template.
+// LatestTemplateVersion returns the latest version. It returns nil if there is
+// no such template or version.
+func GetTemplateVersion(rd store.Readable, name string, version uint) (*TemplateVersion, error) {
+	if version == LatestTemplateVersion {
+		latest, err := TemplateVersions(rd, name)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get latest version: %v", err)
+		}
+
+		version = latest
+	}
+
+	buf, err := rd.Get(templateVersionKey(name, version))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get version: %v", err)
+	}
+
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	var templateVersion TemplateVersion
+
+	err = json.Unmarshal(buf, &templateVersion)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal version: %v", err)
+	}
+
+	return &templateVersion, nil
+}
+
+// AddTemplateVersion saves the configuration as a new version of the template
+// with the given name, creating the template if needed. It returns the new
+// version.
+func AddTemplateVersion(snap store.Snapshot, name, adminID string,
+	configuration Configuration) (uint, error) {
+
+	versions, err := TemplateVersions(snap, name)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to get versions: %v", err)
+	}
+
+	if versions == 0 {
+		_, err = appendPosition(snap, templatesCountKey(), templatesPositionKey, name)
+		if err != nil {
+			return 0, xerrors.Errorf("failed to add to templates: %v", err)
+		}
+	}
+
+	templateVersion := TemplateVersion{
+		Version:       versions + 1,
+		AdminID:       adminID,
+		Configuration: configuration,
+	}
+
+	buf, err := json.Marshal(templateVersion)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to marshal version: %v", err)
+	}
+
+	err = snap.Set(templateVersionKey(name, templateVersion.Version), buf)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to set version: %v", err)
+	}
+
+	err = setCounter(snap, templateKey(name), uint64(templateVersion.Version))
+	if err != nil {
+		return 0, xerrors.Errorf("failed to set versions: %v", err)
+	}
+
+	return templateVersion.Version, nil
+}
+
+// IterateTemplates calls fn with the names of the templates in the creation
+// order. The iteration stops when fn returns false.
+func IterateTemplates(rd store.Readable, fn func(name string) bool) error {
+	count, err := getCounter(rd, templatesCountKey())
+	if err != nil {
+		return xerrors.Errorf("failed to get count: %v", err)
+	}
+
+	for position := uint64(0); position < count; position++ {
+		name, err := rd.Get(templatesPositionKey(position))
+		if err != nil {
+			return xerrors.Errorf("failed to get position %d: %v", position, err)
+		}
+
+		if !fn(string(name)) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ApplyOverrides returns a copy of the configuration where the non-empty
+// overrides replace the original values. An override without any text, such
+// as the one decoded from "{}", is empty.
+func (c Configuration) ApplyOverrides(title *Title, additionalInfo LangMap,
+	schedule *Schedule) Configuration {
+
+	if title != nil && (!title.Text.IsEmpty() || title.URL != "") {
+		c.Title = *title
+	}
+
+	if !additionalInfo.IsEmpty() {
+		c.AdditionalInfo = additionalInfo
+	}
+
+	if schedule != nil {
+		c.Schedule = schedule
+	}
+
+	return c
+}
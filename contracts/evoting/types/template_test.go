@@ -0,0 +1,111 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestTemplates(t *testing.T) {
+	snap := newFakeSnapshot()
+
+	versions, err := TemplateVersions(snap, "referendum")
+	require.NoError(t, err)
+	require.Zero(t, versions)
+
+	missing, err := GetTemplateVersion(snap, "referendum", LatestTemplateVersion)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	config := func(title string) Configuration {
+		return Configuration{Title: Title{Text: LangMap{"en": title}}}
+	}
+
+	version, err := AddTemplateVersion(snap, "referendum", "alice", config("v1"))
+	require.NoError(t, err)
+	require.Equal(t, uint(1), version)
+
+	// a name that looks like a key of another template
+	version, err = AddTemplateVersion(snap, "referendum:1", "bob", config("other"))
+	require.NoError(t, err)
+	require.Equal(t, uint(1), version)
+
+	version, err = AddTemplateVersion(snap, "referendum", "bob", config("v2"))
+	require.NoError(t, err)
+	require.Equal(t, uint(2), version)
+
+	latest, err := GetTemplateVersion(snap, "referendum", LatestTemplateVersion)
+	require.NoError(t, err)
+	require.Equal(t, TemplateVersion{Version: 2, AdminID: "bob", Configuration: config("v2")},
+		*latest)
+
+	first, err := GetTemplateVersion(snap, "referendum", 1)
+	require.NoError(t, err)
+	require.Equal(t, TemplateVersion{Version: 1, AdminID: "alice", Configuration: config("v1")},
+		*first)
+
+	missing, err = GetTemplateVersion(snap, "referendum", 3)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	other, err := GetTemplateVersion(snap, "referendum:1", LatestTemplateVersion)
+	require.NoError(t, err)
+	require.Equal(t, "other", other.Configuration.Title.Text.Get("en"))
+
+	var names []string
+
+	err = IterateTemplates(snap, func(name string) bool {
+		names = append(names, name)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"referendum", "referendum:1"}, names)
+
+	// each version has its own key
+	require.Len(t, snap.values, 1+2+2+3)
+
+	snap.err = xerrors.New("oops")
+
+	err = IterateTemplates(snap, nil)
+	require.EqualError(t, err, "failed to get count: oops")
+
+	_, err = AddTemplateVersion(snap, "referendum", "alice", config("v3"))
+	require.EqualError(t, err, "failed to get versions: failed to get versions: oops")
+}
+
+func TestConfiguration_ApplyOverrides(t *testing.T) {
+	config := Configuration{
+		Title:          Title{Text: LangMap{"en": "title"}},
+		AdditionalInfo: LangMap{"en": "info"},
+		Schedule:       &Schedule{OpenAt: 1000},
+	}
+
+	require.Equal(t, config, config.ApplyOverrides(nil, nil, nil))
+	require.Equal(t, config, config.ApplyOverrides(&Title{Text: LangMap{}}, LangMap{}, nil))
+	require.Equal(t, config, config.ApplyOverrides(nil, LangMap{"en": ""}, nil))
+
+	title := Title{Text: LangMap{"en": "other"}}
+	schedule := &Schedule{OpenAt: 2000, CloseAt: 3000}
+
+	overridden := config.ApplyOverrides(&title, LangMap{"fr": "info"}, schedule)
+	require.Equal(t, Configuration{
+		Title:          title,
+		AdditionalInfo: LangMap{"fr": "info"},
+		Schedule:       schedule,
+	}, overridden)
+}
+
+func TestSchedule_IsValid(t *testing.T) {
+	var schedule *Schedule
+	require.True(t, schedule.isValid())
+
+	require.True(t, (&Schedule{}).isValid())
+	require.True(t, (&Schedule{OpenAt: 1000}).isValid())
+	require.True(t, (&Schedule{CloseAt: 1000}).isValid())
+	require.True(t, (&Schedule{OpenAt: 1000, CloseAt: 2000}).isValid())
+
+	require.False(t, (&Schedule{OpenAt: 2000, CloseAt: 1000}).isValid())
+	require.False(t, (&Schedule{OpenAt: 1000, CloseAt: 1000}).isValid())
+	require.False(t, (&Schedule{OpenAt: -1}).isValid())
+}
@@ -107,6 +107,62 @@ func (ce CreateForm) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// CreateFormFromTemplate defines the transaction to create a form from the
+// configuration of an existing form or of a template. Exactly one of
+// SourceFormID and TemplateName must be set.
+//
+// - implements serde.Message
+type CreateFormFromTemplate struct {
+	AdminID string
+
+	// SourceFormID is the hex-encoded ID of the form to clone
+	SourceFormID string
+
+	TemplateName string
+	// TemplateVersion is the version of the template, LatestTemplateVersion
+	// for the latest one.
+	TemplateVersion uint
+
+	// Title, AdditionalInfo and Schedule override the ones of the source
+	// configuration if they are set.
+	Title          *Title
+	AdditionalInfo LangMap
+	Schedule       *Schedule
+}
+
+// Serialize implements serde.Message
+func (cf CreateFormFromTemplate) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, cf)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode create form from template: %v", err)
+	}
+
+	return data, nil
+}
+
+// SaveTemplate defines the transaction to save a new version of a template
+//
+// - implements serde.Message
+type SaveTemplate struct {
+	Name          string
+	AdminID       string
+	Configuration Configuration
+}
+
+// Serialize implements serde.Message
+func (st SaveTemplate) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, st)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode save template: %v", err)
+	}
+
+	return data, nil
+}
+
 // OpenForm defines the transaction to open a form
 //
 // - implements serde.Message
@@ -127,6 +183,57 @@ func (oe OpenForm) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// UpdateConfiguration defines the transaction to replace the configuration of
+// a form that is not open yet
+//
+// - implements serde.Message
+type UpdateConfiguration struct {
+	// FormID is hex-encoded
+	FormID        string
+	Configuration Configuration
+}
+
+// Serialize implements serde.Message
+func (uc UpdateConfiguration) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, uc)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode update configuration: %v", err)
+	}
+
+	return data, nil
+}
+
+// VoterWeight is the weight of the ballot of a voter
+type VoterWeight struct {
+	UserID string
+	Weight uint32
+}
+
+// SetVoterWeights defines the transaction to set the weight of each voter
+//
+// - implements serde.Message
+type SetVoterWeights struct {
+	// FormID is hex-encoded
+	FormID string
+	// VoterWeights is a list, rather than a map, so that the contract can
+	// refuse a voter listed twice
+	VoterWeights []VoterWeight
+}
+
+// Serialize implements serde.Message
+func (sv SetVoterWeights) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, sv)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode set voter weights: %v", err)
+	}
+
+	return data, nil
+}
+
 // CastVote defines the transaction to cast a vote
 //
 // - implements serde.Message
@@ -135,6 +242,9 @@ type CastVote struct {
 	FormID string
 	UserID string
 	Ballot Ciphervote
+	// Weight is the encrypted weight of the voter. It is only set in a
+	// weighted form.
+	Weight *EncryptedWeight
 }
 
 // Serialize implements serde.Message
@@ -149,6 +259,40 @@ func (cv CastVote) Serialize(ctx serde.Context) ([]byte, error) {
 	return data, nil
 }
 
+// MaxVotesPerBatch is the maximum number of votes in a CastVotes transaction
+const MaxVotesPerBatch = 1000
+
+// BatchVote is a vote of a CastVotes transaction
+type BatchVote struct {
+	UserID string
+	Ballot Ciphervote
+	// Weight is the encrypted weight of the voter. It is only set in a
+	// weighted form.
+	Weight *EncryptedWeight
+}
+
+// CastVotes defines the transaction to cast several votes at once, for
+// example to enter digitised paper ballots
+//
+// - implements serde.Message
+type CastVotes struct {
+	// FormID is hex-encoded
+	FormID string
+	Votes  []BatchVote
+}
+
+// Serialize implements serde.Message
+func (cv CastVotes) Serialize(ctx serde.Context) ([]byte, error) {
+	format := transactionFormats.Get(ctx.GetFormat())
+
+	data, err := format.Encode(ctx, cv)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encode cast votes: %v", err)
+	}
+
+	return data, nil
+}
+
 // CloseForm defines the transaction to close a form
 //
 // - implements serde.Message
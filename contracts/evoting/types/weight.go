@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/proof"
+	"golang.org/x/xerrors"
+)
+
+// weightProtocolName is the name of the protocol of the weight proofs
+const weightProtocolName = "WeightEncryption"
+
+// weightPredicate is the statement proven by a weight proof: the pair (K, C)
+// encrypts M with a randomness k, that is K = kB and C - M = kP, where B is
+// the base point and P the public key of the form.
+var weightPredicate = proof.And(proof.Rep("K", "k", "B"), proof.Rep("S", "k", "P"))
+
+// EncryptedWeight is the weight of a voter in a weighted form, encrypted with
+// the public key of the form, and the proof that the pair encrypts it. The
+// randomness of the pair is secret, so that the weight of a shuffled ballot is
+// only revealed by the decryption, like the rest of the ballot.
+type EncryptedWeight struct {
+	Pair  EGPair
+	Proof []byte
+}
+
+// EncryptWeight encrypts the weight with the public key of a form, with a
+// fresh randomness, and proves that the pair encrypts the weight.
+func EncryptWeight(pubkey kyber.Point, weight uint32) (EncryptedWeight, error) {
+	if pubkey == nil {
+		return EncryptedWeight{}, xerrors.Errorf("the form has no public key")
+	}
+
+	M := embedWeight(weight)
+	k := suite.Scalar().Pick(suite.RandomStream())
+
+	K := suite.Point().Mul(k, nil)
+	S := suite.Point().Mul(k, pubkey)
+	C := suite.Point().Add(S, M)
+
+	points := weightPoints(pubkey, K, S)
+	prover := weightPredicate.Prover(suite, map[string]kyber.Scalar{"k": k}, points, nil)
+
+	weightProof, err := proof.HashProve(suite, weightProtocolName, prover)
+	if err != nil {
+		return EncryptedWeight{}, xerrors.Errorf("failed to prove weight: %v", err)
+	}
+
+	return EncryptedWeight{
+		Pair:  EGPair{K: K, C: C},
+		Proof: weightProof,
+	}, nil
+}
+
+// Verify checks that the pair encrypts the weight with the public key of the
+// form.
+func (w EncryptedWeight) Verify(pubkey kyber.Point, weight uint32) error {
+	if pubkey == nil {
+		return xerrors.Errorf("the form has no public key")
+	}
+
+	if w.Pair.K == nil || w.Pair.C == nil {
+		return xerrors.Errorf("the pair is incomplete")
+	}
+
+	S := suite.Point().Sub(w.Pair.C, embedWeight(weight))
+
+	verifier := weightPredicate.Verifier(suite, weightPoints(pubkey, w.Pair.K, S))
+
+	err := proof.HashVerify(suite, weightProtocolName, verifier, w.Proof)
+	if err != nil {
+		return xerrors.Errorf("failed to verify proof: %v", err)
+	}
+
+	return nil
+}
+
+// embedWeight returns the point embedding the weight. The embedding must be
+// deterministic, so that the contract can check the pair against the weight.
+func embedWeight(weight uint32) kyber.Point {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, weight)
+
+	return suite.Point().Embed(buf, suite.XOF(buf))
+}
+
+func weightPoints(pubkey, K, S kyber.Point) map[string]kyber.Point {
+	return map[string]kyber.Point{
+		"B": suite.Point().Base(),
+		"P": pubkey,
+		"K": K,
+		"S": S,
+	}
+}
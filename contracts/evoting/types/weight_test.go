@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWeight(t *testing.T) {
+	_, err := EncryptWeight(nil, 1)
+	require.EqualError(t, err, "the form has no public key")
+
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pubkey := suite.Point().Mul(secret, nil)
+
+	weight1, err := EncryptWeight(pubkey, 3)
+	require.NoError(t, err)
+
+	weight2, err := EncryptWeight(pubkey, 3)
+	require.NoError(t, err)
+
+	// the randomness is fresh, so the same weight gives different pairs
+	require.False(t, weight1.Pair.K.Equal(weight2.Pair.K))
+	require.False(t, weight1.Pair.C.Equal(weight2.Pair.C))
+
+	require.NoError(t, weight1.Verify(pubkey, 3))
+	require.NoError(t, weight2.Verify(pubkey, 3))
+
+	S := suite.Point().Mul(secret, weight1.Pair.K)
+	M := suite.Point().Sub(weight1.Pair.C, S)
+
+	buf, err := M.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 3}, buf)
+}
+
+func TestEncryptedWeight_Verify(t *testing.T) {
+	pubkey := suite.Point().Pick(suite.RandomStream())
+
+	weight, err := EncryptWeight(pubkey, 3)
+	require.NoError(t, err)
+
+	err = weight.Verify(nil, 3)
+	require.EqualError(t, err, "the form has no public key")
+
+	err = EncryptedWeight{}.Verify(pubkey, 3)
+	require.EqualError(t, err, "the pair is incomplete")
+
+	// the pair doesn't encrypt another weight
+	err = weight.Verify(pubkey, 2)
+	require.Error(t, err)
+
+	// the pair isn't encrypted with another key
+	err = weight.Verify(suite.Point().Pick(suite.RandomStream()), 3)
+	require.Error(t, err)
+
+	// the proof doesn't hold for another pair
+	other, err := EncryptWeight(pubkey, 3)
+	require.NoError(t, err)
+
+	err = EncryptedWeight{Pair: other.Pair, Proof: weight.Proof}.Verify(pubkey, 3)
+	require.Error(t, err)
+
+	err = EncryptedWeight{Pair: weight.Pair, Proof: []byte("invalid")}.Verify(pubkey, 3)
+	require.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 
 	"github.com/stretchr/testify/require"
+	vclient "go.dedis.ch/d-voting/client"
 	"go.dedis.ch/d-voting/contracts/evoting"
 	"go.dedis.ch/d-voting/contracts/evoting/controller"
 	"go.dedis.ch/d-voting/contracts/evoting/types"
@@ -26,7 +27,6 @@ import (
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/suites"
-	"go.dedis.ch/kyber/v3/util/random"
 	"golang.org/x/xerrors"
 )
 
@@ -211,72 +211,11 @@ func decryptBallots(m txManager, actor dkg.Actor, form types.Form) error {
 	return nil
 }
 
-// encryptManual encrypts a ballot manually
-func encryptManual(message []byte, pubkey kyber.Point) (K, C kyber.Point, remainder []byte, err error) {
-
-	// Embed the message (or as much of it as will fit) into a curve point.
-	M := suite.Point().Embed(message, random.New())
-	max := suite.Point().EmbedLen()
-	if max > len(message) {
-		max = len(message)
-	}
-	remainder = message[max:]
-	// ElGamal-encrypt the point to produce ciphertext (K,C).
-	k := suite.Scalar().Pick(random.New()) // ephemeral private key
-	K = suite.Point().Mul(k, nil)          // ephemeral DH public key
-	S := suite.Point().Mul(k, pubkey)      // ephemeral DH shared secret
-	C = S.Add(S, M)                        // message blinded with secret
-
-	return K, C, remainder, nil
-}
-
 // encodeBallotID encodes the ballotID
 func encodeBallotID(ID string) types.ID {
 	return types.ID(base64.StdEncoding.EncodeToString([]byte(ID)))
 }
 
-// marshallBallotManual marshall a ballot and encrypt it manually
-func marshallBallotManual(voteStr string, pubkey kyber.Point, chunks int) (ptypes.CiphervoteJSON, error) {
-
-	ballot := make(ptypes.CiphervoteJSON, chunks)
-	vote := strings.NewReader(voteStr)
-
-	buf := make([]byte, 29)
-
-	for i := 0; i < chunks; i++ {
-		var K, C kyber.Point
-		var err error
-
-		n, err := vote.Read(buf)
-		if err != nil {
-			return nil, xerrors.Errorf("failed to read: %v", err)
-		}
-
-		K, C, _, err = encryptManual(buf[:n], pubkey)
-
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to encrypt the plaintext: %v", err)
-		}
-
-		kbuff, err := K.MarshalBinary()
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to marshal K: %v", err)
-		}
-
-		cbuff, err := C.MarshalBinary()
-		if err != nil {
-			return ptypes.CiphervoteJSON{}, xerrors.Errorf("failed to marshal C: %v", err)
-		}
-
-		ballot[i] = ptypes.EGPairJSON{
-			K: kbuff,
-			C: cbuff,
-		}
-	}
-
-	return ballot, nil
-}
-
 // checkBallots checks that the decrypted ballots are correct
 // and match the casted votes
 func checkBallots(decryptedBallots, castedVotes []types.Ballot, t *testing.T) {
@@ -335,7 +274,7 @@ func castVotesLoad(numVotesPerSec, numSec, BallotSize, chunksPerBallot int, form
 	proxyCount := len(proxyArray)
 
 	// all ballots are identical
-	ballot, err := marshallBallotManual(b1, pubKey, chunksPerBallot)
+	ballot, err := vclient.EncryptBallot(b1, pubKey, chunksPerBallot)
 	require.NoError(t, err)
 
 	// atomic counter
@@ -389,7 +328,8 @@ func cast(idx int, castVoteRequest ptypes.CastVoteRequest, contentType, randompr
 
 	t.Logf("cast ballot to proxy %v", randomproxy)
 
-	signed, err := createSignedRequest(secret, castVoteRequest)
+	signed, err := createSignedRequest(secret, http.MethodPost,
+		controller.FormPathSlash+formID+"/vote", castVoteRequest)
 	require.NoError(t, err)
 
 	resp, err := http.Post(randomproxy+controller.FormPathSlash+formID+"/vote", contentType, bytes.NewBuffer(signed))
@@ -446,7 +386,7 @@ func castVotesScenario(numVotes, BallotSize, chunksPerBallot int, formID, conten
 
 	for i := 0; i < numVotes; i++ {
 
-		ballot, err := marshallBallotManual(ballotList[i], pubKey, chunksPerBallot)
+		ballot, err := vclient.EncryptBallot(ballotList[i], pubKey, chunksPerBallot)
 		require.NoError(t, err)
 
 		castVoteRequest := ptypes.CastVoteRequest{
@@ -457,7 +397,8 @@ func castVotesScenario(numVotes, BallotSize, chunksPerBallot int, formID, conten
 		randomproxy := proxyArray[rand.Intn(len(proxyArray))]
 		t.Logf("cast ballot to proxy %v", randomproxy)
 
-		signed, err := createSignedRequest(secret, castVoteRequest)
+		signed, err := createSignedRequest(secret, http.MethodPost,
+			"/evoting/forms/"+formID+"/vote", castVoteRequest)
 		require.NoError(t, err)
 
 		resp, err := http.Post(randomproxy+"/evoting/forms/"+formID+"/vote", contentType, bytes.NewBuffer(signed))
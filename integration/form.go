@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	jsonDela "go.dedis.ch/dela/serde/json"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/util/random"
 	"golang.org/x/xerrors"
 )
 
@@ -78,7 +80,8 @@ func createFormScenario(contentType, proxy string, secret kyber.Scalar, t *testi
 		AdminID:       "adminId",
 	}
 
-	signed, err := createSignedRequest(secret, createSimpleFormRequest)
+	signed, err := createSignedRequest(secret, http.MethodPost, "/evoting/forms",
+		createSimpleFormRequest)
 	require.NoError(t, err)
 
 	resp, err := http.Post(proxy+"/evoting/forms", contentType, bytes.NewBuffer(signed))
@@ -192,7 +195,8 @@ func updateForm(secret kyber.Scalar, proxyAddr, formIDHex, action string, t *tes
 		Action: action,
 	}
 
-	signed, err := createSignedRequest(secret, msg)
+	signed, err := createSignedRequest(secret, http.MethodPut,
+		"/evoting/forms/"+formIDHex, msg)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPut, proxyAddr+"/evoting/forms/"+formIDHex, bytes.NewBuffer(signed))
@@ -241,12 +245,33 @@ func getFormInfo(proxyAddr, formID string, t *testing.T) ptypes.GetFormResponse
 
 }
 
-func createSignedRequest(secret kyber.Scalar, msg interface{}) ([]byte, error) {
+// createSignedRequest signs the message with the fields preventing its replay,
+// for a request with the given method and path.
+func createSignedRequest(secret kyber.Scalar, method, path string,
+	msg interface{}) ([]byte, error) {
+
 	jsonMsg, err := json.Marshal(msg)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to marshal json: %v", err)
 	}
 
+	fields := map[string]json.RawMessage{}
+
+	err = json.Unmarshal(jsonMsg, &fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal json: %v", err)
+	}
+
+	fields["Timestamp"] = json.RawMessage(strconv.FormatInt(time.Now().Unix(), 10))
+	fields["Nonce"] = json.RawMessage(`"` + hex.EncodeToString(random.Bits(128, true, random.New())) + `"`)
+	fields["Method"] = json.RawMessage(strconv.Quote(method))
+	fields["Path"] = json.RawMessage(strconv.Quote(path))
+
+	jsonMsg, err = json.Marshal(fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal json: %v", err)
+	}
+
 	payload := base64.URLEncoding.EncodeToString(jsonMsg)
 
 	hash := sha256.New()
@@ -139,7 +139,7 @@ func getIntegrationTest(numNodes, numVotes int) func(*testing.T) {
 		form, err = getForm(formFac, formID, nodes[0].GetOrdering())
 		require.NoError(t, err)
 
-		fmt.Println("Title of the form : " + form.Configuration.Title.En)
+		fmt.Println("Title of the form : " + form.Configuration.Title.Text.Get("en"))
 		fmt.Println("ID of the form : " + string(form.FormID))
 		fmt.Println("Status of the form : " + strconv.Itoa(int(form.Status)))
 		fmt.Println("Number of decrypted ballots : " + strconv.Itoa(len(form.DecryptedBallots)))
@@ -302,7 +302,7 @@ func getIntegrationTestCrash(numNodes, numVotes, failingNodes int) func(*testing
 		form, err = getForm(formFac, formID, nodes[0].GetOrdering())
 		require.NoError(t, err)
 
-		fmt.Println("Title of the form : " + form.Configuration.Title.En)
+		fmt.Println("Title of the form : " + form.Configuration.Title.Text.Get("en"))
 		fmt.Println("ID of the form : " + string(form.FormID))
 		fmt.Println("Status of the form : " + strconv.Itoa(int(form.Status)))
 		fmt.Println("Number of decrypted ballots : " + strconv.Itoa(len(form.DecryptedBallots)))
@@ -421,7 +421,7 @@ func getIntegrationBenchmark(numNodes, numVotes int) func(*testing.B) {
 		form, err = getForm(formFac, formID, nodes[0].GetOrdering())
 		require.NoError(b, err)
 
-		fmt.Println("Title of the form : " + form.Configuration.Title.En)
+		fmt.Println("Title of the form : " + form.Configuration.Title.Text.Get("en"))
 		fmt.Println("ID of the form : " + string(form.FormID))
 		fmt.Println("Status of the form : " + strconv.Itoa(int(form.Status)))
 		fmt.Println("Number of decrypted ballots : " + strconv.Itoa(len(form.DecryptedBallots)))
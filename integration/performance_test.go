@@ -77,7 +77,7 @@ func customVotesScenario(b *testing.B, stuffing bool) {
 	fmt.Println("Creating form")
 
 	// ##### CREATE FORM #####
-	formID, err := createFormNChunks(m, types.Title{En: "Three votes form", Fr: "", De: "", URL: ""}, adminID, numChunksPerBallot)
+	formID, err := createFormNChunks(m, types.Title{Text: types.LangMap{"en": "Three votes form"}}, adminID, numChunksPerBallot)
 	require.NoError(b, err)
 
 	time.Sleep(time.Millisecond * 1000)
@@ -158,7 +158,7 @@ func customVotesScenario(b *testing.B, stuffing bool) {
 	form, err = getForm(formFac, formID, nodes[0].GetOrdering())
 	require.NoError(b, err)
 
-	fmt.Println("Title of the form : " + form.Configuration.Title.En)
+	fmt.Println("Title of the form : " + form.Configuration.Title.Text.Get("en"))
 	fmt.Println("ID of the form : " + string(form.FormID))
 	fmt.Println("Status of the form : " + strconv.Itoa(int(form.Status)))
 	fmt.Println("Number of decrypted ballots : " + strconv.Itoa(len(form.DecryptedBallots)))
@@ -198,23 +198,22 @@ func createFormNChunks(m txManager, title types.Title, admin string, numChunks i
 		Scaffold: []types.Subject{
 			{
 				ID:       "aa",
-				Title:    types.Title{En: "subject1", Fr: "", De: "", URL: ""},
+				Title:    types.Title{Text: types.LangMap{"en": "subject1"}},
 				Order:    nil,
 				Subjects: nil,
 				Selects:  nil,
 				Ranks:    []types.Rank{},
 				Texts: []types.Text{{
 					ID:        "bb",
-					Title:     types.Title{En: "Enter favorite snack", Fr: "", De: "", URL: ""},
+					Title:     types.Title{Text: types.LangMap{"en": "Enter favorite snack"}},
 					MaxN:      1,
 					MinN:      0,
 					MaxLength: uint(base64.StdEncoding.DecodedLen(textSize)),
 					Regex:     "",
-					Choices:   []types.Choice{{Choice: "Your fav snack: ", URL: ""}},
+					Choices:   []types.Choice{{Choice: types.LangMap{"en": "Your fav snack: "}}},
 				}},
 			},
 		},
-		AdditionalInfo: "",
 	}
 
 	createForm := types.CreateForm{
@@ -226,7 +226,8 @@ func startFormProcess(wg *sync.WaitGroup, numNodes, numVotes, numSec int, proxyA
 		Action: "shuffle",
 	}
 
-	signed, err := createSignedRequest(secret, shuffleBallotsRequest)
+	signed, err := createSignedRequest(secret, http.MethodPut,
+		"/evoting/services/shuffle/"+formID, shuffleBallotsRequest)
 	require.NoError(t, err)
 
 	randomproxy = proxyArray[rand.Intn(len(proxyArray))]
@@ -0,0 +1,52 @@
+// Package output prints the results of the CLI commands, either as a table
+// for the operators or as JSON for the scripts.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/xerrors"
+)
+
+// Table is the human-readable representation of a result.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// Print writes the value as indented JSON if asJSON is true, otherwise it
+// writes the table with aligned columns.
+func Print(out io.Writer, asJSON bool, value interface{}, table Table) error {
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(value)
+		if err != nil {
+			return xerrors.Errorf("failed to encode: %v", err)
+		}
+
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	if len(table.Header) > 0 {
+		fmt.Fprintln(writer, strings.Join(table.Header, "\t"))
+	}
+
+	for _, row := range table.Rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return xerrors.Errorf("failed to write table: %v", err)
+	}
+
+	return nil
+}
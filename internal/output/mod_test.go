@@ -0,0 +1,35 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	value := struct {
+		Name  string
+		Count int
+	}{"aa", 1}
+
+	table := Table{
+		Header: []string{"NAME", "COUNT"},
+		Rows:   [][]string{{"aa", "1"}, {"longer", "22"}},
+	}
+
+	out := new(bytes.Buffer)
+
+	err := Print(out, false, value, table)
+	require.NoError(t, err)
+	require.Equal(t, "NAME    COUNT\naa      1\nlonger  22\n", out.String())
+
+	out.Reset()
+
+	err = Print(out, true, value, table)
+	require.NoError(t, err)
+	require.Equal(t, "{\n  \"Name\": \"aa\",\n  \"Count\": 1\n}\n", out.String())
+
+	err = Print(out, true, make(chan int), table)
+	require.EqualError(t, err, "failed to encode: json: unsupported type: chan int")
+}
@@ -21,12 +21,9 @@ func NewForm(ctx serde.Context, snapshot store.Snapshot, formID string) (types.F
 	form := types.Form{
 		Configuration: types.Configuration{
 			Title: types.Title{
-				En:  "dummyTitle",
-				Fr:  "",
-				De:  "",
-				URL: "",
+				Text: types.LangMap{"en": "dummyTitle"},
+				URL:  "",
 			},
-			AdditionalInfo: "",
 		},
 		FormID:           formID,
 		Status:           types.Closed,
@@ -77,20 +74,20 @@ func NewKCPointsMarshalled(k int) ([]kyber.Point, []kyber.Point, kyber.Point) {
 
 // BasicConfiguration returns a basic form configuration
 var BasicConfiguration = types.Configuration{
-	Title: types.Title{En: "formTitle", Fr: "", De: "", URL: ""},
+	Title: types.Title{Text: types.LangMap{"en": "formTitle"}},
 	Scaffold: []types.Subject{
 		{
 			ID:       "aa",
-			Title:    types.Title{En: "subject1", Fr: "", De: "", URL: ""},
+			Title:    types.Title{Text: types.LangMap{"en": "subject1"}},
 			Order:    nil,
 			Subjects: nil,
 			Selects: []types.Select{
 				{
 					ID:      "bb",
-					Title:   types.Title{En: "Select your favorite snacks", Fr: "", De: "", URL: ""},
+					Title:   types.Title{Text: types.LangMap{"en": "Select your favorite snacks"}},
 					MaxN:    3,
 					MinN:    0,
-					Choices: []types.Choice{{Choice: "snickers", URL: ""}, {Choice: "mars", URL: ""}, {Choice: "vodka", URL: ""}, {Choice: "babibel", URL: ""}},
+					Choices: []types.Choice{{Choice: types.LangMap{"en": "snickers"}}, {Choice: types.LangMap{"en": "mars"}}, {Choice: types.LangMap{"en": "vodka"}}, {Choice: types.LangMap{"en": "babibel"}}},
 				},
 			},
 			Ranks: []types.Rank{},
@@ -98,7 +95,7 @@ var BasicConfiguration = types.Configuration{
 		},
 		{
 			ID:       "dd",
-			Title:    types.Title{En: "subject2", Fr: "", De: "", URL: ""},
+			Title:    types.Title{Text: types.LangMap{"en": "subject2"}},
 			Order:    nil,
 			Subjects: nil,
 			Selects:  nil,
@@ -106,12 +103,12 @@ var BasicConfiguration = types.Configuration{
 			Texts: []types.Text{
 				{
 					ID:        "ee",
-					Title:     types.Title{En: "dissertation", Fr: "", De: "", URL: ""},
+					Title:     types.Title{Text: types.LangMap{"en": "dissertation"}},
 					MaxN:      1,
 					MinN:      1,
 					MaxLength: 3,
 					Regex:     "",
-					Choices:   []types.Choice{{Choice: "write yes in your language", URL: ""}},
+					Choices:   []types.Choice{{Choice: types.LangMap{"en": "write yes in your language"}}},
 				},
 			},
 		},
@@ -3,25 +3,86 @@ package proxy
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
+	"errors"
 
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"go.dedis.ch/d-voting/proxy/drain"
 	"go.dedis.ch/d-voting/proxy/types"
 	dkgSrv "go.dedis.ch/d-voting/services/dkg"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/core/txn"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
-// NewDKG returns a new initialized DKG proxy
-func NewDKG(mngr txn.Manager, d dkgSrv.DKG, pk kyber.Point) DKG {
+// DKGSetupOperation is the kind of the operation that sets up a DKG actor
+const DKGSetupOperation = "dkgsetup"
+
+// NewDKG returns a new initialized DKG proxy. The setups of the actors are
+// tracked by the tracker.
+func NewDKG(mngr txn.Manager, d dkgSrv.DKG, verifier types.Verifier,
+	tracker *drain.Tracker) DKG {
+
 	return dkg{
 		manager:    mngr,
 		dkgService: d,
-		pk:         pk,
+		verifier:   verifier,
+		tracker:    tracker,
+	}
+}
+
+// ResumeDKGSetups sets up again the actors whose setup was interrupted by the
+// last stop of the node.
+func ResumeDKGSetups(d dkgSrv.DKG, tracker *drain.Tracker) error {
+	ops, err := tracker.Pending()
+	if err != nil {
+		return xerrors.Errorf("failed to get pending operations: %v", err)
+	}
+
+	for _, op := range ops {
+		if op.Kind != DKGSetupOperation {
+			continue
+		}
+
+		formIDBuf, err := hex.DecodeString(op.FormID)
+		if err != nil {
+			return xerrors.Errorf("failed to decode formID: %v", err)
+		}
+
+		a, exists := d.GetActor(formIDBuf)
+
+		status := dkgSrv.Setup
+		if exists {
+			status = a.Status().Status
+		}
+
+		// the setup is over, or the actor is gone
+		if status != dkgSrv.Initialized && status != dkgSrv.Failed {
+			err = tracker.Remove(op)
+			if err != nil {
+				return xerrors.Errorf("failed to remove operation: %v", err)
+			}
+
+			continue
+		}
+
+		dela.Logger.Info().Str("formID", op.FormID).Msg("resuming DKG setup")
+
+		err = tracker.Go(op, setup(a))
+		if err != nil {
+			return xerrors.Errorf("failed to resume setup: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// setup returns a function that sets up the actor.
+func setup(a dkgSrv.Actor) func() error {
+	return func() error {
+		_, err := a.Setup()
+		return err
 	}
 }
 
@@ -33,8 +94,10 @@ type dkg struct {
 	manager txn.Manager
 	// dkgService is the DKG service
 	dkgService dkgSrv.DKG
-	// pk is the public key of the proxy
-	pk kyber.Point
+	// verifier verifies the signed requests
+	verifier types.Verifier
+	// tracker tracks the setups, so that they are resumed if the node stops
+	tracker *drain.Tracker
 }
 
 // NewDKGActor implements proxy.DKG
@@ -46,26 +109,26 @@ func (d dkg) NewDKGActor(w http.ResponseWriter, r *http.Request) {
 	// Read the request
 	signed, err := types.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, types.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// Verify the request
-	err = signed.GetAndVerify(d.pk, &req)
+	err = signed.GetAndVerify(d.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
 	formIDBuf, err := hex.DecodeString(req.FormID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+req.FormID,
-			http.StatusBadRequest)
+		BadRequestError(w, r, types.CodeInvalidFormID,
+			xerrors.Errorf("failed to decode formID: %v", err))
 		return
 	}
 
 	if len(formIDBuf) == 0 {
-		http.Error(w, "formID is empty", http.StatusBadRequest)
+		BadRequestError(w, r, types.CodeInvalidFormID, xerrors.New("formID is empty"))
 		return
 	}
 
@@ -77,8 +140,7 @@ func (d dkg) NewDKGActor(w http.ResponseWriter, r *http.Request) {
 	// subscribe to the DKG service
 	_, err = d.dkgService.Listen(formIDBuf, d.manager)
 	if err != nil {
-		http.Error(w, "failed to start actor: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to start actor: %v", err))
 		return
 	}
 }
@@ -93,7 +155,7 @@ func (d dkg) Actor(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is present
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars))
 		return
 	}
 
@@ -101,13 +163,13 @@ func (d dkg) Actor(w http.ResponseWriter, r *http.Request) {
 
 	formIDBuf, err := hex.DecodeString(formID)
 	if err != nil {
-		BadRequestError(w, r, xerrors.Errorf("failed to decode formID: %v", err), nil)
+		BadRequestError(w, r, types.CodeInvalidFormID, xerrors.Errorf("failed to decode formID: %v", err))
 		return
 	}
 
 	actor, found := d.dkgService.GetActor(formIDBuf)
 	if !found {
-		NotFoundErr(w, r, xerrors.New("actor not found"), nil)
+		NotFoundErr(w, r, types.CodeActorNotFound, xerrors.New("actor not found"))
 		return
 	}
 
@@ -136,7 +198,7 @@ func (d dkg) Actor(w http.ResponseWriter, r *http.Request) {
 	// encode the response
 	err = json.NewEncoder(w).Encode(response)
 	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to write response: %v", err), nil)
+		InternalError(w, r, xerrors.Errorf("failed to write response: %v", err))
 		return
 	}
 }
@@ -149,20 +211,20 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 	// Read the request
 	signed, err := types.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, types.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// Verify the signature
-	err = signed.GetAndVerify(d.pk, &req)
+	err = signed.GetAndVerify(d.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
 	vars := mux.Vars(r)
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars))
 		return
 	}
 
@@ -170,14 +232,14 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 
 	formIDBuf, err := hex.DecodeString(formID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+formID, http.StatusBadRequest)
+		BadRequestError(w, r, types.CodeInvalidFormID, xerrors.Errorf("failed to decode formID: %v", err))
 		return
 	}
 
 	// get the actor
 	a, exists := d.dkgService.GetActor(formIDBuf)
 	if !exists {
-		http.Error(w, "actor does not exist", http.StatusInternalServerError)
+		NotFoundErr(w, r, types.CodeActorNotFound, xerrors.New("actor not found"))
 		return
 	}
 
@@ -186,21 +248,26 @@ func (d dkg) EditDKGActor(w http.ResponseWriter, r *http.Request) {
 	case "setup":
 		// As the setup can be long, we run it asynchronously. One can fetch the
 		// status of the actor to know when the setup is over.
-		go func() {
-			_, err := a.Setup()
-			if err != nil {
-				dela.Logger.Err(err).Msg("failed to setup")
-			}
-		}()
+		op := drain.Operation{Kind: DKGSetupOperation, FormID: formID}
+
+		err = d.tracker.Go(op, setup(a))
+		if errors.Is(err, drain.ErrDraining) {
+			UnavailableError(w, r, err)
+			return
+		}
+		if err != nil {
+			InternalError(w, r, xerrors.Errorf("failed to start setup: %v", err))
+			return
+		}
 	// begin the decryption
 	case "computePubshares":
 		err = a.ComputePubshares()
 		if err != nil {
-			http.Error(w, "failed to compute pubshares: "+err.Error(), http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to compute pubshares: %v", err))
 			return
 		}
 	default:
-		BadRequestError(w, r, xerrors.Errorf("invalid action: %s", req.Action), nil)
+		BadRequestError(w, r, types.CodeInvalidAction, xerrors.Errorf("invalid action: %s", req.Action))
 		return
 	}
 }
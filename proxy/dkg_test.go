@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
@@ -8,17 +9,23 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/proxy/drain"
 	"go.dedis.ch/d-voting/proxy/types"
 	dkgSrv "go.dedis.ch/d-voting/services/dkg"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/dela/cli/node"
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/util/random"
 	"golang.org/x/xerrors"
 )
 
@@ -36,15 +43,17 @@ func TestNewDkg(t *testing.T) {
 	var pk kyber.Point
 	ctx.Injector.Inject(&pk)
 
-	dkgInterface := NewDKG(mngr, d, pk)
+	verifier := types.NewKeyVerifier(pk)
+
+	dkgInterface := NewDKG(mngr, d, verifier, nil)
 	//check that the dkg is not nil
 	require.NotNil(t, dkgInterface)
 	//the txn.Manager of the dkg should be the same as the one we injected$
 	require.Equal(t, mngr, dkgInterface.(dkg).manager)
 	//the dkg of the dkg should be the same as the one we injected
 	require.Equal(t, d, dkgInterface.(dkg).dkgService)
-	//the pk of the dkg should be the same as the one we injected
-	require.Equal(t, pk, dkgInterface.(dkg).pk)
+	//the verifier of the dkg should be the same as the one we injected
+	require.Equal(t, verifier, dkgInterface.(dkg).verifier)
 }
 
 // test that NewDKGActor is working properly
@@ -80,9 +89,9 @@ func TestNewDKGActor(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, types.NewKeyVerifier(public), nil)
 
-	requestt, e := createSignedRequest(secret, request)
+	requestt, e := createSignedRequest(secret, "POST", "/dkg", request)
 	require.NoError(t, e)
 
 	r, e := http.NewRequest("POST", "/dkg", strings.NewReader(string(requestt)))
@@ -121,7 +130,7 @@ func TestNewDKGActorInvalidRequest(t *testing.T) {
 	err = secret.UnmarshalBinary(secretkeyBuf)
 	require.NoError(t, err)
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, types.NewKeyVerifier(public), nil)
 
 	r, e := http.NewRequest("POST", "/dkg", strings.NewReader("abcd"))
 	if e != nil {
@@ -130,7 +139,7 @@ func TestNewDKGActorInvalidRequest(t *testing.T) {
 
 	dkgInterface.NewDKGActor(w, r)
 
-	require.Equal(t, 500, w.(*httptest.ResponseRecorder).Result().StatusCode)
+	require.Equal(t, 400, w.(*httptest.ResponseRecorder).Result().StatusCode)
 
 }
 
@@ -167,9 +176,9 @@ func TestNewDKGActorInvalidSignature(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, types.NewKeyVerifier(public), nil)
 
-	requestt, err := createSignedRequest(secret, request)
+	requestt, err := createSignedRequest(secret, "POST", "/dkg", request)
 	require.NoError(t, err)
 
 	r, err := http.NewRequest("POST", "/dkg", strings.NewReader(string(requestt)))
@@ -177,7 +186,7 @@ func TestNewDKGActorInvalidSignature(t *testing.T) {
 
 	dkgInterface.NewDKGActor(w, r)
 
-	require.Equal(t, 500, w.(*httptest.ResponseRecorder).Result().StatusCode)
+	require.Equal(t, 403, w.(*httptest.ResponseRecorder).Result().StatusCode)
 
 }
 
@@ -214,9 +223,9 @@ func TestNewDKGActorInvalidFormID(t *testing.T) {
 		FormID: "abcdefg",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, types.NewKeyVerifier(public), nil)
 
-	requestt, err := createSignedRequest(secret, request)
+	requestt, err := createSignedRequest(secret, "POST", "/dkg", request)
 
 	require.NoError(t, err)
 
@@ -263,9 +272,9 @@ func TestNewDKGActorFormDoesNotExist(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGServiceError{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGServiceError{}, types.NewKeyVerifier(public), nil)
 
-	requestt, err := createSignedRequest(secret, request)
+	requestt, err := createSignedRequest(secret, "POST", "/dkg", request)
 
 	require.NoError(t, err)
 
@@ -310,9 +319,9 @@ func TestActor(t *testing.T) {
 		FormID: "abcd",
 	}
 
-	dkgInterface := NewDKG(mngr, mockDKGService{}, public)
+	dkgInterface := NewDKG(mngr, mockDKGService{}, types.NewKeyVerifier(public), nil)
 
-	requestt, err := createSignedRequest(secret, request)
+	requestt, err := createSignedRequest(secret, "GET", "/services/dkg/actors/1234", request)
 	require.NoError(t, err)
 
 	r, err := http.NewRequest("GET", "/services/dkg/actors/1234", strings.NewReader(string(requestt)))
@@ -324,9 +333,151 @@ func TestActor(t *testing.T) {
 
 }
 
+// test that the setup of an actor is tracked, and refused once the node is
+// stopping
+func TestEditDKGActor_Setup(t *testing.T) {
+	secret := suite.Scalar().Pick(random.New())
+	verifier := types.NewKeyVerifier(suite.Point().Mul(secret, nil))
+
+	actor := newSetupActor(dkgSrv.Initialized)
+	service := setupDKGService{actors: map[string]dkgSrv.Actor{"aa": actor}}
+	tracker := drain.NewTracker(fake.NewInMemoryDB(), 0)
+
+	dkgInterface := NewDKG(nil, service, verifier, tracker)
+
+	edit := func() *httptest.ResponseRecorder {
+		body := signed(t, secret, http.MethodPut, "/evoting/services/dkg/actors/aa",
+			types.UpdateDKG{Action: "setup"})
+
+		r := httptest.NewRequest(http.MethodPut, "/evoting/services/dkg/actors/aa",
+			strings.NewReader(body))
+		r = mux.SetURLVars(r, map[string]string{"formID": "aa"})
+
+		w := httptest.NewRecorder()
+		dkgInterface.EditDKGActor(w, r)
+
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, edit().Code)
+
+	select {
+	case <-actor.setups:
+	case <-time.After(time.Second):
+		t.Fatal("actor not set up")
+	}
+
+	require.NoError(t, tracker.Drain(context.Background()))
+
+	ops, err := tracker.Pending()
+	require.NoError(t, err)
+	require.Empty(t, ops)
+
+	w := edit()
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Equal(t, drain.RetryAfter, w.Header().Get("Retry-After"))
+}
+
+// test that the interrupted setups are resumed, unless the actor is set up or
+// gone
+func TestResumeDKGSetups(t *testing.T) {
+	db := fake.NewInMemoryDB()
+
+	// interrupt the setups of three actors
+	previous := drain.NewTracker(db, 0)
+
+	release := make(chan struct{})
+	for _, formID := range []string{"aa", "bb", "cc"} {
+		op := drain.Operation{Kind: DKGSetupOperation, FormID: formID}
+
+		err := previous.Go(op, func() error {
+			<-release
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, previous.Drain(ctx))
+	close(release)
+
+	// the resumed setup waits for the resume to be over, as the fake database
+	// doesn't serialize its transactions
+	interrupted := newSetupActor(dkgSrv.Failed)
+	interrupted.release = make(chan struct{})
+
+	service := setupDKGService{actors: map[string]dkgSrv.Actor{
+		"aa": interrupted,
+		"bb": newSetupActor(dkgSrv.Setup),
+	}}
+
+	tracker := drain.NewTracker(db, 0)
+
+	err := ResumeDKGSetups(service, tracker)
+	require.NoError(t, err)
+
+	close(interrupted.release)
+
+	select {
+	case <-interrupted.setups:
+	case <-time.After(time.Second):
+		t.Fatal("setup not resumed")
+	}
+
+	require.NoError(t, tracker.Drain(context.Background()))
+
+	ops, err := tracker.Pending()
+	require.NoError(t, err)
+	require.Empty(t, ops)
+}
+
 // -----------------------------------------------------------------------------
 // Utility functions
 
+// setupDKGService returns the actors of a map
+//
+// - implements dkgSrv.DKG
+type setupDKGService struct {
+	dkgSrv.DKG
+	actors map[string]dkgSrv.Actor
+}
+
+func (s setupDKGService) GetActor(formID []byte) (dkgSrv.Actor, bool) {
+	actor, found := s.actors[hex.EncodeToString(formID)]
+	return actor, found
+}
+
+// setupActor notifies its setups, which wait for the release channel if it
+// is set
+//
+// - implements dkgSrv.Actor
+type setupActor struct {
+	dkgSrv.Actor
+	status  dkgSrv.StatusCode
+	setups  chan struct{}
+	release chan struct{}
+}
+
+func newSetupActor(status dkgSrv.StatusCode) *setupActor {
+	return &setupActor{status: status, setups: make(chan struct{}, 1)}
+}
+
+func (a *setupActor) Setup() (kyber.Point, error) {
+	a.setups <- struct{}{}
+
+	if a.release != nil {
+		<-a.release
+	}
+
+	return nil, nil
+}
+
+func (a *setupActor) Status() dkgSrv.Status {
+	return dkgSrv.Status{Status: a.status}
+}
+
 // mock dkgService
 type mockDKGService struct {
 	dkgSrv.DKG
@@ -352,12 +503,33 @@ func (m mockDKGServiceError) GetActor(formID []byte) (dkgSrv.Actor, bool) {
 	return nil, false
 }
 
-func createSignedRequest(secret kyber.Scalar, msg interface{}) ([]byte, error) {
+// createSignedRequest returns a signed request for the HTTP method and path,
+// with the fields that protect it against replays.
+func createSignedRequest(secret kyber.Scalar, method, path string,
+	msg interface{}) ([]byte, error) {
+
 	jsonMsg, err := json.Marshal(msg)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to marshal json: %v", err)
 	}
 
+	fields := map[string]json.RawMessage{}
+
+	err = json.Unmarshal(jsonMsg, &fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal json: %v", err)
+	}
+
+	fields["Timestamp"] = json.RawMessage(strconv.FormatInt(time.Now().Unix(), 10))
+	fields["Nonce"] = json.RawMessage(`"` + hex.EncodeToString(random.Bits(128, true, random.New())) + `"`)
+	fields["Method"] = json.RawMessage(strconv.Quote(method))
+	fields["Path"] = json.RawMessage(strconv.Quote(path))
+
+	jsonMsg, err = json.Marshal(fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal json: %v", err)
+	}
+
 	payload := base64.URLEncoding.EncodeToString(jsonMsg)
 
 	hash := sha256.New()
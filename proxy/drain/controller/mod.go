@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/cli"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/store/kv"
+	"golang.org/x/xerrors"
+)
+
+// NewController returns a new controller initializer
+func NewController() node.Initializer {
+	return controller{}
+}
+
+// controller is an initializer that drains the proxy when the node stops.
+//
+// - implements node.Initializer
+type controller struct{}
+
+// SetCommands implements node.Initializer.
+func (m controller) SetCommands(builder node.Builder) {
+	builder.SetStartFlags(
+		cli.DurationFlag{
+			Name: "draintimeout",
+			Usage: "the maximum duration to wait for the pending requests, " +
+				"operations and transactions of the proxy when the node stops",
+			Value: drain.DefaultTimeout,
+		},
+	)
+}
+
+// OnStart implements node.Initializer. It creates the tracker of the proxy,
+// which saves the pending operations in the database.
+func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
+	var db kv.DB
+	err := inj.Resolve(&db)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve db: %v", err)
+	}
+
+	inj.Inject(drain.NewTracker(db, ctx.Duration("draintimeout")))
+
+	return nil
+}
+
+// OnStop implements node.Initializer. It waits for the pending work of the
+// proxy. A timeout is logged rather than returned, so that the other
+// components still stop.
+func (controller) OnStop(inj node.Injector) error {
+	var tracker *drain.Tracker
+	err := inj.Resolve(&tracker)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve tracker: %v", err)
+	}
+
+	err = tracker.Stop()
+	if err != nil {
+		dela.Logger.Warn().Err(err).Msg("proxy not drained")
+		return nil
+	}
+
+	dela.Logger.Info().Msg("proxy drained")
+
+	return nil
+}
@@ -0,0 +1,302 @@
+// Package drain stops the proxy gracefully. It tracks the requests that change
+// the state and the asynchronous operations they start, refuses the new ones
+// once the node is stopping, and waits for the pending work before the node
+// stops.
+//
+// The asynchronous operations are saved in the database until they are over,
+// so that the ones interrupted by a stop can be resumed when the node starts
+// again.
+package drain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/store/kv"
+	"golang.org/x/xerrors"
+)
+
+// BucketName is the name of the bucket of the pending operations
+const BucketName = "proxyoperations"
+
+// DefaultTimeout is the default maximum duration to wait for the pending work
+// when the node stops.
+const DefaultTimeout = 30 * time.Second
+
+// RetryAfter is the delay, in seconds, advertised to the clients whose requests
+// are refused because the node is stopping.
+const RetryAfter = "30"
+
+// ErrDraining is returned when an operation is started while the node is
+// stopping.
+var ErrDraining = errors.New("the node is stopping")
+
+// Operation is an asynchronous operation started by the proxy
+type Operation struct {
+	// Kind tells what the operation does
+	Kind string
+	// FormID is the hex-encoded ID of the form of the operation
+	FormID string
+}
+
+// key returns the key of the operation in the bucket
+func (op Operation) key() []byte {
+	return []byte(op.Kind + "/" + op.FormID)
+}
+
+// Tracker tracks the requests and the operations of the proxy.
+type Tracker struct {
+	sync.Mutex
+
+	db      kv.DB
+	timeout time.Duration
+	hooks   []func(context.Context) error
+
+	// running is the number of requests and operations in progress, and idle
+	// is closed when it is zero.
+	running int
+	idle    chan struct{}
+
+	// draining is true once the node is stopping, and stopped once the
+	// tracker stopped waiting. The operations that are over after the stop
+	// are kept in the database, as they may have been interrupted.
+	draining bool
+	stopped  bool
+}
+
+// NewTracker returns a new tracker that saves the operations in the database,
+// and waits at most the timeout, or DefaultTimeout if it is not positive, when
+// it stops.
+func NewTracker(db kv.DB, timeout time.Duration) *Tracker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	idle := make(chan struct{})
+	close(idle)
+
+	return &Tracker{
+		db:      db,
+		timeout: timeout,
+		idle:    idle,
+	}
+}
+
+// Handler returns a handler that tracks the requests of next that change the
+// state. Once the node is stopping, these requests are refused with a 503
+// error, and the others are still served.
+func (t *Tracker) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isReadOnly(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !t.begin() {
+			w.Header().Set("Retry-After", RetryAfter)
+
+			ptypes.NewHTTPError(r, http.StatusServiceUnavailable,
+				ptypes.CodeNodeStopping, ErrDraining).Write(w)
+			return
+		}
+
+		defer t.end()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Go saves the operation and runs fn in a goroutine. The operation is removed
+// from the database once fn returns, whether it fails or not, unless the
+// tracker stopped in the meantime. It returns ErrDraining if the node is
+// stopping.
+func (t *Tracker) Go(op Operation, fn func() error) error {
+	if !t.begin() {
+		return ErrDraining
+	}
+
+	err := t.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(BucketName))
+		if err != nil {
+			return xerrors.Errorf("failed to get bucket: %v", err)
+		}
+
+		return bucket.Set(op.key(), []byte{})
+	})
+	if err != nil {
+		t.end()
+		return xerrors.Errorf("failed to save operation: %v", err)
+	}
+
+	go func() {
+		defer t.end()
+
+		err := fn()
+		if err != nil {
+			dela.Logger.Err(err).Str("kind", op.Kind).Str("formID", op.FormID).
+				Msg("operation failed")
+		}
+
+		t.Lock()
+		stopped := t.stopped
+		t.Unlock()
+
+		if stopped {
+			return
+		}
+
+		err = t.Remove(op)
+		if err != nil {
+			dela.Logger.Err(err).Str("kind", op.Kind).Str("formID", op.FormID).
+				Msg("failed to remove operation")
+		}
+	}()
+
+	return nil
+}
+
+// Remove removes an operation from the database.
+func (t *Tracker) Remove(op Operation) error {
+	err := t.db.Update(func(tx kv.WritableTx) error {
+		bucket := tx.GetBucket([]byte(BucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.Delete(op.key())
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to remove operation: %v", err)
+	}
+
+	return nil
+}
+
+// Pending returns the operations saved in the database. When it is called at
+// start, these are the operations interrupted by the last stop.
+func (t *Tracker) Pending() ([]Operation, error) {
+	var ops []Operation
+
+	err := t.db.View(func(tx kv.ReadableTx) error {
+		bucket := tx.GetBucket([]byte(BucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, _ []byte) error {
+			parts := strings.SplitN(string(key), "/", 2)
+			if len(parts) != 2 {
+				return xerrors.Errorf("invalid operation: %s", key)
+			}
+
+			ops = append(ops, Operation{Kind: parts[0], FormID: parts[1]})
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read operations: %v", err)
+	}
+
+	return ops, nil
+}
+
+// OnDrain adds a function called when the node stops, once the requests and
+// the operations are over. It must return when the context is done.
+func (t *Tracker) OnDrain(hook func(context.Context) error) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.hooks = append(t.hooks, hook)
+}
+
+// Stop drains the tracker, waiting at most the timeout of the tracker.
+func (t *Tracker) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+
+	return t.Drain(ctx)
+}
+
+// Drain refuses the new requests and operations, waits for the ones in
+// progress, and then calls the hooks, until the context is done.
+func (t *Tracker) Drain(ctx context.Context) error {
+	t.Lock()
+	t.draining = true
+	idle := t.idle
+	hooks := t.hooks
+	t.Unlock()
+
+	defer func() {
+		t.Lock()
+		t.stopped = true
+		t.Unlock()
+	}()
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		t.Lock()
+		running := t.running
+		t.Unlock()
+
+		return xerrors.Errorf("%d requests or operations still running: %v",
+			running, ctx.Err())
+	}
+
+	for _, hook := range hooks {
+		err := hook(ctx)
+		if err != nil {
+			return xerrors.Errorf("failed to drain: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// begin counts a new request or operation. It returns false if the node is
+// stopping.
+func (t *Tracker) begin() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.draining {
+		return false
+	}
+
+	if t.running == 0 {
+		t.idle = make(chan struct{})
+	}
+
+	t.running++
+
+	return true
+}
+
+// end counts the end of a request or operation.
+func (t *Tracker) end() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.running--
+
+	if t.running == 0 {
+		close(t.idle)
+	}
+}
+
+// isReadOnly returns true if the request can't change the state.
+func isReadOnly(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
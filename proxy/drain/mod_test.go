@@ -0,0 +1,184 @@
+package drain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+func TestTracker_Handler(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 0)
+
+	handler := tracker.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	require.Equal(t, http.StatusNoContent, serve(handler, http.MethodPost).Code)
+
+	require.NoError(t, tracker.Drain(context.Background()))
+
+	// the reads are still served
+	require.Equal(t, http.StatusNoContent, serve(handler, http.MethodGet).Code)
+
+	rec := serve(handler, http.MethodPost)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, RetryAfter, rec.Header().Get("Retry-After"))
+
+	var httpErr ptypes.HTTPError
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&httpErr))
+	require.Equal(t, ptypes.CodeNodeStopping, httpErr.Reason)
+}
+
+func TestTracker_DrainWaitsForRequests(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 0)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := tracker.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+
+	go serve(handler, http.MethodPut)
+	<-started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- tracker.Drain(context.Background())
+	}()
+
+	select {
+	case <-drained:
+		t.Fatal("drained with a request in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case err := <-drained:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("still draining")
+	}
+}
+
+func TestTracker_DrainTimeout(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 10*time.Millisecond)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	err := tracker.Go(Operation{Kind: "test", FormID: "aa"}, func() error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = tracker.Stop()
+	require.EqualError(t, err, "1 requests or operations still running: context deadline exceeded")
+}
+
+func TestTracker_Go(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 0)
+
+	op := Operation{Kind: "test", FormID: "aa"}
+	release := make(chan struct{})
+
+	err := tracker.Go(op, func() error {
+		<-release
+		return xerrors.New("oops")
+	})
+	require.NoError(t, err)
+
+	ops, err := tracker.Pending()
+	require.NoError(t, err)
+	require.Equal(t, []Operation{op}, ops)
+
+	close(release)
+
+	// a failed operation is over too
+	require.NoError(t, tracker.Drain(context.Background()))
+
+	ops, err = tracker.Pending()
+	require.NoError(t, err)
+	require.Empty(t, ops)
+
+	err = tracker.Go(op, func() error { return nil })
+	require.Equal(t, ErrDraining, err)
+}
+
+func TestTracker_GoAfterStop(t *testing.T) {
+	db := fake.NewInMemoryDB()
+	tracker := NewTracker(db, 0)
+
+	op := Operation{Kind: "test", FormID: "aa"}
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	err := tracker.Go(op, func() error {
+		defer close(done)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, tracker.Drain(ctx))
+
+	close(release)
+	<-done
+
+	// the operation may have been interrupted by the stop, so it is kept to be
+	// resumed by the next tracker
+	ops, err := NewTracker(db, 0).Pending()
+	require.NoError(t, err)
+	require.Equal(t, []Operation{op}, ops)
+}
+
+func TestTracker_Remove(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 0)
+
+	// nothing to remove
+	require.NoError(t, tracker.Remove(Operation{Kind: "test", FormID: "aa"}))
+}
+
+func TestTracker_OnDrain(t *testing.T) {
+	tracker := NewTracker(fake.NewInMemoryDB(), 0)
+
+	var calls []string
+
+	tracker.OnDrain(func(ctx context.Context) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	tracker.OnDrain(func(ctx context.Context) error {
+		calls = append(calls, "second")
+		return xerrors.New("oops")
+	})
+
+	err := tracker.Drain(context.Background())
+	require.EqualError(t, err, "failed to drain: oops")
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func serve(handler http.Handler, method string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, "/evoting/forms", nil))
+
+	return rec
+}
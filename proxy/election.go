@@ -4,21 +4,24 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
 	"go.dedis.ch/d-voting/contracts/evoting"
 	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
 	"go.dedis.ch/d-voting/proxy/txnmanager"
 	ptypes "go.dedis.ch/d-voting/proxy/types"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/txn/pool"
 	"go.dedis.ch/dela/serde"
-	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
 	"golang.org/x/xerrors"
 )
@@ -31,9 +34,11 @@ func getSignedErr(err error) error {
 	return xerrors.Errorf("failed to get and verify signed request: %v", err)
 }
 
-// NewForm returns a new initialized form proxy
+// NewForm returns a new initialized form proxy. The votes are refused when
+// they exceed the limits of the form or of the user.
 func NewForm(srv ordering.Service, p pool.Pool,
-	ctx serde.Context, fac serde.Factory, pk kyber.Point, txnManaxer txnmanager.Manager) Form {
+	ctx serde.Context, fac serde.Factory, verifier ptypes.Verifier, txnManaxer txnmanager.Manager,
+	limiter *ratelimit.Limiter) Form {
 
 	logger := dela.Logger.With().Timestamp().Str("role", "evoting-proxy").Logger()
 
@@ -44,7 +49,8 @@ func NewForm(srv ordering.Service, p pool.Pool,
 		formFac:     fac,
 		mngr:        txnManaxer,
 		pool:        p,
-		pk:          pk,
+		verifier:    verifier,
+		limiter:     limiter,
 	}
 }
 
@@ -60,7 +66,8 @@ type form struct {
 	formFac     serde.Factory
 	mngr        txnmanager.Manager
 	pool        pool.Pool
-	pk          kyber.Point
+	verifier    ptypes.Verifier
+	limiter     *ratelimit.Limiter
 }
 
 // NewForm implements proxy.Proxy
@@ -70,14 +77,14 @@ func (h *form) NewForm(w http.ResponseWriter, r *http.Request) {
 	// get the signed request
 	signed, err := ptypes.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(h.pk, &req)
+	err = signed.GetAndVerify(h.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
@@ -89,40 +96,67 @@ func (h *form) NewForm(w http.ResponseWriter, r *http.Request) {
 	// serialize the transaction
 	data, err := createForm.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CreateFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateFormTransaction: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, blockIdx, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdCreateForm, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdCreateForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
-	// hash the transaction
-	hash := sha256.New()
-	hash.Write(txnID)
-	formID := hash.Sum(nil)
+	sendFormCreated(w, r, h.mngr, submission)
+}
 
-	// create it to get the  token
-	transactionClientInfo, err := h.mngr.CreateTransactionResult(txnID, blockIdx, txnmanager.UnknownTransactionStatus)
+// CloneForm implements proxy.Proxy. It creates a new form with the
+// configuration of an existing one.
+func (h *form) CloneForm(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CloneFormRequest
+
+	// get the signed request
+	signed, err := ptypes.NewSignedRequest(r.Body)
 	if err != nil {
-		http.Error(w, "failed to create transaction info: "+err.Error(), http.StatusInternalServerError)
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
-	response := ptypes.CreateFormResponse{
-		FormID: hex.EncodeToString(formID),
-		Token:  transactionClientInfo.Token,
+	// get the request and verify the signature
+	err = signed.GetAndVerify(h.verifier, r, &req)
+	if err != nil {
+		signedErr(w, r, err)
+		return
 	}
 
-	// send the response json
-	err = txnmanager.SendResponse(w, response)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
+		return
+	}
+
+	createForm := types.CreateFormFromTemplate{
+		AdminID:        req.AdminID,
+		SourceFormID:   formID,
+		Title:          req.Title,
+		AdditionalInfo: req.AdditionalInfo,
+		Schedule:       req.Schedule,
+	}
+
+	// serialize the transaction
+	data, err := createForm.Serialize(h.context)
 	if err != nil {
-		fmt.Printf("Caught unhandled error: %+v", err)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateFormFromTemplateTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdCreateFormFromTemplate, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
+		return
 	}
+
+	sendFormCreated(w, r, h.mngr, submission)
 }
 
 // NewFormVote implements proxy.Proxy
@@ -132,132 +166,247 @@ func (h *form) NewFormVote(w http.ResponseWriter, r *http.Request) {
 	// get the signed request
 	signed, err := ptypes.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(h.pk, &req)
+	err = signed.GetAndVerify(h.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
-	vars := mux.Vars(r)
-
-	// check if the formID is valid
-	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
 		return
 	}
 
-	formID := vars["formID"]
-
-	elecMD, err := h.getFormsMetadata()
+	// unmarshal the encrypted ballot
+	ciphervote, err := decodeBallot(req.Ballot)
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		BadRequestError(w, r, ptypes.CodeInvalidBallot, err)
 		return
 	}
 
-	// check if the form exist
-	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+	err = h.limiter.AllowVotes(formID, req.UserID)
+	if err != nil {
+		TooManyRequestsError(w, r, err)
 		return
 	}
 
-	ciphervote := make(types.Ciphervote, len(req.Ballot))
+	// the transaction was counted with the vote
+	r = r.WithContext(ratelimit.WithAdmission(r.Context()))
 
-	// unmarshal the encrypted ballot
-	for i, egpair := range req.Ballot {
-		k := suite.Point()
-
-		err = k.UnmarshalBinary(egpair.K)
-		if err != nil {
-			http.Error(w, "failed to unmarshal K: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		c := suite.Point()
-
-		err = c.UnmarshalBinary(egpair.C)
-		if err != nil {
-			http.Error(w, "failed to unmarshal C: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+	form, err := types.FormFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get form: %v", err))
+		return
+	}
 
-		ciphervote[i] = types.EGPair{
-			K: k,
-			C: c,
-		}
+	weight, err := encryptWeight(form, req.UserID)
+	if err != nil {
+		InternalError(w, r, err)
+		return
 	}
 
 	castVote := types.CastVote{
 		FormID: formID,
 		UserID: req.UserID,
 		Ballot: ciphervote,
+		Weight: weight,
 	}
 
 	// serialize the vote
 	data, err := castVote.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CastVoteTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CastVoteTransaction: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdCastVote, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdCastVote, evoting.FormArg, data)
 	if err != nil {
 		h.logger.Err(err).Msg("failed to submit txn")
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
 	// send the transaction's information
-	err = h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	err = h.mngr.SendSubmission(w, submission)
 	if err != nil {
-		http.Error(w, "couldn't send transaction info: "+err.Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("couldn't send transaction info: %v", err))
 		return
 	}
 }
 
-// EditForm implements proxy.Proxy
-func (h *form) EditForm(w http.ResponseWriter, r *http.Request) {
-	var req ptypes.UpdateFormRequest
+// NewFormVotes implements proxy.Proxy. It casts a batch of votes in a single
+// transaction.
+func (h *form) NewFormVotes(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CastVotesRequest
 
 	// get the signed request
 	signed, err := ptypes.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// get the request and verify the signature
-	err = signed.GetAndVerify(h.pk, &req)
+	err = signed.GetAndVerify(h.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
-	vars := mux.Vars(r)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
+		return
+	}
 
-	// check if the formID is valid
-	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+	if len(req.Votes) == 0 || len(req.Votes) > types.MaxVotesPerBatch {
+		BadRequestError(w, r, ptypes.CodeInvalidBatch, xerrors.Errorf(
+			"the batch must have between 1 and %d votes", types.MaxVotesPerBatch))
 		return
 	}
 
-	formID := vars["formID"]
+	form, err := types.FormFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get form: %v", err))
+		return
+	}
 
-	elecMD, err := h.getFormsMetadata()
+	castVotes := types.CastVotes{
+		FormID: formID,
+		Votes:  make([]types.BatchVote, len(req.Votes)),
+	}
+
+	userIDs := make([]string, len(req.Votes))
+
+	for i, vote := range req.Votes {
+		ciphervote, err := decodeBallot(vote.Ballot)
+		if err != nil {
+			BadRequestError(w, r, ptypes.CodeInvalidBallot, xerrors.Errorf("invalid vote %d: %v", i, err))
+			return
+		}
+
+		weight, err := encryptWeight(form, vote.UserID)
+		if err != nil {
+			InternalError(w, r, xerrors.Errorf("invalid vote %d: %v", i, err))
+			return
+		}
+
+		castVotes.Votes[i] = types.BatchVote{
+			UserID: vote.UserID,
+			Ballot: ciphervote,
+			Weight: weight,
+		}
+
+		userIDs[i] = vote.UserID
+	}
+
+	err = h.limiter.AllowVotes(formID, userIDs...)
+	if errors.Is(err, ratelimit.ErrBatchTooLarge) {
+		BadRequestError(w, r, ptypes.CodeInvalidBatch, err)
+		return
+	}
+
+	if err != nil {
+		TooManyRequestsError(w, r, err)
+		return
+	}
+
+	// the transaction was counted with the votes
+	r = r.WithContext(ratelimit.WithAdmission(r.Context()))
+
+	// serialize the votes
+	data, err := castVotes.Serialize(h.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal CastVotesTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdCastVotes, evoting.FormArg, data)
+	if err != nil {
+		h.logger.Err(err).Msg("failed to submit txn")
+		submitErr(w, r, err)
+		return
+	}
+
+	// send the transaction's information
+	err = h.mngr.SendSubmission(w, submission)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("couldn't send transaction info: %v", err))
+		return
+	}
+}
+
+// encryptWeight returns the encrypted weight of the voter in a weighted form.
+// It is nil if the form is not weighted, if the voter is not allowed to vote or
+// if the form is not set up yet, in which case the contract rejects the vote.
+func encryptWeight(form types.Form, userID string) (*types.EncryptedWeight, error) {
+	voterWeight := form.VoterWeight(userID)
+	if voterWeight == 0 || form.Pubkey == nil {
+		return nil, nil
+	}
+
+	weight, err := types.EncryptWeight(form.Pubkey, voterWeight)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to encrypt weight: %v", err)
+	}
+
+	return &weight, nil
+}
+
+// decodeBallot unmarshals the El Gamal pairs of an encrypted ballot.
+func decodeBallot(ballot ptypes.CiphervoteJSON) (types.Ciphervote, error) {
+	ciphervote := make(types.Ciphervote, len(ballot))
+
+	for i, egpair := range ballot {
+		k := suite.Point()
+
+		err := k.UnmarshalBinary(egpair.K)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to unmarshal K: %v", err)
+		}
+
+		c := suite.Point()
+
+		err = c.UnmarshalBinary(egpair.C)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to unmarshal C: %v", err)
+		}
+
+		ciphervote[i] = types.EGPair{
+			K: k,
+			C: c,
+		}
+	}
+
+	return ciphervote, nil
+}
+
+// EditForm implements proxy.Proxy
+func (h *form) EditForm(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.UpdateFormRequest
+
+	// get the signed request
+	signed, err := ptypes.NewSignedRequest(r.Body)
+	if err != nil {
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
+		return
+	}
+
+	// get the request and verify the signature
+	err = signed.GetAndVerify(h.verifier, r, &req)
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		signedErr(w, r, err)
 		return
 	}
 
-	// check if the form exists
-	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
 		return
 	}
 
@@ -270,8 +419,12 @@ func (h *form) EditForm(w http.ResponseWriter, r *http.Request) {
 		h.combineShares(formID, w, r)
 	case "cancel":
 		h.cancelForm(formID, w, r)
+	case "updateConfiguration":
+		h.updateConfiguration(formID, req.Configuration, w, r)
+	case "setVoterWeights":
+		h.setVoterWeights(formID, req.VoterWeights, w, r)
 	default:
-		BadRequestError(w, r, xerrors.Errorf("invalid action: %s", req.Action), nil)
+		BadRequestError(w, r, ptypes.CodeInvalidAction, xerrors.Errorf("invalid action: %s", req.Action))
 		return
 	}
 }
@@ -286,20 +439,79 @@ func (h *form) openForm(formID string, w http.ResponseWriter, r *http.Request) {
 	// serialize the transaction
 	data, err := openForm.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal OpenFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal OpenFormTransaction: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdOpenForm, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdOpenForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
 	// send the transaction's informations
-	h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	h.mngr.SendSubmission(w, submission)
+}
+
+// updateConfiguration replaces the configuration of a form that is not open
+// yet.
+func (h *form) updateConfiguration(formID string, configuration *types.Configuration,
+	w http.ResponseWriter, r *http.Request) {
+
+	if configuration == nil {
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, xerrors.Errorf("missing configuration"))
+		return
+	}
+
+	updateConfiguration := types.UpdateConfiguration{
+		FormID:        formID,
+		Configuration: *configuration,
+	}
+
+	// serialize the transaction
+	data, err := updateConfiguration.Serialize(h.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal UpdateConfigurationTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdUpdateConfiguration, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
+		return
+	}
+
+	// send the transaction's informations
+	h.mngr.SendSubmission(w, submission)
+}
+
+// setVoterWeights sets the weight of each voter of a form.
+func (h *form) setVoterWeights(formID string, weights []types.VoterWeight,
+	w http.ResponseWriter, r *http.Request) {
+
+	setVoterWeights := types.SetVoterWeights{
+		FormID:       formID,
+		VoterWeights: weights,
+	}
+
+	// serialize the transaction
+	data, err := setVoterWeights.Serialize(h.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal SetVoterWeightsTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdSetVoterWeights, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
+		return
+	}
+
+	// send the transaction's informations
+	h.mngr.SendSubmission(w, submission)
 }
 
 // closeForm closes a form.
@@ -312,20 +524,19 @@ func (h *form) closeForm(formIDHex string, w http.ResponseWriter, r *http.Reques
 	// serialize the transaction
 	data, err := closeForm.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CloseFormTransaction: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CloseFormTransaction: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdCloseForm, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdCloseForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
 	// send the transaction's informations
-	h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	h.mngr.SendSubmission(w, submission)
 
 }
 
@@ -334,13 +545,12 @@ func (h *form) combineShares(formIDHex string, w http.ResponseWriter, r *http.Re
 
 	form, err := types.FormFromStore(h.context, h.formFac, formIDHex, h.orderingSvc.GetStore())
 	if err != nil {
-		http.Error(w, "failed to get form: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to get form: %v", err))
 		return
 	}
 	if form.Status != types.PubSharesSubmitted {
-		http.Error(w, "the submission of public shares must be over!",
-			http.StatusUnauthorized)
+		ConflictError(w, r, ptypes.CodeInvalidFormStatus,
+			xerrors.New("the submission of public shares must be over!"))
 		return
 	}
 
@@ -351,20 +561,19 @@ func (h *form) combineShares(formIDHex string, w http.ResponseWriter, r *http.Re
 	// serialize the transaction
 	data, err := decryptBallots.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal decryptBallots: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal decryptBallots: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdCombineShares, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdCombineShares, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
 	// send the transaction's informations
-	h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	h.mngr.SendSubmission(w, submission)
 }
 
 // cancelForm cancels a form.
@@ -377,20 +586,19 @@ func (h *form) cancelForm(formIDHex string, w http.ResponseWriter, r *http.Reque
 	// serialize the transaction
 	data, err := cancelForm.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to marshal CancelForm: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to marshal CancelForm: %v", err))
 		return
 	}
 
 	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdCancelForm, evoting.FormArg, data)
+	submission, err := h.mngr.Submit(r, evoting.CmdCancelForm, evoting.FormArg, data)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		submitErr(w, r, err)
 		return
 	}
 
 	// send the transaction's informations
-	h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	h.mngr.SendSubmission(w, submission)
 }
 
 // Form implements proxy.Proxy. The request should not be signed because it
@@ -399,20 +607,15 @@ func (h *form) Form(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Headers", "*")
 
-	vars := mux.Vars(r)
-
-	// check if the form exists
-	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
 		return
 	}
 
-	formID := vars["formID"]
-
 	// get the form
 	form, err := types.FormFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
 	if err != nil {
-		http.Error(w, xerrors.Errorf("failed to get form: %v", err).Error(), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("failed to get form: %v", err))
 		return
 	}
 
@@ -422,8 +625,7 @@ func (h *form) Form(w http.ResponseWriter, r *http.Request) {
 	if form.Pubkey != nil {
 		pubkeyBuf, err = form.Pubkey.MarshalBinary()
 		if err != nil {
-			http.Error(w, "failed to marshal pubkey: "+err.Error(),
-				http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to marshal pubkey: %v", err))
 			return
 		}
 	}
@@ -437,8 +639,7 @@ func (h *form) Form(w http.ResponseWriter, r *http.Request) {
 
 	suff, err := form.Suffragia(h.context, h.orderingSvc.GetStore())
 	if err != nil {
-		http.Error(w, "couldn't get ballots: "+err.Error(),
-			http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("couldn't get ballots: %v", err))
 		return
 	}
 
@@ -452,6 +653,7 @@ func (h *form) Form(w http.ResponseWriter, r *http.Request) {
 		ChunksPerBallot: form.ChunksPerBallot(),
 		BallotSize:      form.BallotSize,
 		Voters:          suff.UserIDs,
+		VoterWeights:    form.VoterWeights,
 	}
 
 	txnmanager.SendResponse(w, response)
@@ -464,43 +666,59 @@ func (h *form) Forms(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Headers", "*")
 
-	elecMD, err := h.getFormsMetadata()
+	query, err := parseFormsQuery(r.URL.Query())
 	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to get form metadata: %v", err), nil)
+		BadRequestError(w, r, ptypes.CodeInvalidQuery, xerrors.Errorf("invalid query: %v", err))
 		return
 	}
 
-	allFormsInfo := make([]ptypes.LightForm, len(elecMD.FormsIDs))
+	formsInfo := make([]ptypes.LightForm, 0)
+	nextCursor := ""
+
+	var summaryErr error
 
 	// get the forms
-	for i, id := range elecMD.FormsIDs {
-		form, err := types.FormFromStore(h.context, h.formFac, id, h.orderingSvc.GetStore())
+	err = h.iterateForms(query.status, query.cursor, func(position uint64, formID string) bool {
+		summary, err := types.FormSummaryFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
 		if err != nil {
-			InternalError(w, r, xerrors.Errorf("failed to get form: %v", err), nil)
-			return
+			summaryErr = err
+			return false
 		}
 
-		var pubkeyBuf []byte
+		if !query.matches(summary) {
+			return true
+		}
 
-		if form.Pubkey != nil {
-			pubkeyBuf, err = form.Pubkey.MarshalBinary()
-			if err != nil {
-				InternalError(w, r, xerrors.Errorf("failed to marshal pubkey: %v", err), nil)
-				return
-			}
+		if query.limit > 0 && len(formsInfo) == query.limit {
+			nextCursor = strconv.FormatUint(position, 10)
+			return false
 		}
 
 		info := ptypes.LightForm{
-			FormID: string(form.FormID),
-			Title:  form.Configuration.Title,
-			Status: uint16(form.Status),
-			Pubkey: hex.EncodeToString(pubkeyBuf),
+			FormID: summary.FormID,
+			Title:  summary.Title,
+			Status: uint16(summary.Status),
+			Pubkey: summary.Pubkey,
 		}
 
-		allFormsInfo[i] = info
+		formsInfo = append(formsInfo, info)
+
+		return true
+	})
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to iterate forms: %v", err))
+		return
+	}
+
+	if summaryErr != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get form: %v", summaryErr))
+		return
 	}
 
-	response := ptypes.GetFormsResponse{Forms: allFormsInfo}
+	response := ptypes.GetFormsResponse{
+		Forms:      formsInfo,
+		NextCursor: nextCursor,
+	}
 
 	txnmanager.SendResponse(w, response)
 
@@ -508,64 +726,289 @@ func (h *form) Forms(w http.ResponseWriter, r *http.Request) {
 
 // DeleteForm implements proxy.Proxy
 func (h *form) DeleteForm(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-
-	// check if the formID is valid
-	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+	formID, ok := h.existingFormID(w, r)
+	if !ok {
 		return
 	}
 
-	formID := vars["formID"]
+	// the legacy requests sign the form ID in the Authorization header, the
+	// others are signed requests whose payload has the replay protection
+	// fields
+	auth := r.Header.Get("Authorization")
+	if auth != "" {
+		err := h.verifyLegacyDelete(formID, auth, r)
+		if err != nil {
+			ForbiddenError(w, r, ptypes.CodeInvalidSignature, err)
+			return
+		}
+	} else {
+		signed, err := ptypes.NewSignedRequest(r.Body)
+		if err != nil {
+			BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
+			return
+		}
+
+		err = h.verifier.Verify(signed, r)
+		if err != nil {
+			signedErr(w, r, fmt.Errorf("%w: %w", ptypes.ErrNotVerified, err))
+			return
+		}
+	}
+
+	deleteForm := types.DeleteForm{
+		FormID: formID,
+	}
 
-	elecMD, err := h.getFormsMetadata()
+	data, err := deleteForm.Serialize(h.context)
 	if err != nil {
-		http.Error(w, "failed to get form metadata", http.StatusNotFound)
+		InternalError(w, r, xerrors.Errorf("failed to marshal DeleteForm: %v", err))
 		return
 	}
 
-	// check if the form exists
-	if elecMD.FormsIDs.Contains(formID) < 0 {
-		http.Error(w, "the form does not exist", http.StatusNotFound)
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdDeleteForm, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
 		return
 	}
 
-	// auth should contain the hex-encoded signature on the hex-encoded form
-	// ID
-	auth := r.Header.Get("Authorization")
+	// send the transaction's information
+	h.mngr.SendSubmission(w, submission)
+}
+
+// verifyLegacyDelete checks the deprecated Authorization token of a form
+// deletion, which is the hex-encoded signature on the form ID by the default
+// key. This token can be replayed, so it is only accepted if the verifier
+// allows the legacy requests.
+func (h *form) verifyLegacyDelete(formID, auth string, r *http.Request) error {
+	if !h.verifier.AllowsLegacy() {
+		return xerrors.New("the deletion must be a signed request, " +
+			"the Authorization header is not accepted")
+	}
+
+	pk, err := h.verifier.Key("")
+	if err != nil {
+		return xerrors.Errorf("invalid key: %v", err)
+	}
 
 	signature, err := hex.DecodeString(auth)
 	if err != nil {
-		BadRequestError(w, r, xerrors.Errorf("failed to decode auth: %v", err), nil)
-		return
+		return xerrors.Errorf("failed to decode auth: %v", err)
 	}
 
-	// check if the signature is valid
-	err = schnorr.Verify(suite, h.pk, []byte(formID), signature)
+	err = schnorr.Verify(suite, pk, []byte(formID), signature)
 	if err != nil {
-		ForbiddenError(w, r, xerrors.Errorf("signature verification failed: %v", err), nil)
-		return
+		return xerrors.Errorf("signature verification failed: %v", err)
 	}
 
-	deleteForm := types.DeleteForm{
-		FormID: formID,
+	h.logger.Warn().Str("path", r.URL.Path).
+		Msg("deprecated form deletion signed in the Authorization header")
+
+	return nil
+}
+
+// formsQuery holds the pagination and filtering parameters of a forms
+// listing.
+type formsQuery struct {
+	status *types.Status
+	admin  string
+	limit  int
+	// cursor is the position in the forms index where the listing starts
+	cursor uint64
+}
+
+// parseFormsQuery parses the "status", "admin", "limit" and "cursor" query
+// parameters. They are all optional.
+func parseFormsQuery(values url.Values) (formsQuery, error) {
+	query := formsQuery{
+		admin: values.Get("admin"),
 	}
 
-	data, err := deleteForm.Serialize(h.context)
+	statusStr := values.Get("status")
+	if statusStr != "" {
+		status, err := strconv.ParseUint(statusStr, 10, 16)
+		if err != nil {
+			return query, xerrors.Errorf("invalid status: %v", err)
+		}
+
+		s := types.Status(status)
+		query.status = &s
+	}
+
+	limitStr := values.Get("limit")
+	if limitStr != "" {
+		limit, err := strconv.ParseUint(limitStr, 10, 31)
+		if err != nil {
+			return query, xerrors.Errorf("invalid limit: %v", err)
+		}
+
+		query.limit = int(limit)
+	}
+
+	cursorStr := values.Get("cursor")
+	if cursorStr != "" {
+		cursor, err := strconv.ParseUint(cursorStr, 10, 64)
+		if err != nil {
+			return query, xerrors.Errorf("invalid cursor: %v", err)
+		}
+
+		query.cursor = cursor
+	}
+
+	return query, nil
+}
+
+// matches returns true if the form passes the filters of the query.
+func (q formsQuery) matches(summary types.FormSummary) bool {
+	if q.status != nil && summary.Status != *q.status {
+		return false
+	}
+
+	return q.admin == "" || summary.AdminID == q.admin
+}
+
+// sendFormCreated sends the ID of a form created by the given transaction,
+// which is the hash of the transaction ID, along with the transaction token.
+func sendFormCreated(w http.ResponseWriter, r *http.Request, mngr txnmanager.Manager,
+	submission txnmanager.Submission) {
+
+	// hash the transaction
+	hash := sha256.New()
+	hash.Write(submission.TransactionID)
+	formID := hash.Sum(nil)
+
+	// create it to get the  token
+	transactionClientInfo, err := mngr.CreateTransactionResult(submission.TransactionID,
+		submission.BlockIdx, submission.Status)
 	if err != nil {
-		InternalError(w, r, xerrors.Errorf("failed to marshal DeleteForm: %v", err), nil)
+		InternalError(w, r, xerrors.Errorf("failed to create transaction info: %v", err))
 		return
 	}
 
-	// create the transaction and add it to the pool
-	txnID, lastBlock, err := h.mngr.SubmitTxn(r.Context(), evoting.CmdDeleteForm, evoting.FormArg, data)
+	response := ptypes.CreateFormResponse{
+		FormID: hex.EncodeToString(formID),
+		Token:  transactionClientInfo.Token,
+	}
+
+	if submission.Status != txnmanager.UnknownTransactionStatus {
+		response.Status = byte(submission.Status)
+		response.BlockIndex = submission.BlockIdx
+		response.Message = submission.Message
+	}
+
+	if submission.Status == txnmanager.RejectedTransaction {
+		code, message := txnmanager.Reason(submission.Message)
+		response.Code = string(code)
+		response.Message = message
+	}
+
+	// send the response json
+	err = txnmanager.SendResponse(w, response)
 	if err != nil {
-		http.Error(w, "failed to submit txn: "+err.Error(), http.StatusInternalServerError)
+		fmt.Printf("Caught unhandled error: %+v", err)
+	}
+}
+
+// submitErr sends the error of a failed submission. An invalid "wait" query
+// parameter is a bad request, and a submission refused by the limit of the
+// pool is a too many requests error.
+func submitErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, txnmanager.ErrInvalidWait) {
+		BadRequestError(w, r, ptypes.CodeInvalidWait, err)
 		return
 	}
 
-	// send the transaction's information
-	h.mngr.SendTransactionInfo(w, txnID, lastBlock, txnmanager.UnknownTransactionStatus)
+	if errors.Is(err, ptypes.ErrTooManyRequests) {
+		TooManyRequestsError(w, r, err)
+		return
+	}
+
+	InternalError(w, r, xerrors.Errorf("failed to submit txn: %v", err))
+}
+
+// existingFormID returns the ID of the form in the path of the request. It
+// sends the error and returns false if the ID is not hex-encoded or if the form
+// doesn't exist.
+func (h *form) existingFormID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	formID := mux.Vars(r)["formID"]
+
+	_, err := hex.DecodeString(formID)
+	if err != nil || formID == "" {
+		BadRequestError(w, r, ptypes.CodeInvalidFormID, xerrors.Errorf("invalid form ID: %q", formID))
+		return "", false
+	}
+
+	exists, err := h.formExists(formID)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get form index: %v", err))
+		return "", false
+	}
+
+	if !exists {
+		NotFoundErr(w, r, ptypes.CodeFormNotFound, xerrors.Errorf("form %s not found", formID))
+		return "", false
+	}
+
+	return formID, true
+}
+
+// formExists returns true if the form is in the forms index, or in the legacy
+// FormsMetadata if the contract didn't migrate it to the index yet.
+func (h *form) formExists(formID string) (bool, error) {
+	rd := h.orderingSvc.GetStore()
+
+	initialized, err := types.IsFormsIndexInitialized(rd)
+	if err != nil {
+		return false, xerrors.Errorf("failed to check index: %v", err)
+	}
+
+	if !initialized {
+		elecMD, err := h.getFormsMetadata()
+		if err != nil {
+			return false, xerrors.Errorf("failed to get form metadata: %v", err)
+		}
+
+		return elecMD.FormsIDs.Contains(formID) >= 0, nil
+	}
+
+	entry, err := types.GetFormIndexEntry(rd, formID)
+	if err != nil {
+		return false, xerrors.Errorf("failed to get index entry: %v", err)
+	}
+
+	return entry != nil, nil
+}
+
+// iterateForms calls fn with the forms of the forms index, starting at the
+// given position. It falls back to the legacy FormsMetadata, where the
+// position is the index in the list, if the contract didn't migrate it to the
+// forms index yet.
+func (h *form) iterateForms(status *types.Status, start uint64,
+	fn func(position uint64, formID string) bool) error {
+
+	rd := h.orderingSvc.GetStore()
+
+	initialized, err := types.IsFormsIndexInitialized(rd)
+	if err != nil {
+		return xerrors.Errorf("failed to check index: %v", err)
+	}
+
+	if initialized {
+		return types.IterateFormsIndex(rd, status, start, fn)
+	}
+
+	elecMD, err := h.getFormsMetadata()
+	if err != nil {
+		return xerrors.Errorf("failed to get form metadata: %v", err)
+	}
+
+	for position := start; position < uint64(len(elecMD.FormsIDs)); position++ {
+		if !fn(position, elecMD.FormsIDs[position]) {
+			return nil
+		}
+	}
+
+	return nil
 }
 
 func (h *form) getFormsMetadata() (types.FormsMetadata, error) {
@@ -0,0 +1,102 @@
+package proxy
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+)
+
+func TestParseFormsQuery(t *testing.T) {
+	query, err := parseFormsQuery(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, formsQuery{}, query)
+
+	values := url.Values{
+		"status": []string{"1"},
+		"admin":  []string{"admin"},
+		"limit":  []string{"10"},
+		"cursor": []string{"3"},
+	}
+
+	query, err = parseFormsQuery(values)
+	require.NoError(t, err)
+	require.Equal(t, types.Open, *query.status)
+	require.Equal(t, "admin", query.admin)
+	require.Equal(t, 10, query.limit)
+	require.Equal(t, uint64(3), query.cursor)
+
+	_, err = parseFormsQuery(url.Values{"status": []string{"open"}})
+	require.EqualError(t, err, "invalid status: strconv.ParseUint: parsing \"open\": invalid syntax")
+
+	_, err = parseFormsQuery(url.Values{"limit": []string{"-1"}})
+	require.EqualError(t, err, "invalid limit: strconv.ParseUint: parsing \"-1\": invalid syntax")
+
+	_, err = parseFormsQuery(url.Values{"cursor": []string{"aa"}})
+	require.EqualError(t, err, "invalid cursor: strconv.ParseUint: parsing \"aa\": invalid syntax")
+}
+
+func TestFormsQuery_Matches(t *testing.T) {
+	summary := types.FormSummary{
+		Status:  types.Open,
+		AdminID: "admin",
+	}
+
+	require.True(t, formsQuery{}.matches(summary))
+
+	status := types.Open
+	require.True(t, formsQuery{status: &status, admin: "admin"}.matches(summary))
+	require.False(t, formsQuery{admin: "other"}.matches(summary))
+
+	status = types.Closed
+	require.False(t, formsQuery{status: &status}.matches(summary))
+}
+
+func TestDecodeBallot(t *testing.T) {
+	k, err := suite.Point().Pick(suite.RandomStream()).MarshalBinary()
+	require.NoError(t, err)
+
+	c, err := suite.Point().Pick(suite.RandomStream()).MarshalBinary()
+	require.NoError(t, err)
+
+	ciphervote, err := decodeBallot(ptypes.CiphervoteJSON{{K: k, C: c}})
+	require.NoError(t, err)
+	require.Len(t, ciphervote, 1)
+
+	buf, err := ciphervote[0].K.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, k, buf)
+
+	_, err = decodeBallot(ptypes.CiphervoteJSON{{K: []byte("bad"), C: c}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unmarshal K")
+
+	_, err = decodeBallot(ptypes.CiphervoteJSON{{K: k, C: []byte("bad")}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to unmarshal C")
+}
+
+func TestEncryptWeight(t *testing.T) {
+	form := types.Form{
+		Pubkey:       suite.Point().Pick(suite.RandomStream()),
+		VoterWeights: map[string]uint32{"alice": 3},
+	}
+
+	weight, err := encryptWeight(form, "alice")
+	require.NoError(t, err)
+	require.NotNil(t, weight)
+	require.NoError(t, weight.Verify(form.Pubkey, 3))
+
+	// the contract rejects the vote of a voter without weight
+	weight, err = encryptWeight(form, "bob")
+	require.NoError(t, err)
+	require.Nil(t, weight)
+
+	form.VoterWeights = nil
+
+	weight, err = encryptWeight(form, "alice")
+	require.NoError(t, err)
+	require.Nil(t, weight)
+}
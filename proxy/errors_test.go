@@ -0,0 +1,549 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	etypes "go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/keyring"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	"go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/store"
+	sjson "go.dedis.ch/dela/serde/json"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/util/random"
+	"golang.org/x/xerrors"
+)
+
+var errorsFormID = hex.EncodeToString([]byte("errors"))
+
+func TestHandlers_Errors(t *testing.T) {
+	secret := suite.Scalar().Pick(random.New())
+	other := suite.Scalar().Pick(random.New())
+
+	unknownFormID := hex.EncodeToString([]byte("unknown"))
+
+	ballot := validBallot(t)
+
+	// the limiter already accepted the only vote of the user for the minute
+	drained := ratelimit.NewLimiter(ratelimit.Config{UserVotes: 1})
+	require.NoError(t, drained.AllowVotes(errorsFormID, "user"))
+
+	testCases := []struct {
+		name      string
+		method    string
+		path      string
+		body      string
+		msg       interface{}
+		signer    kyber.Scalar
+		header    map[string]string
+		verifier  types.Verifier
+		limiter   *ratelimit.Limiter
+		submitErr error
+		status    int
+		code      types.ErrorCode
+	}{
+		{
+			name:   "unknown route",
+			method: http.MethodGet,
+			path:   "/evoting/unknown",
+			status: http.StatusNotFound,
+			code:   types.CodeRouteNotFound,
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodPatch,
+			path:   "/evoting/forms",
+			status: http.StatusMethodNotAllowed,
+			code:   types.CodeMethodNotAllowed,
+		},
+		{
+			name:   "not a signed request",
+			method: http.MethodPost,
+			path:   "/evoting/forms",
+			body:   "abcd",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidRequest,
+		},
+		{
+			name:   "signed by another key",
+			method: http.MethodPost,
+			path:   "/evoting/forms",
+			signer: other,
+			msg:    types.CreateFormRequest{},
+			status: http.StatusForbidden,
+			code:   types.CodeInvalidSignature,
+		},
+		{
+			name:     "too many requests",
+			method:   http.MethodPost,
+			path:     "/evoting/forms",
+			msg:      types.CreateFormRequest{},
+			verifier: busyVerifier{},
+			status:   http.StatusTooManyRequests,
+			code:     types.CodeTooManyRequests,
+		},
+		{
+			name:   "invalid query",
+			method: http.MethodGet,
+			path:   "/evoting/forms?limit=-1",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidQuery,
+		},
+		{
+			name:   "invalid form ID",
+			method: http.MethodGet,
+			path:   "/evoting/forms/zz",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidFormID,
+		},
+		{
+			name:   "form not found",
+			method: http.MethodGet,
+			path:   "/evoting/forms/" + unknownFormID,
+			status: http.StatusNotFound,
+			code:   types.CodeFormNotFound,
+		},
+		{
+			name:   "vote for an unknown form",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + unknownFormID + "/vote",
+			msg:    types.CastVoteRequest{},
+			status: http.StatusNotFound,
+			code:   types.CodeFormNotFound,
+		},
+		{
+			name:   "invalid ballot",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + errorsFormID + "/vote",
+			msg: types.CastVoteRequest{
+				Ballot: types.CiphervoteJSON{{K: []byte("bad")}},
+			},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidBallot,
+		},
+		{
+			name:    "vote over the limit of the user",
+			method:  http.MethodPost,
+			path:    "/evoting/forms/" + errorsFormID + "/vote",
+			msg:     types.CastVoteRequest{UserID: "user", Ballot: ballot},
+			limiter: drained,
+			status:  http.StatusTooManyRequests,
+			code:    types.CodeTooManyRequests,
+		},
+		{
+			name:   "batch over the limit of a user",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + errorsFormID + "/votes:batch",
+			msg: types.CastVotesRequest{
+				Votes: []types.CastVoteRequest{{UserID: "other", Ballot: ballot}, {UserID: "user", Ballot: ballot}},
+			},
+			limiter: drained,
+			status:  http.StatusTooManyRequests,
+			code:    types.CodeTooManyRequests,
+		},
+		{
+			name:   "batch larger than the limit of the form",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + errorsFormID + "/votes:batch",
+			msg: types.CastVotesRequest{
+				Votes: []types.CastVoteRequest{{UserID: "a", Ballot: ballot}, {UserID: "b", Ballot: ballot}},
+			},
+			limiter: ratelimit.NewLimiter(ratelimit.Config{FormVotes: 60, FormBurst: 1}),
+			status:  http.StatusBadRequest,
+			code:    types.CodeInvalidBatch,
+		},
+		{
+			name:      "vote over the limit of the pool",
+			method:    http.MethodPost,
+			path:      "/evoting/forms/" + errorsFormID + "/vote",
+			msg:       types.CastVoteRequest{UserID: "user", Ballot: ballot},
+			submitErr: &ratelimit.Error{Scope: ratelimit.ScopePool, RetryAfter: 1500 * time.Millisecond},
+			status:    http.StatusTooManyRequests,
+			code:      types.CodeTooManyRequests,
+		},
+		{
+			name:   "empty batch",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + errorsFormID + "/votes:batch",
+			msg:    types.CastVotesRequest{},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidBatch,
+		},
+		{
+			name:   "invalid ballot in a batch",
+			method: http.MethodPost,
+			path:   "/evoting/forms/" + errorsFormID + "/votes:batch",
+			msg: types.CastVotesRequest{
+				Votes: []types.CastVoteRequest{{Ballot: types.CiphervoteJSON{{K: []byte("bad")}}}},
+			},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidBallot,
+		},
+		{
+			name:   "invalid action",
+			method: http.MethodPut,
+			path:   "/evoting/forms/" + errorsFormID,
+			msg:    types.UpdateFormRequest{Action: "fake"},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidAction,
+		},
+		{
+			name:   "missing configuration",
+			method: http.MethodPut,
+			path:   "/evoting/forms/" + errorsFormID,
+			msg:    types.UpdateFormRequest{Action: "updateConfiguration"},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidRequest,
+		},
+		{
+			name:   "combine shares of an open form",
+			method: http.MethodPut,
+			path:   "/evoting/forms/" + errorsFormID,
+			msg:    types.UpdateFormRequest{Action: "combineShares"},
+			status: http.StatusConflict,
+			code:   types.CodeInvalidFormStatus,
+		},
+		{
+			name:      "invalid wait",
+			method:    http.MethodPut,
+			path:      "/evoting/forms/" + errorsFormID + "?wait=abc",
+			msg:       types.UpdateFormRequest{Action: "close"},
+			submitErr: fmt.Errorf("%w: abc", txnmanager.ErrInvalidWait),
+			status:    http.StatusBadRequest,
+			code:      types.CodeInvalidWait,
+		},
+		{
+			name:      "failed submission",
+			method:    http.MethodPut,
+			path:      "/evoting/forms/" + errorsFormID,
+			msg:       types.UpdateFormRequest{Action: "close"},
+			submitErr: xerrors.New("oops"),
+			status:    http.StatusInternalServerError,
+			code:      types.CodeInternal,
+		},
+		{
+			name:   "legacy deletion signed by another key",
+			method: http.MethodDelete,
+			path:   "/evoting/forms/" + errorsFormID,
+			header: map[string]string{"Authorization": "aa"},
+			status: http.StatusForbidden,
+			code:   types.CodeInvalidSignature,
+		},
+		{
+			name:   "template not found",
+			method: http.MethodGet,
+			path:   "/evoting/templates/unknown",
+			status: http.StatusNotFound,
+			code:   types.CodeTemplateNotFound,
+		},
+		{
+			name:   "invalid template version",
+			method: http.MethodGet,
+			path:   "/evoting/templates/unknown?version=latest",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidQuery,
+		},
+		{
+			name:   "DKG of an invalid form ID",
+			method: http.MethodPost,
+			path:   "/evoting/services/dkg/actors",
+			msg:    types.NewDKGRequest{FormID: "zz"},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidFormID,
+		},
+		{
+			name:   "DKG of an empty form ID",
+			method: http.MethodPost,
+			path:   "/evoting/services/dkg/actors",
+			msg:    types.NewDKGRequest{},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidFormID,
+		},
+		{
+			name:   "status of an unknown actor",
+			method: http.MethodGet,
+			path:   "/evoting/services/dkg/actors/" + errorsFormID,
+			status: http.StatusNotFound,
+			code:   types.CodeActorNotFound,
+		},
+		{
+			name:   "setup of an unknown actor",
+			method: http.MethodPut,
+			path:   "/evoting/services/dkg/actors/" + errorsFormID,
+			msg:    types.UpdateDKG{Action: "setup"},
+			status: http.StatusNotFound,
+			code:   types.CodeActorNotFound,
+		},
+		{
+			name:   "shuffle of an invalid form ID",
+			method: http.MethodPut,
+			path:   "/evoting/services/shuffle/zz",
+			msg:    types.UpdateShuffle{Action: "shuffle"},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidFormID,
+		},
+		{
+			name:   "invalid shuffle action",
+			method: http.MethodPut,
+			path:   "/evoting/services/shuffle/" + errorsFormID,
+			msg:    types.UpdateShuffle{Action: "fake"},
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidAction,
+		},
+		{
+			name:   "no subscription",
+			method: http.MethodGet,
+			path:   "/evoting/events",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidQuery,
+		},
+		{
+			name:   "subscription to an invalid token",
+			method: http.MethodGet,
+			path:   "/evoting/events?token=unknown",
+			status: http.StatusBadRequest,
+			code:   types.CodeInvalidToken,
+		},
+		{
+			name:   "subscription to an unknown form",
+			method: http.MethodGet,
+			path:   "/evoting/events?form=" + unknownFormID,
+			status: http.StatusNotFound,
+			code:   types.CodeFormNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := tc.verifier
+			if verifier == nil {
+				verifier = types.NewKeyVerifier(suite.Point().Mul(secret, nil))
+			}
+
+			limiter := tc.limiter
+			if limiter == nil {
+				limiter = ratelimit.NewLimiter(ratelimit.Config{})
+			}
+
+			router := newErrorsRouter(t, verifier, fakeTxnManager{submitErr: tc.submitErr}, limiter)
+
+			body := tc.body
+			if tc.msg != nil {
+				signer := tc.signer
+				if signer == nil {
+					signer = secret
+				}
+
+				body = signed(t, signer, tc.method, tc.path, tc.msg)
+			}
+
+			r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(body))
+			for key, value := range tc.header {
+				r.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, r)
+
+			require.Equal(t, tc.status, rec.Code, rec.Body.String())
+			require.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+
+			var httpErr types.HTTPError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+			require.Equal(t, uint(tc.status), httpErr.Code)
+			require.Equal(t, http.StatusText(tc.status), httpErr.Title)
+			require.Equal(t, tc.code, httpErr.Reason)
+			require.Equal(t, tc.method, httpErr.Args["method"])
+
+			if tc.status == http.StatusTooManyRequests {
+				require.NotEmpty(t, rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestHandlers_LegacyRequests(t *testing.T) {
+	secret := suite.Scalar().Pick(random.New())
+
+	pk, err := suite.Point().Mul(secret, nil).MarshalBinary()
+	require.NoError(t, err)
+
+	keys, err := keyring.NewKeyring(fake.NewInMemoryDB())
+	require.NoError(t, err)
+	require.NoError(t, keys.SetDefault(hex.EncodeToString(pk)))
+
+	router := newErrorsRouter(t, keys, fakeTxnManager{}, ratelimit.NewLimiter(ratelimit.Config{}))
+
+	send := func(method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		for key, value := range header {
+			r.Header.Set(key, value)
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, r)
+
+		return rec
+	}
+
+	// a payload without timestamp, nonce, method and path is refused, and so
+	// is its replay
+	body := legacySigned(t, secret, types.CastVoteRequest{UserID: "user", Ballot: validBallot(t)})
+	path := "/evoting/forms/" + errorsFormID + "/vote"
+
+	for i := 0; i < 2; i++ {
+		rec := send(http.MethodPost, path, body, nil)
+		require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		require.Contains(t, rec.Body.String(), string(types.CodeInvalidSignature))
+	}
+
+	// a deletion signed in the Authorization header is refused, even with a
+	// valid signature of the form ID
+	signature, err := schnorr.Sign(suite, secret, []byte(errorsFormID))
+	require.NoError(t, err)
+
+	rec := send(http.MethodDelete, "/evoting/forms/"+errorsFormID, "",
+		map[string]string{"Authorization": hex.EncodeToString(signature)})
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), "the Authorization header is not accepted")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// newErrorsRouter returns a router with the handlers of the proxy, where only
+// the errorsFormID form exists.
+func newErrorsRouter(t *testing.T, verifier types.Verifier, mngr txnmanager.Manager,
+	limiter *ratelimit.Limiter) *mux.Router {
+
+	ctx := sjson.NewContext()
+	roster := authority.FromAuthority(fake.NewAuthority(2, fake.NewSigner))
+	formFac := etypes.NewFormFactory(etypes.CiphervoteFactory{}, fake.NewRosterFac(roster))
+
+	srvc := fake.NewService(errorsFormID, etypes.Form{
+		FormID:        errorsFormID,
+		Configuration: etypes.Configuration{Title: etypes.Title{Text: etypes.LangMap{"en": "errors"}}},
+		Status:        etypes.Open,
+		Pubkey:        suite.Point().Pick(random.New()),
+		BallotSize:    1,
+		Roster:        roster,
+	}, ctx)
+
+	require.NoError(t, etypes.InitFormsIndex(srvc.BallotSnap))
+	require.NoError(t, etypes.AddFormToIndex(srvc.BallotSnap, errorsFormID, etypes.Open))
+
+	srv := &fakeOrdering{Service: &srvc, store: emptyStore{Readable: srvc.GetStore()}}
+
+	ep := NewForm(srv, &fake.Pool{}, ctx, formFac, verifier, mngr, limiter)
+	tp := NewTemplate(srv, ctx, verifier, mngr)
+	evp := NewEvents(srv, ctx, formFac, mngr)
+	dp := NewDKG(nil, mockDKGService{}, verifier, drain.NewTracker(fake.NewInMemoryDB(), 0))
+	sp := NewShuffle(nil, verifier)
+
+	router := mux.NewRouter()
+
+	router.HandleFunc("/evoting/forms", ep.NewForm).Methods(http.MethodPost)
+	router.HandleFunc("/evoting/forms", ep.Forms).Methods(http.MethodGet)
+	router.HandleFunc("/evoting/forms/{formID}", ep.Form).Methods(http.MethodGet)
+	router.HandleFunc("/evoting/forms/{formID}", ep.EditForm).Methods(http.MethodPut)
+	router.HandleFunc("/evoting/forms/{formID}", ep.DeleteForm).Methods(http.MethodDelete)
+	router.HandleFunc("/evoting/forms/{formID}/vote", ep.NewFormVote).Methods(http.MethodPost)
+	router.HandleFunc("/evoting/forms/{formID}/votes:batch", ep.NewFormVotes).Methods(http.MethodPost)
+	router.HandleFunc("/evoting/templates/{templateName}", tp.Template).Methods(http.MethodGet)
+	router.HandleFunc("/evoting/events", evp.Events).Methods(http.MethodGet)
+	router.HandleFunc("/evoting/services/dkg/actors", dp.NewDKGActor).Methods(http.MethodPost)
+	router.HandleFunc("/evoting/services/dkg/actors/{formID}", dp.Actor).Methods(http.MethodGet)
+	router.HandleFunc("/evoting/services/dkg/actors/{formID}", dp.EditDKGActor).Methods(http.MethodPut)
+	router.HandleFunc("/evoting/services/shuffle/{formID}", sp.EditShuffle).Methods(http.MethodPut)
+
+	router.NotFoundHandler = http.HandlerFunc(NotFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(NotAllowedHandler)
+
+	return router
+}
+
+// validBallot returns a ballot that can be decoded.
+func validBallot(t *testing.T) types.CiphervoteJSON {
+	k, err := suite.Point().Pick(random.New()).MarshalBinary()
+	require.NoError(t, err)
+
+	c, err := suite.Point().Pick(random.New()).MarshalBinary()
+	require.NoError(t, err)
+
+	return types.CiphervoteJSON{{K: k, C: c}}
+}
+
+// legacySigned returns a deprecated signed request, whose payload has no
+// replay protection fields.
+func legacySigned(t *testing.T, secret kyber.Scalar, msg interface{}) string {
+	buf, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	payload := base64.URLEncoding.EncodeToString(buf)
+	digest := sha256.Sum256([]byte(payload))
+
+	signature, err := schnorr.Sign(suite, secret, digest[:])
+	require.NoError(t, err)
+
+	signedJSON, err := json.Marshal(types.SignedRequest{
+		Payload:   payload,
+		Signature: hex.EncodeToString(signature),
+	})
+	require.NoError(t, err)
+
+	return string(signedJSON)
+}
+
+// signed returns a signed request for the HTTP method and the path of the URL.
+func signed(t *testing.T, secret kyber.Scalar, method, url string, msg interface{}) string {
+	path := strings.SplitN(url, "?", 2)[0]
+
+	buf, err := createSignedRequest(secret, method, path, msg)
+	require.NoError(t, err)
+
+	return string(buf)
+}
+
+// emptyStore returns no value for the keys that don't exist, as the store of a
+// node does.
+//
+// - implements store.Readable
+type emptyStore struct {
+	store.Readable
+}
+
+func (s emptyStore) Get(key []byte) ([]byte, error) {
+	buf, err := s.Readable.Get(key)
+	if err != nil {
+		return nil, nil
+	}
+
+	return buf, nil
+}
+
+// busyVerifier refuses all the requests because it received too many.
+//
+// - implements types.Verifier
+type busyVerifier struct {
+	types.Verifier
+}
+
+func (busyVerifier) Verify(types.SignedRequest, *http.Request) error {
+	return types.ErrTooManyRequests
+}
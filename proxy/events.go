@@ -0,0 +1,283 @@
+package proxy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+// keepAliveInterval is the interval at which a comment is sent on an idle
+// events stream, so that proxies in between don't close the connection.
+const keepAliveInterval = 15 * time.Second
+
+// maxSubscribedForms and maxSubscribedTokens are the maximum number of forms
+// and of transactions a connection can subscribe to, as each of them is
+// checked at every block.
+const (
+	maxSubscribedForms  = 20
+	maxSubscribedTokens = 100
+)
+
+// NewEvents returns a new initialized events proxy
+func NewEvents(srv ordering.Service, ctx serde.Context, fac serde.Factory,
+	txnManager txnmanager.Manager) Events {
+
+	logger := dela.Logger.With().Timestamp().Str("role", "evoting-events-proxy").Logger()
+
+	return &events{
+		logger:      logger,
+		orderingSvc: srv,
+		context:     ctx,
+		formFac:     fac,
+		mngr:        txnManager,
+	}
+}
+
+// events defines the HTTP handler to stream the updates of forms and
+// transactions
+//
+// - implements proxy.Events
+type events struct {
+	orderingSvc ordering.Service
+	logger      zerolog.Logger
+	context     serde.Context
+	formFac     serde.Factory
+	mngr        txnmanager.Manager
+}
+
+// Events implements proxy.Events. It streams Server-Sent Events until the
+// client closes the connection. The client subscribes to forms with the
+// "form" query parameter, and to transactions with the "token" query
+// parameter. Both can be repeated.
+func (h *events) Events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		InternalError(w, r, xerrors.New("streaming is not supported"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	formIDs := query["form"]
+	tokens := query["token"]
+
+	if len(formIDs) == 0 && len(tokens) == 0 {
+		BadRequestError(w, r, ptypes.CodeInvalidQuery, xerrors.New("no form or token to subscribe to"))
+		return
+	}
+
+	if len(formIDs) > maxSubscribedForms || len(tokens) > maxSubscribedTokens {
+		BadRequestError(w, r, ptypes.CodeInvalidQuery, xerrors.Errorf(
+			"too many subscriptions: at most %d forms and %d tokens",
+			maxSubscribedForms, maxSubscribedTokens))
+		return
+	}
+
+	// the events are watched before looking up the current state so that no
+	// update is missed
+	blocks := h.orderingSvc.Watch(r.Context())
+
+	// maps the hex-encoded transaction IDs to their token
+	pending := make(map[string]string, len(tokens))
+
+	// the transactions already included are sent at once
+	var included []ptypes.TransactionEvent
+
+	for _, token := range tokens {
+		submission, err := h.mngr.LookupToken(token)
+		if err != nil {
+			BadRequestError(w, r, ptypes.CodeInvalidToken, xerrors.Errorf("invalid token: %v", err))
+			return
+		}
+
+		if submission.Status == txnmanager.UnknownTransactionStatus {
+			pending[hex.EncodeToString(submission.TransactionID)] = token
+			continue
+		}
+
+		included = append(included, newTransactionEvent(token, submission))
+	}
+
+	forms := make(map[string]types.FormSummary, len(formIDs))
+
+	for _, formID := range formIDs {
+		summary, err := types.FormSummaryFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
+		if err != nil {
+			NotFoundErr(w, r, ptypes.CodeFormNotFound, xerrors.Errorf("failed to get form: %v", err))
+			return
+		}
+
+		forms[formID] = summary
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range included {
+		err := writeEvent(w, flusher, "transaction", event)
+		if err != nil {
+			h.logger.Warn().Err(err).Msg("failed to send event")
+			return
+		}
+	}
+
+	for _, summary := range forms {
+		err := writeEvent(w, flusher, "form", newFormEvent(summary, 0))
+		if err != nil {
+			h.logger.Warn().Err(err).Msg("failed to send event")
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		case block, ok := <-blocks:
+			if !ok {
+				return
+			}
+
+			err := h.sendBlockEvents(w, flusher, block, pending, forms)
+			if err != nil {
+				h.logger.Warn().Err(err).Msg("failed to send events")
+				return
+			}
+		}
+	}
+}
+
+// sendBlockEvents sends the events of the subscribed transactions included in
+// the block, and of the subscribed forms whose state changed.
+func (h *events) sendBlockEvents(w http.ResponseWriter, flusher http.Flusher,
+	block ordering.Event, pending map[string]string,
+	forms map[string]types.FormSummary) error {
+
+	for _, res := range block.Transactions {
+		txnID := hex.EncodeToString(res.GetTransaction().GetID())
+
+		token, found := pending[txnID]
+		if !found {
+			continue
+		}
+
+		delete(pending, txnID)
+
+		submission := txnmanager.Submission{
+			BlockIdx: block.Index,
+			Status:   txnmanager.IncludedTransaction,
+		}
+
+		accepted, message := res.GetStatus()
+		if !accepted {
+			submission.Status = txnmanager.RejectedTransaction
+			submission.Message = message
+		}
+
+		err := writeEvent(w, flusher, "transaction", newTransactionEvent(token, submission))
+		if err != nil {
+			return xerrors.Errorf("failed to send transaction event: %v", err)
+		}
+	}
+
+	for formID, previous := range forms {
+		summary, err := types.FormSummaryFromStore(h.context, h.formFac, formID, h.orderingSvc.GetStore())
+		if err != nil {
+			// the form may have been deleted
+			h.logger.Warn().Err(err).Str("formID", formID).Msg("failed to get form")
+			continue
+		}
+
+		if summary.Status == previous.Status &&
+			summary.BallotCount == previous.BallotCount &&
+			summary.ShuffleRounds == previous.ShuffleRounds &&
+			summary.PubsharesSubmissions == previous.PubsharesSubmissions {
+			continue
+		}
+
+		forms[formID] = summary
+
+		err = writeEvent(w, flusher, "form", newFormEvent(summary, block.Index))
+		if err != nil {
+			return xerrors.Errorf("failed to send form event: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// newTransactionEvent returns the event of a transaction whose status is
+// known.
+func newTransactionEvent(token string, submission txnmanager.Submission) ptypes.TransactionEvent {
+	event := ptypes.TransactionEvent{
+		Token:      token,
+		BlockIndex: submission.BlockIdx,
+		Status:     byte(submission.Status),
+	}
+
+	if submission.Status == txnmanager.RejectedTransaction {
+		event.Code = string(submission.Code)
+		event.Message = submission.Message
+
+		if event.Code == "" {
+			code, message := txnmanager.Reason(submission.Message)
+			event.Code = string(code)
+			event.Message = message
+		}
+	}
+
+	return event
+}
+
+func newFormEvent(summary types.FormSummary, blockIndex uint64) ptypes.FormEvent {
+	return ptypes.FormEvent{
+		FormID:               summary.FormID,
+		BlockIndex:           blockIndex,
+		Status:               uint16(summary.Status),
+		BallotCount:          summary.BallotCount,
+		ShuffleRounds:        summary.ShuffleRounds,
+		PubsharesSubmissions: summary.PubsharesSubmissions,
+	}
+}
+
+// writeEvent writes a Server-Sent Event with a JSON payload.
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, name string, data interface{}) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal event: %v", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, buf)
+	if err != nil {
+		return xerrors.Errorf("failed to write event: %v", err)
+	}
+
+	flusher.Flush()
+
+	return nil
+}
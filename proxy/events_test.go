@@ -0,0 +1,215 @@
+package proxy
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/validation"
+	sjson "go.dedis.ch/dela/serde/json"
+	"golang.org/x/xerrors"
+)
+
+var eventsFormID = hex.EncodeToString([]byte("form"))
+
+func TestEvents_NoSubscription(t *testing.T) {
+	h := NewEvents(&fakeOrdering{}, sjson.NewContext(), nil, fakeTxnManager{})
+
+	rec := httptest.NewRecorder()
+	h.Events(rec, httptest.NewRequest(http.MethodGet, "/evoting/events", nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestEvents_InvalidToken(t *testing.T) {
+	h := NewEvents(&fakeOrdering{}, sjson.NewContext(), nil, fakeTxnManager{})
+
+	rec := httptest.NewRecorder()
+	h.Events(rec, httptest.NewRequest(http.MethodGet, "/evoting/events?token=unknown", nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "invalid token: unknown token")
+}
+
+func TestEvents_TooManySubscriptions(t *testing.T) {
+	h := NewEvents(&fakeOrdering{}, sjson.NewContext(), nil, fakeTxnManager{})
+
+	target := "/evoting/events?token=token" + strings.Repeat("&token=token", maxSubscribedTokens)
+
+	rec := httptest.NewRecorder()
+	h.Events(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "too many subscriptions")
+}
+
+func TestEvents_Stream(t *testing.T) {
+	summaries := []types.FormSummary{
+		// at subscription
+		{FormID: eventsFormID, Status: types.Open},
+		// after the first block, nothing changed
+		{FormID: eventsFormID, Status: types.Open},
+		// after the second block, a ballot was cast
+		{FormID: eventsFormID, Status: types.Open, BallotCount: 1},
+	}
+
+	blocks := make(chan ordering.Event, 2)
+
+	blocks <- ordering.Event{
+		Index: 1,
+		Transactions: []validation.TransactionResult{
+			fakeTxnResult{tx: fake.Transaction{Id: []byte("other")}, accepted: true},
+			fakeTxnResult{tx: fake.Transaction{Id: []byte("tx1")},
+				message: "[FORM_NOT_OPEN] failed to cast vote: the form is not open, current status: 2"},
+		},
+	}
+
+	blocks <- ordering.Event{
+		Index: 2,
+		Transactions: []validation.TransactionResult{
+			fakeTxnResult{tx: fake.Transaction{Id: []byte("tx2")}, accepted: true},
+		},
+	}
+
+	close(blocks)
+
+	srv := &fakeOrdering{
+		blocks: blocks,
+		store:  &fakeSummaryStore{summaries: summaries},
+	}
+
+	mngr := fakeTxnManager{
+		submissions: map[string]txnmanager.Submission{
+			"token1": {TransactionID: []byte("tx1")},
+			"token2": {TransactionID: []byte("tx2")},
+			// included before the subscription
+			"token3": {TransactionID: []byte("tx3"), BlockIdx: 0, Status: txnmanager.IncludedTransaction},
+		},
+	}
+
+	h := NewEvents(srv, sjson.NewContext(), nil, mngr)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet,
+		"/evoting/events?form="+eventsFormID+"&token=token1&token=token2&token=token3", nil)
+
+	h.Events(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+	expected := []string{
+		`event: transaction`,
+		`data: {"Token":"token3","BlockIndex":0,"Status":1}`,
+		``,
+		`event: form`,
+		`data: {"FormID":"` + eventsFormID + `","BlockIndex":0,"Status":1,"BallotCount":0,` +
+			`"ShuffleRounds":0,"PubsharesSubmissions":0}`,
+		``,
+		`event: transaction`,
+		`data: {"Token":"token1","BlockIndex":1,"Status":2,"Code":"FORM_NOT_OPEN",` +
+			`"Message":"failed to cast vote: the form is not open, current status: 2"}`,
+		``,
+		`event: transaction`,
+		`data: {"Token":"token2","BlockIndex":2,"Status":1}`,
+		``,
+		`event: form`,
+		`data: {"FormID":"` + eventsFormID + `","BlockIndex":2,"Status":1,"BallotCount":1,` +
+			`"ShuffleRounds":0,"PubsharesSubmissions":0}`,
+		``,
+		``,
+	}
+
+	require.Equal(t, strings.Join(expected, "\n"), rec.Body.String())
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// fakeOrdering is an ordering service that streams the given blocks.
+//
+// - implements ordering.Service
+type fakeOrdering struct {
+	ordering.Service
+	blocks chan ordering.Event
+	store  store.Readable
+}
+
+func (f *fakeOrdering) GetStore() store.Readable {
+	return f.store
+}
+
+func (f *fakeOrdering) Watch(ctx context.Context) <-chan ordering.Event {
+	return f.blocks
+}
+
+// fakeSummaryStore returns the next summary each time the summary of a form
+// is read.
+//
+// - implements store.Readable
+type fakeSummaryStore struct {
+	summaries []types.FormSummary
+}
+
+func (s *fakeSummaryStore) Get(key []byte) ([]byte, error) {
+	if len(s.summaries) == 0 {
+		return nil, xerrors.New("no more summary")
+	}
+
+	summary := s.summaries[0]
+	s.summaries = s.summaries[1:]
+
+	return json.Marshal(summary)
+}
+
+// fakeTxnManager maps the tokens to the current status of their transaction.
+//
+// - implements txnmanager.Manager
+type fakeTxnManager struct {
+	txnmanager.Manager
+	submissions map[string]txnmanager.Submission
+	submitErr   error
+}
+
+func (m fakeTxnManager) LookupToken(token string) (txnmanager.Submission, error) {
+	submission, found := m.submissions[token]
+	if !found {
+		return txnmanager.Submission{}, xerrors.New("unknown token")
+	}
+
+	return submission, nil
+}
+
+func (m fakeTxnManager) Submit(*http.Request, evoting.Command, string, []byte) (txnmanager.Submission, error) {
+	return txnmanager.Submission{}, m.submitErr
+}
+
+// fakeTxnResult
+//
+// - implements validation.TransactionResult
+type fakeTxnResult struct {
+	validation.TransactionResult
+	tx       txn.Transaction
+	accepted bool
+	message  string
+}
+
+func (r fakeTxnResult) GetTransaction() txn.Transaction {
+	return r.tx
+}
+
+func (r fakeTxnResult) GetStatus() (bool, string) {
+	return r.accepted, r.message
+}
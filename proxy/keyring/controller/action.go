@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+
+	"go.dedis.ch/d-voting/proxy/keyring"
+	"go.dedis.ch/dela/cli/node"
+	"golang.org/x/xerrors"
+)
+
+// addAction is an action to add a key
+//
+// - implements node.ActionTemplate
+type addAction struct{}
+
+// Execute implements node.ActionTemplate.
+func (a *addAction) Execute(ctx node.Context) error {
+	var keys *keyring.Keyring
+	err := ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
+	_, err = keys.Add(ctx.Flags.String("id"), ctx.Flags.String("key"))
+	if err != nil {
+		return xerrors.Errorf("failed to add key: %v", err)
+	}
+
+	return nil
+}
+
+// revokeAction is an action to revoke a key
+//
+// - implements node.ActionTemplate
+type revokeAction struct{}
+
+// Execute implements node.ActionTemplate.
+func (a *revokeAction) Execute(ctx node.Context) error {
+	var keys *keyring.Keyring
+	err := ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
+	err = keys.Revoke(ctx.Flags.String("id"))
+	if err != nil {
+		return xerrors.Errorf("failed to revoke key: %v", err)
+	}
+
+	return nil
+}
+
+// listAction is an action to list the keys
+//
+// - implements node.ActionTemplate
+type listAction struct{}
+
+// Execute implements node.ActionTemplate. It prints one key per line.
+func (a *listAction) Execute(ctx node.Context) error {
+	var keys *keyring.Keyring
+	err := ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
+	for _, key := range keys.List() {
+		fmt.Fprintf(ctx.Out, "%s %s\n", key.ID, key.PublicKey)
+	}
+
+	return nil
+}
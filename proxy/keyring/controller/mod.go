@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"go.dedis.ch/d-voting/proxy/keyring"
+	"go.dedis.ch/dela/cli"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/store/kv"
+	"golang.org/x/xerrors"
+)
+
+// NewController returns a new controller initializer
+func NewController() node.Initializer {
+	return controller{}
+}
+
+// controller is an initializer with a set of commands.
+//
+// - implements node.Initializer
+type controller struct{}
+
+// SetCommands implements node.Initializer.
+func (m controller) SetCommands(builder node.Builder) {
+	builder.SetStartFlags(
+		cli.BoolFlag{
+			Name: "legacyrequests",
+			Usage: "accept the deprecated requests that can be replayed: the " +
+				"payloads without timestamp, nonce, method and path, and the " +
+				"form deletions signed in the Authorization header",
+		},
+	)
+
+	cmd := builder.SetCommand("proxykeys")
+	cmd.SetDescription("manage the keys accepted to sign the proxy requests")
+
+	// dvoting --config /tmp/node1 proxykeys add --id backend-2 --key <hex>
+	sub := cmd.SetSubCommand("add")
+	sub.SetDescription("add a key")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "id",
+			Usage:    "the ID of the key, set in the KeyID of the signed requests",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "key",
+			Usage:    "the public key, hex encoded",
+			Required: true,
+		},
+	)
+	sub.SetAction(builder.MakeAction(&addAction{}))
+
+	// dvoting --config /tmp/node1 proxykeys revoke --id backend-1
+	sub = cmd.SetSubCommand("revoke")
+	sub.SetDescription("revoke a key")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "id",
+			Usage:    "the ID of the key",
+			Required: true,
+		},
+	)
+	sub.SetAction(builder.MakeAction(&revokeAction{}))
+
+	// dvoting --config /tmp/node1 proxykeys list
+	sub = cmd.SetSubCommand("list")
+	sub.SetDescription("list the keys")
+	sub.SetAction(builder.MakeAction(&listAction{}))
+}
+
+// OnStart implements node.Initializer. It creates the keyring with the keys
+// saved in the database. The legacy requests are refused unless the
+// legacyrequests flag is set.
+func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
+	var db kv.DB
+	err := inj.Resolve(&db)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve db: %v", err)
+	}
+
+	keys, err := keyring.NewKeyring(db)
+	if err != nil {
+		return xerrors.Errorf("failed to create keyring: %v", err)
+	}
+
+	keys.AllowLegacy(ctx.Bool("legacyrequests"))
+
+	inj.Inject(keys)
+
+	return nil
+}
+
+// OnStop implements node.Initializer.
+func (controller) OnStop(node.Injector) error {
+	return nil
+}
@@ -0,0 +1,360 @@
+// Package keyring implements the set of public keys accepted by the proxy to
+// verify the requests signed by the web backends. The keys are saved in the
+// database of the node, so that a key can be added or revoked without
+// restarting the node, for example to rotate the key of a web backend.
+package keyring
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/store/kv"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/suites"
+	"golang.org/x/xerrors"
+)
+
+var suite = suites.MustFind("ed25519")
+
+// BucketName is the name of the bucket in the database.
+const BucketName = "proxykeys"
+
+// DefaultMaxAge is the default maximum difference between the timestamp of a
+// request and the time of the node.
+const DefaultMaxAge = 5 * time.Minute
+
+// Key is a public key of the keyring
+type Key struct {
+	ID string
+	// PublicKey is hex-encoded
+	PublicKey string
+}
+
+// Keyring holds the keys accepted to sign the requests. The default key, set
+// with the proxykey flag, verifies the requests without key ID.
+//
+// - implements ptypes.Verifier
+type Keyring struct {
+	sync.Mutex
+
+	db         kv.DB
+	keys       map[string]Key
+	defaultKey kyber.Point
+
+	// maxAge is the maximum difference between the timestamp of a request
+	// and the time of the node
+	maxAge time.Duration
+	// nonces holds the nonces of the requests that can still be accepted
+	nonces *nonceCache
+	// legacy is true if the deprecated requests, which can be replayed, are
+	// accepted
+	legacy bool
+	// started is the time at which the keyring was created. The nonces are
+	// only kept in memory, so the requests signed before are refused, as
+	// their nonce may have been used before the node restarted.
+	started time.Time
+
+	// now is used to get the time, it is replaced in the tests
+	now func() time.Time
+}
+
+// NewKeyring returns a new keyring with the keys saved in the database.
+func NewKeyring(db kv.DB) (*Keyring, error) {
+	k := &Keyring{
+		db:      db,
+		keys:    make(map[string]Key),
+		maxAge:  DefaultMaxAge,
+		nonces:  newNonceCache(DefaultMaxAge, DefaultMaxNonces),
+		started: time.Now(),
+		now:     time.Now,
+	}
+
+	err := db.View(func(tx kv.ReadableTx) error {
+		bucket := tx.GetBucket([]byte(BucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(_, value []byte) error {
+			var key Key
+
+			err := json.Unmarshal(value, &key)
+			if err != nil {
+				return xerrors.Errorf("failed to unmarshal key: %v", err)
+			}
+
+			k.keys[key.ID] = key
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read keys: %v", err)
+	}
+
+	return k, nil
+}
+
+// SetDefault sets the hex-encoded key verifying the requests without key ID.
+// The default key is not changed if the given key is empty.
+func (k *Keyring) SetDefault(publicKey string) error {
+	if publicKey == "" {
+		return nil
+	}
+
+	pk, err := decodeKey(publicKey)
+	if err != nil {
+		return xerrors.Errorf("invalid key: %v", err)
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	k.defaultKey = pk
+
+	return nil
+}
+
+// Add saves a new key with the given ID.
+func (k *Keyring) Add(id string, publicKey string) (Key, error) {
+	if id == "" {
+		return Key{}, xerrors.Errorf("the key must have an ID")
+	}
+
+	_, err := decodeKey(publicKey)
+	if err != nil {
+		return Key{}, xerrors.Errorf("invalid key: %v", err)
+	}
+
+	key := Key{
+		ID:        id,
+		PublicKey: publicKey,
+	}
+
+	buf, err := json.Marshal(key)
+	if err != nil {
+		return Key{}, xerrors.Errorf("failed to marshal key: %v", err)
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	_, found := k.keys[id]
+	if found {
+		return Key{}, xerrors.Errorf("key %q already exists", id)
+	}
+
+	err = k.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(BucketName))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Set([]byte(id), buf)
+	})
+	if err != nil {
+		return Key{}, xerrors.Errorf("failed to save key: %v", err)
+	}
+
+	k.keys[id] = key
+
+	return key, nil
+}
+
+// Revoke deletes a key. The requests signed by this key are rejected from now
+// on.
+func (k *Keyring) Revoke(id string) error {
+	k.Lock()
+	defer k.Unlock()
+
+	_, found := k.keys[id]
+	if !found {
+		return xerrors.Errorf("key %q not found", id)
+	}
+
+	err := k.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(BucketName))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to delete key: %v", err)
+	}
+
+	delete(k.keys, id)
+
+	return nil
+}
+
+// List returns the keys sorted by ID. It doesn't include the default key.
+func (k *Keyring) List() []Key {
+	k.Lock()
+	defer k.Unlock()
+
+	keys := make([]Key, 0, len(k.keys))
+	for _, key := range k.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+// Key implements ptypes.Verifier. The empty ID is the default key.
+func (k *Keyring) Key(id string) (kyber.Point, error) {
+	k.Lock()
+	defer k.Unlock()
+
+	if id == "" {
+		if k.defaultKey == nil {
+			return nil, xerrors.Errorf("no default key")
+		}
+
+		return k.defaultKey, nil
+	}
+
+	key, found := k.keys[id]
+	if !found {
+		return nil, xerrors.Errorf("unknown key %q", id)
+	}
+
+	return decodeKey(key.PublicKey)
+}
+
+// AllowLegacy sets whether the deprecated requests are accepted: the payloads
+// signed by the default key without timestamp, nonce, method and path, and the
+// form deletions signed in the Authorization header. They can be replayed, so
+// they are refused by default.
+func (k *Keyring) AllowLegacy(allow bool) {
+	k.Lock()
+	defer k.Unlock()
+
+	k.legacy = allow
+}
+
+// AllowsLegacy implements ptypes.Verifier.
+func (k *Keyring) AllowsLegacy() bool {
+	k.Lock()
+	defer k.Unlock()
+
+	return k.legacy
+}
+
+// Verify implements ptypes.Verifier. The payload of the request must have a
+// timestamp, a nonce, and the HTTP method and path of r. The request is
+// rejected if it is stale or replayed. The legacy requests signed by the
+// default key without any of these fields are only accepted if they are
+// allowed.
+func (k *Keyring) Verify(req ptypes.SignedRequest, r *http.Request) error {
+	pk, err := k.Key(req.KeyID)
+	if err != nil {
+		return xerrors.Errorf("invalid key: %v", err)
+	}
+
+	err = req.Verify(pk)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := req.GetMetadata()
+	if err != nil {
+		return err
+	}
+
+	if metadata.IsLegacy() {
+		if req.KeyID != "" || !k.AllowsLegacy() {
+			return xerrors.Errorf("the request must have a timestamp, a nonce, " +
+				"a method and a path")
+		}
+
+		dela.Logger.Warn().Str("method", r.Method).Str("path", r.URL.Path).
+			Msg("deprecated signed request without timestamp, nonce, method and path")
+
+		return nil
+	}
+
+	if metadata.Method == "" || metadata.Path == "" {
+		return xerrors.Errorf("the request must have a method and a path")
+	}
+
+	err = metadata.Match(r)
+	if err != nil {
+		return err
+	}
+
+	return k.checkReplay(pk, metadata)
+}
+
+// checkReplay returns an error if the timestamp of the request is too far
+// from the time of the node or before its start, or if its nonce was already
+// used with the key. The nonces are bound to the public key rather than to
+// the ID of the key, which is not signed and may be rewritten when the key is
+// known under several IDs.
+func (k *Keyring) checkReplay(pk kyber.Point, metadata ptypes.RequestMetadata) error {
+	if metadata.Timestamp == 0 || metadata.Nonce == "" {
+		return xerrors.Errorf("the request must have a timestamp and a nonce")
+	}
+
+	buf, err := pk.MarshalBinary()
+	if err != nil {
+		return xerrors.Errorf("failed to marshal key: %v", err)
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	now := k.now()
+	timestamp := time.Unix(metadata.Timestamp, 0)
+
+	if now.Sub(timestamp) > k.maxAge || timestamp.Sub(now) > k.maxAge {
+		return xerrors.Errorf("stale request: timestamp %d is more than %s away",
+			metadata.Timestamp, k.maxAge)
+	}
+
+	if metadata.Timestamp < k.started.Unix() {
+		return xerrors.Errorf("stale request: timestamp %d is before the start "+
+			"of the node", metadata.Timestamp)
+	}
+
+	nonce := hex.EncodeToString(buf) + ":" + metadata.Nonce
+
+	if k.nonces.has(nonce, now) {
+		return xerrors.Errorf("replayed request: nonce %q already used", metadata.Nonce)
+	}
+
+	err = k.nonces.add(nonce, now)
+	if err != nil {
+		return xerrors.Errorf("failed to save nonce: %w", err)
+	}
+
+	return nil
+}
+
+// decodeKey returns the point of a hex-encoded public key.
+func decodeKey(publicKey string) (kyber.Point, error) {
+	buf, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to decode key: %v", err)
+	}
+
+	pk := suite.Point()
+
+	err = pk.UnmarshalBinary(buf)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal key: %v", err)
+	}
+
+	return pk, nil
+}
@@ -0,0 +1,303 @@
+package keyring
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+func TestKeyring_AddRevokeList(t *testing.T) {
+	db := fake.NewInMemoryDB()
+
+	k, err := NewKeyring(db)
+	require.NoError(t, err)
+
+	_, pk1 := newKeyPair(t)
+	_, pk2 := newKeyPair(t)
+
+	_, err = k.Add("", pk1)
+	require.EqualError(t, err, "the key must have an ID")
+
+	_, err = k.Add("backend-1", "xx")
+	require.EqualError(t, err, "invalid key: failed to decode key: "+
+		"encoding/hex: invalid byte: U+0078 'x'")
+
+	key1, err := k.Add("backend-1", pk1)
+	require.NoError(t, err)
+
+	_, err = k.Add("backend-1", pk2)
+	require.EqualError(t, err, `key "backend-1" already exists`)
+
+	key2, err := k.Add("backend-0", pk2)
+	require.NoError(t, err)
+
+	require.Equal(t, []Key{key2, key1}, k.List())
+
+	// the keys are loaded from the database
+	k, err = NewKeyring(db)
+	require.NoError(t, err)
+	require.Equal(t, []Key{key2, key1}, k.List())
+
+	err = k.Revoke("backend-0")
+	require.NoError(t, err)
+
+	err = k.Revoke("backend-0")
+	require.EqualError(t, err, `key "backend-0" not found`)
+
+	_, err = k.Key("backend-0")
+	require.EqualError(t, err, `unknown key "backend-0"`)
+
+	k, err = NewKeyring(db)
+	require.NoError(t, err)
+	require.Equal(t, []Key{key1}, k.List())
+
+	_, err = NewKeyring(fake.NewBadViewDB())
+	require.Error(t, err)
+}
+
+func TestKeyring_VerifyDefaultKey(t *testing.T) {
+	k, err := NewKeyring(fake.NewInMemoryDB())
+	require.NoError(t, err)
+
+	secret, pk := newKeyPair(t)
+
+	r := httptest.NewRequest(http.MethodPut, "/evoting/forms/aa", nil)
+
+	req := sign(t, secret, "", `{"Foo":"bar"}`)
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, "invalid key: no default key")
+
+	err = k.SetDefault(pk)
+	require.NoError(t, err)
+
+	// the legacy requests without replay protection are refused, so they
+	// can't be replayed
+	err = k.Verify(req, r)
+	require.EqualError(t, err, "the request must have a timestamp, a nonce, a method and a path")
+
+	// unless they are allowed
+	k.AllowLegacy(true)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+
+	k.AllowLegacy(false)
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, "the request must have a timestamp, a nonce, a method and a path")
+
+	req = sign(t, secret, "", `{"Foo":"bar","Timestamp":`+now(0)+`,"Nonce":"n1"}`)
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, "the request must have a method and a path")
+
+	req = sign(t, secret, "", `{"Foo":"bar",`+metadata(0, "n1", "PUT", "/evoting/forms/aa")+`}`)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, `replayed request: nonce "n1" already used`)
+}
+
+func TestKeyring_VerifyKey(t *testing.T) {
+	k, err := NewKeyring(fake.NewInMemoryDB())
+	require.NoError(t, err)
+
+	secret, pk := newKeyPair(t)
+	other, _ := newKeyPair(t)
+
+	_, err = k.Add("backend", pk)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPut, "/evoting/forms/aa", nil)
+
+	err = k.Verify(sign(t, secret, "unknown", `{}`), r)
+	require.EqualError(t, err, `invalid key: unknown key "unknown"`)
+
+	err = k.Verify(sign(t, other, "backend", `{}`), r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid signature")
+
+	err = k.Verify(sign(t, secret, "backend", `{}`), r)
+	require.EqualError(t, err, "the request must have a timestamp, a nonce, a method and a path")
+
+	// the legacy requests are only accepted with the default key
+	k.AllowLegacy(true)
+
+	err = k.Verify(sign(t, secret, "backend", `{}`), r)
+	require.EqualError(t, err, "the request must have a timestamp, a nonce, a method and a path")
+
+	k.AllowLegacy(false)
+
+	err = k.Verify(sign(t, secret, "backend", `{"Method":"PUT","Path":"/evoting/forms/aa"}`), r)
+	require.EqualError(t, err, "the request must have a timestamp and a nonce")
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(0, "n1", "DELETE", "/evoting/forms/aa")+`}`), r)
+	require.EqualError(t, err, `the request was signed for method "DELETE", not "PUT"`)
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(0, "n1", "PUT", "/evoting/forms/bb")+`}`), r)
+	require.EqualError(t, err, `the request was signed for path "/evoting/forms/bb", `+
+		`not "/evoting/forms/aa"`)
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(-10*time.Minute, "n1", "PUT", "/evoting/forms/aa")+`}`), r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stale request")
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(10*time.Minute, "n1", "PUT", "/evoting/forms/aa")+`}`), r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stale request")
+
+	req := sign(t, secret, "backend", `{`+metadata(0, "n1", "PUT", "/evoting/forms/aa")+`}`)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, `replayed request: nonce "n1" already used`)
+
+	// the nonce is forgotten once no request with this nonce can be accepted
+	k.now = func() time.Time { return time.Now().Add(2*DefaultMaxAge + time.Minute) }
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(2*DefaultMaxAge+time.Minute,
+		"n2", "PUT", "/evoting/forms/aa")+`}`), r)
+	require.NoError(t, err)
+	require.Len(t, k.nonces.nonces, 1)
+
+	err = k.Revoke("backend")
+	require.NoError(t, err)
+
+	err = k.Verify(sign(t, secret, "backend", `{`+metadata(0, "n3", "PUT", "/evoting/forms/aa")+`}`), r)
+	require.EqualError(t, err, `invalid key: unknown key "backend"`)
+}
+
+func TestKeyring_VerifyReplayUnderOtherID(t *testing.T) {
+	k, err := NewKeyring(fake.NewInMemoryDB())
+	require.NoError(t, err)
+
+	secret, pk := newKeyPair(t)
+
+	// during a rotation, the same key is the default one and in the keyring
+	err = k.SetDefault(pk)
+	require.NoError(t, err)
+
+	_, err = k.Add("backend", pk)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPut, "/evoting/forms/aa", nil)
+	req := sign(t, secret, "backend", `{`+metadata(0, "n1", "PUT", "/evoting/forms/aa")+`}`)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+
+	// the ID of the key is not signed, so it can be rewritten
+	req.KeyID = ""
+
+	err = k.Verify(req, r)
+	require.EqualError(t, err, `replayed request: nonce "n1" already used`)
+}
+
+func TestKeyring_VerifyAfterRestart(t *testing.T) {
+	db := fake.NewInMemoryDB()
+
+	k, err := NewKeyring(db)
+	require.NoError(t, err)
+
+	// the keyring of the node before the restart
+	k.started = time.Now().Add(-time.Hour)
+
+	secret, pk := newKeyPair(t)
+
+	_, err = k.Add("backend", pk)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPut, "/evoting/forms/aa", nil)
+	req := sign(t, secret, "backend", `{`+metadata(-time.Minute, "n1", "PUT", "/evoting/forms/aa")+`}`)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+
+	// the nonces are lost by the restart, but the request is refused as it
+	// was signed before
+	k, err = NewKeyring(db)
+	require.NoError(t, err)
+
+	err = k.Verify(req, r)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is before the start of the node")
+
+	req = sign(t, secret, "backend", `{`+metadata(time.Minute, "n1", "PUT", "/evoting/forms/aa")+`}`)
+
+	err = k.Verify(req, r)
+	require.NoError(t, err)
+}
+
+func TestNonceCache_Bounded(t *testing.T) {
+	cache := newNonceCache(time.Minute, 2)
+
+	now := time.Now()
+
+	require.NoError(t, cache.add("n1", now))
+	require.NoError(t, cache.add("n2", now.Add(time.Minute)))
+
+	require.True(t, cache.has("n1", now))
+
+	// the nonces can't be evicted before they expire
+	err := cache.add("n3", now.Add(time.Minute))
+	require.EqualError(t, err, "too many requests: 2 nonces in the last 2m0s")
+
+	require.False(t, cache.has("n1", now.Add(3*time.Minute)))
+	require.True(t, cache.has("n2", now.Add(3*time.Minute)))
+
+	require.NoError(t, cache.add("n3", now.Add(3*time.Minute)))
+	require.Len(t, cache.queue, 2)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func newKeyPair(t *testing.T) (kyber.Scalar, string) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+
+	buf, err := suite.Point().Mul(secret, nil).MarshalBinary()
+	require.NoError(t, err)
+
+	return secret, hex.EncodeToString(buf)
+}
+
+func sign(t *testing.T, secret kyber.Scalar, keyID string, msg string) ptypes.SignedRequest {
+	payload := base64.URLEncoding.EncodeToString([]byte(msg))
+
+	hash := sha256.Sum256([]byte(payload))
+
+	signature, err := schnorr.Sign(suite, secret, hash[:])
+	require.NoError(t, err)
+
+	return ptypes.SignedRequest{
+		Payload:   payload,
+		Signature: hex.EncodeToString(signature),
+		KeyID:     keyID,
+	}
+}
+
+func now(offset time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(offset).Unix(), 10)
+}
+
+func metadata(offset time.Duration, nonce, method, path string) string {
+	return fmt.Sprintf(`"Timestamp":%s,"Nonce":%q,"Method":%q,"Path":%q`,
+		now(offset), nonce, method, path)
+}
@@ -0,0 +1,81 @@
+package keyring
+
+import (
+	"fmt"
+	"time"
+
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+)
+
+// DefaultMaxNonces is the default maximum number of nonces kept by the cache,
+// so that its memory is bounded whatever the load is.
+const DefaultMaxNonces = 100000
+
+// nonceItem is an item of the eviction queue of the cache
+type nonceItem struct {
+	nonce string
+	seen  time.Time
+}
+
+// nonceCache remembers the nonces of the requests that can still be accepted.
+// A request is stale once its timestamp is more than maxAge away from the
+// time of the node, and its timestamp is at most maxAge ahead of the time at
+// which it was seen, so a nonce is evicted 2*maxAge after it was seen. The
+// nonces are evicted in the order they were seen, which is also the order of
+// their expiration. The lock of the keyring must be held to use the cache.
+type nonceCache struct {
+	maxAge  time.Duration
+	maxSize int
+	nonces  map[string]struct{}
+	queue   []nonceItem
+}
+
+// newNonceCache returns a new empty cache.
+func newNonceCache(maxAge time.Duration, maxSize int) *nonceCache {
+	return &nonceCache{
+		maxAge:  maxAge,
+		maxSize: maxSize,
+		nonces:  make(map[string]struct{}),
+	}
+}
+
+// has returns true if the nonce was seen by a request that can still be
+// accepted.
+func (c *nonceCache) has(nonce string, now time.Time) bool {
+	c.prune(now)
+
+	_, found := c.nonces[nonce]
+
+	return found
+}
+
+// add saves a nonce seen at the given time. It returns an error if the cache
+// is full of nonces that can't be evicted yet, because evicting them would
+// make the replay of their request possible.
+func (c *nonceCache) add(nonce string, now time.Time) error {
+	c.prune(now)
+
+	if len(c.queue) >= c.maxSize {
+		return fmt.Errorf("%w: %d nonces in the last %s", ptypes.ErrTooManyRequests,
+			len(c.queue), 2*c.maxAge)
+	}
+
+	c.nonces[nonce] = struct{}{}
+	c.queue = append(c.queue, nonceItem{nonce: nonce, seen: now})
+
+	return nil
+}
+
+// prune evicts the nonces whose request is stale.
+func (c *nonceCache) prune(now time.Time) {
+	for len(c.queue) > 0 {
+		item := c.queue[0]
+
+		if now.Sub(item.seen) <= 2*c.maxAge {
+			return
+		}
+
+		delete(c.nonces, item.nonce)
+		c.queue = c.queue[1:]
+	}
+}
@@ -3,16 +3,22 @@
 // Function names follow the convention used in URL helpers on rails:
 // https://guides.rubyonrails.org/routing.html#path-and-url-helpers
 //
-// For the API specification look at /docs/api.md.
+// For the API specification look at /docs/api.md. The OpenAPI document of the
+// routes is served at OpenAPIPath, see Operations.
 package proxy
 
 import (
-	"encoding/json"
-	"fmt"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
 	"go.dedis.ch/d-voting/proxy/types"
 	"go.dedis.ch/kyber/v3/suites"
+	"golang.org/x/xerrors"
 )
 
 var suite = suites.MustFind("ed25519")
@@ -23,6 +29,10 @@ type Form interface {
 	NewForm(http.ResponseWriter, *http.Request)
 	// POST /forms/{formID}/vote
 	NewFormVote(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/votes:batch
+	NewFormVotes(http.ResponseWriter, *http.Request)
+	// POST /forms/{formID}/clone
+	CloneForm(http.ResponseWriter, *http.Request)
 	// PUT /forms/{formID}
 	EditForm(http.ResponseWriter, *http.Request)
 	// GET /forms
@@ -33,6 +43,25 @@ type Form interface {
 	DeleteForm(http.ResponseWriter, *http.Request)
 }
 
+// Template defines the public HTTP API to manage form templates
+type Template interface {
+	// POST /templates
+	NewTemplate(http.ResponseWriter, *http.Request)
+	// GET /templates
+	Templates(http.ResponseWriter, *http.Request)
+	// GET /templates/{templateName}
+	Template(http.ResponseWriter, *http.Request)
+	// POST /templates/{templateName}/forms
+	NewTemplateForm(http.ResponseWriter, *http.Request)
+}
+
+// Events defines the public HTTP API to stream the updates of forms and
+// transactions
+type Events interface {
+	// GET /events
+	Events(http.ResponseWriter, *http.Request)
+}
+
 // DKG defines the public HTTP API of the DKG service
 type DKG interface {
 	// POST /services/dkg
@@ -51,86 +80,79 @@ type Shuffle interface {
 
 // NotFoundHandler defines a generic handler for 404
 func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
-	err := types.HTTPError{
-		Title:   "Not found",
-		Code:    http.StatusNotFound,
-		Message: "The requested endpoint was not found",
-		Args: map[string]interface{}{
-			"url":    r.URL.String(),
-			"method": r.Method,
-		},
-	}
-
-	buf, _ := json.MarshalIndent(&err, "", "  ")
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusNotFound)
-	fmt.Fprintln(w, string(buf))
+	NotFoundErr(w, r, types.CodeRouteNotFound,
+		xerrors.New("the requested endpoint was not found"))
 }
 
-// NotAllowedHandler degines a generic handler for 405
+// NotAllowedHandler defines a generic handler for 405
 func NotAllowedHandler(w http.ResponseWriter, r *http.Request) {
-	err := types.HTTPError{
-		Title:   "Not allowed",
-		Code:    http.StatusMethodNotAllowed,
-		Message: "The requested endpoint was not allowed",
-		Args: map[string]interface{}{
-			"url":    r.URL.String(),
-			"method": r.Method,
-		},
-	}
-
-	buf, _ := json.MarshalIndent(&err, "", "  ")
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	fmt.Fprintln(w, string(buf))
+	httpErr(w, r, http.StatusMethodNotAllowed, types.CodeMethodNotAllowed,
+		xerrors.Errorf("the method %s is not allowed", r.Method))
 }
 
 // InternalError sets an internal server error
-func InternalError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusInternalServerError, "Internal server error", args)
+func InternalError(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr(w, r, http.StatusInternalServerError, types.CodeInternal, err)
 }
 
-// BadRequestError sets an bad request error
-func BadRequestError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusBadRequest, "bad request", args)
+// BadRequestError sets a bad request error
+func BadRequestError(w http.ResponseWriter, r *http.Request, code types.ErrorCode, err error) {
+	httpErr(w, r, http.StatusBadRequest, code, err)
 }
 
-// ForbiddenError sets a forbidden error error
-func ForbiddenError(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusForbidden, "not authorized / forbidden", args)
+// ForbiddenError sets a forbidden error
+func ForbiddenError(w http.ResponseWriter, r *http.Request, code types.ErrorCode, err error) {
+	httpErr(w, r, http.StatusForbidden, code, err)
 }
 
 // NotFoundErr sets a not found error
-func NotFoundErr(w http.ResponseWriter, r *http.Request, err error, args map[string]interface{}) {
-	httpErr(w, r, err, http.StatusNotFound, "not found", args)
+func NotFoundErr(w http.ResponseWriter, r *http.Request, code types.ErrorCode, err error) {
+	httpErr(w, r, http.StatusNotFound, code, err)
 }
 
-func httpErr(w http.ResponseWriter, r *http.Request, err error, code uint, title string, args map[string]interface{}) {
-	if args == nil {
-		args = make(map[string]interface{})
-	}
+// ConflictError sets a conflict error, when the state of a resource doesn't
+// allow the request
+func ConflictError(w http.ResponseWriter, r *http.Request, code types.ErrorCode, err error) {
+	httpErr(w, r, http.StatusConflict, code, err)
+}
 
-	args["error"] = err.Error()
-	args["url"] = r.URL.String()
-	args["method"] = r.Method
+// TooManyRequestsError sets a too many requests error. The Retry-After header
+// tells the delay of the limit that refused the request, or one second.
+func TooManyRequestsError(w http.ResponseWriter, r *http.Request, err error) {
+	retryAfter := time.Second
 
-	errMsg := types.HTTPError{
-		Title:   title,
-		Code:    code,
-		Message: "A problem occurred on the proxy",
-		Args:    args,
+	var limitErr *ratelimit.Error
+	if errors.As(err, &limitErr) && limitErr.RetryAfter > retryAfter {
+		retryAfter = limitErr.RetryAfter
 	}
 
-	buf, _ := json.MarshalIndent(&errMsg, "", "  ")
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	httpErr(w, r, http.StatusTooManyRequests, types.CodeTooManyRequests, err)
+}
+
+// UnavailableError sets a service unavailable error, for a request refused
+// because the node is stopping
+func UnavailableError(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("Retry-After", drain.RetryAfter)
+
+	httpErr(w, r, http.StatusServiceUnavailable, types.CodeNodeStopping, err)
+}
+
+// signedErr sets the error of a signed request that can't be accepted
+func signedErr(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, types.ErrTooManyRequests):
+		TooManyRequestsError(w, r, getSignedErr(err))
+	case errors.Is(err, types.ErrNotVerified):
+		ForbiddenError(w, r, types.CodeInvalidSignature, getSignedErr(err))
+	default:
+		BadRequestError(w, r, types.CodeInvalidRequest, getSignedErr(err))
+	}
+}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(int(code))
-	fmt.Fprintln(w, string(buf))
+func httpErr(w http.ResponseWriter, r *http.Request, status int, code types.ErrorCode, err error) {
+	types.NewHTTPError(r, status, code, err).Write(w)
 }
 
 // AllowCORS defines a basic handler that adds wide Access Control Allow origin
@@ -0,0 +1,590 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	etypes "go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	"go.dedis.ch/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+// OpenAPIPath is the path at which the OpenAPI document of the proxy is served
+const OpenAPIPath = "/evoting/openapi.json"
+
+// OpenAPIVersion is the version of the API described by the OpenAPI document
+const OpenAPIVersion = "1.0.0"
+
+// Parameter describes a query parameter of an operation.
+type Parameter struct {
+	Name        string
+	Description string
+}
+
+// Operation describes a route of the proxy in the OpenAPI document.
+type Operation struct {
+	Method  string
+	Path    string
+	Summary string
+	// Signed is true if the body of the request is a signed request whose
+	// payload is the JSON of Request, as described in docs/msg_sig.md
+	Signed bool
+	// Request is a value of the type of the body of the request, nil if there
+	// is none
+	Request interface{}
+	// Response is a value of the type of the body of the response, nil if the
+	// response is empty
+	Response interface{}
+	// Stream is true if the response is a stream of server-sent events, in
+	// which case Response is a list of the types of the events
+	Stream bool
+	Query  []Parameter
+}
+
+var waitParameter = Parameter{
+	Name:        "wait",
+	Description: "maximum duration to wait for the transaction to be included, such as 10s",
+}
+
+// Operations are the routes of the proxy, registered by the evoting, DKG and
+// shuffle controllers. A route that is registered must have an operation,
+// see MissingOperations.
+var Operations = []Operation{
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/forms",
+		Summary:  "Create a form",
+		Signed:   true,
+		Request:  types.CreateFormRequest{},
+		Response: types.CreateFormResponse{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/forms",
+		Summary:  "List the forms",
+		Response: types.GetFormsResponse{},
+		Query: []Parameter{
+			{Name: "status", Description: "only the forms with this status"},
+			{Name: "admin", Description: "only the forms created by this admin ID"},
+			{Name: "limit", Description: "maximum number of forms in the page"},
+			{Name: "cursor", Description: "NextCursor of the previous page"},
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/forms/{formID}",
+		Summary:  "Get a form",
+		Response: types.GetFormResponse{},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/evoting/forms/{formID}",
+		Summary:  "Open, close, cancel, decrypt or update a form",
+		Signed:   true,
+		Request:  types.UpdateFormRequest{},
+		Response: txnmanager.TransactionClientInfo{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodDelete,
+		Path:     "/evoting/forms/{formID}",
+		Summary:  "Delete a form",
+		Signed:   true,
+		Request:  types.RequestMetadata{},
+		Response: txnmanager.TransactionClientInfo{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/forms/{formID}/vote",
+		Summary:  "Cast a vote",
+		Signed:   true,
+		Request:  types.CastVoteRequest{},
+		Response: txnmanager.TransactionClientInfo{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/forms/{formID}/votes:batch",
+		Summary:  "Cast a batch of votes in a single transaction",
+		Signed:   true,
+		Request:  types.CastVotesRequest{},
+		Response: txnmanager.TransactionClientInfo{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/forms/{formID}/clone",
+		Summary:  "Create a form with the configuration of a form",
+		Signed:   true,
+		Request:  types.CloneFormRequest{},
+		Response: types.CreateFormResponse{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/templates",
+		Summary:  "Save a new version of a template",
+		Signed:   true,
+		Request:  types.CreateTemplateRequest{},
+		Response: txnmanager.TransactionClientInfo{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/templates",
+		Summary:  "List the templates",
+		Response: types.GetTemplatesResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/templates/{templateName}",
+		Summary:  "Get a version of a template",
+		Response: types.GetTemplateResponse{},
+		Query: []Parameter{
+			{Name: "version", Description: "version of the template, the latest one by default"},
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/evoting/templates/{templateName}/forms",
+		Summary:  "Create a form from a template",
+		Signed:   true,
+		Request:  types.CreateTemplateFormRequest{},
+		Response: types.CreateFormResponse{},
+		Query:    []Parameter{waitParameter},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/transactions/{token}",
+		Summary:  "Get the status of a transaction",
+		Response: txnmanager.TransactionClientInfo{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/events",
+		Summary:  "Stream the updates of forms and transactions",
+		Response: []interface{}{types.FormEvent{}, types.TransactionEvent{}},
+		Stream:   true,
+		Query: []Parameter{
+			{Name: "form", Description: "hex-encoded ID of a form to subscribe to, can be repeated"},
+			{Name: "token", Description: "token of a transaction to subscribe to, can be repeated"},
+		},
+	},
+	{
+		Method:  http.MethodGet,
+		Path:    OpenAPIPath,
+		Summary: "Get the OpenAPI document of the proxy",
+	},
+	{
+		Method:  http.MethodPost,
+		Path:    "/evoting/services/dkg/actors",
+		Summary: "Start the DKG actor of a form",
+		Signed:  true,
+		Request: types.NewDKGRequest{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/evoting/services/dkg/actors/{formID}",
+		Summary:  "Get the status of the DKG actor of a form",
+		Response: types.GetActorInfo{},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/evoting/services/dkg/actors/{formID}",
+		Summary: "Set up the DKG of a form, or compute the public shares",
+		Signed:  true,
+		Request: types.UpdateDKG{},
+	},
+	{
+		Method:  http.MethodPut,
+		Path:    "/evoting/services/shuffle/{formID}",
+		Summary: "Shuffle the ballots of a form",
+		Signed:  true,
+		Request: types.UpdateShuffle{},
+	},
+}
+
+// MissingOperations returns the routes of the router that have no operation,
+// as "<method> <path>". The OPTIONS routes, which only answer CORS preflight
+// requests, are ignored.
+func MissingOperations(router *mux.Router) ([]string, error) {
+	known := make(map[string]bool, len(Operations))
+	for _, op := range Operations {
+		known[op.Method+" "+op.Path] = true
+	}
+
+	var missing []string
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return xerrors.Errorf("failed to get path: %v", err)
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return xerrors.Errorf("failed to get methods of %s: %v", path, err)
+		}
+
+		for _, method := range methods {
+			if method != http.MethodOptions && !known[method+" "+path] {
+				missing = append(missing, method+" "+path)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to walk router: %v", err)
+	}
+
+	return missing, nil
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPIBuf  []byte
+	openAPIErr  error
+)
+
+// OpenAPI serves the OpenAPI document of the proxy.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPIBuf, openAPIErr = json.MarshalIndent(NewOpenAPI(), "", "  ")
+	})
+
+	if openAPIErr != nil {
+		InternalError(w, r, openAPIErr)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIBuf)
+}
+
+// NewOpenAPI returns the OpenAPI 3 document describing the operations, with
+// the schemas derived from the Go types of the requests and responses.
+func NewOpenAPI() map[string]interface{} {
+	schemas := newSchemas()
+	paths := map[string]map[string]interface{}{}
+
+	for _, op := range Operations {
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]interface{}{}
+		}
+
+		paths[op.Path][strings.ToLower(op.Method)] = schemas.operation(op)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "D-Voting proxy API",
+			"version": OpenAPIVersion,
+			"description": "HTTP API of the proxy of a D-Voting node. The requests that " +
+				"change the state are signed, as described in docs/msg_sig.md.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.defs,
+		},
+	}
+}
+
+var pathParam = regexp.MustCompile(`{([^}]+)}`)
+
+// schemas derives the JSON schemas of Go types, and keeps the definitions of
+// the named struct types to reference them.
+type schemas struct {
+	defs  map[string]interface{}
+	types map[string]reflect.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		defs:  map[string]interface{}{},
+		types: map[string]reflect.Type{},
+	}
+}
+
+func (s *schemas) operation(op Operation) map[string]interface{} {
+	var params []interface{}
+
+	for _, match := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		params = append(params, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+
+	for _, query := range op.Query {
+		params = append(params, map[string]interface{}{
+			"name":        query.Name,
+			"in":          "query",
+			"description": query.Description,
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	}
+
+	res := map[string]interface{}{
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+		"responses":   s.responses(op),
+	}
+
+	if len(params) > 0 {
+		res["parameters"] = params
+	}
+
+	if op.Request != nil {
+		schema := s.of(reflect.TypeOf(op.Request))
+
+		if op.Signed {
+			signed := s.of(reflect.TypeOf(types.SignedRequest{}))
+
+			schema = map[string]interface{}{
+				"allOf": []interface{}{signed},
+				"description": "signed request whose payload is the base64url-encoded " +
+					"JSON of the fields of x-payload and of RequestMetadata",
+				"x-payload": schema,
+			}
+		}
+
+		res["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schema},
+			},
+		}
+	}
+
+	return res
+}
+
+func (s *schemas) responses(op Operation) map[string]interface{} {
+	ok := map[string]interface{}{"description": "OK"}
+
+	switch {
+	case op.Stream:
+		var events []interface{}
+		for _, event := range op.Response.([]interface{}) {
+			events = append(events, s.of(reflect.TypeOf(event)))
+		}
+
+		ok["content"] = map[string]interface{}{
+			"text/event-stream": map[string]interface{}{
+				"schema": map[string]interface{}{
+					"type":        "string",
+					"description": "server-sent events whose data is one of x-events",
+					"x-events":    events,
+				},
+			},
+		}
+	case op.Path == OpenAPIPath:
+		ok["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"type": "object"},
+			},
+		}
+	case op.Response != nil:
+		ok["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": s.of(reflect.TypeOf(op.Response)),
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"200": ok,
+		"default": map[string]interface{}{
+			"description": "error",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": s.of(reflect.TypeOf(types.HTTPError{})),
+				},
+			},
+		},
+	}
+}
+
+var (
+	langMapType = reflect.TypeOf(etypes.LangMap{})
+	titleType   = reflect.TypeOf(etypes.Title{})
+	hintType    = reflect.TypeOf(etypes.Hint{})
+	bytesType   = reflect.TypeOf([]byte{})
+)
+
+// of returns the schema of the type, or a reference to it for a named struct.
+func (s *schemas) of(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		schema := s.of(t.Elem())
+
+		// the siblings of a reference are ignored
+		if _, isRef := schema["$ref"]; isRef {
+			schema = map[string]interface{}{"allOf": []interface{}{schema}}
+		}
+
+		schema["nullable"] = true
+
+		return schema
+	}
+
+	// the types with a custom JSON encoding
+	switch t {
+	case langMapType:
+		return map[string]interface{}{
+			"description": "translations by language, or a language-neutral text",
+			"oneOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": map[string]interface{}{"type": "string"},
+				},
+			},
+		}
+	case titleType:
+		return map[string]interface{}{
+			"type":                 "object",
+			"description":          "translations by language, and the URL key",
+			"additionalProperties": map[string]interface{}{"type": "string"},
+		}
+	case hintType:
+		return map[string]interface{}{
+			"type":                 "object",
+			"description":          "translations by language",
+			"additionalProperties": map[string]interface{}{"type": "string"},
+		}
+	case bytesType:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+
+		return map[string]interface{}{"type": "array", "items": s.of(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": s.of(t.Elem()),
+		}
+	case reflect.Struct:
+		return s.ref(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// ref returns a reference to the definition of the struct type, which is
+// added to the definitions the first time.
+func (s *schemas) ref(t reflect.Type) map[string]interface{} {
+	name := t.Name()
+
+	// the name is prefixed by the package if another type has the same name
+	if other, found := s.types[name]; found && other != t {
+		name = strings.ReplaceAll(t.String(), ".", "")
+	}
+
+	if _, found := s.types[name]; !found {
+		s.types[name] = t
+		// the definition is set before its fields, so that the recursive
+		// types reference it
+		s.defs[name] = nil
+		s.defs[name] = s.object(t)
+	}
+
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// object returns the schema of the fields of the struct, as encoded by
+// encoding/json.
+func (s *schemas) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	s.fields(t, properties, &required)
+
+	sort.Strings(required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func (s *schemas) fields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// the fields of an embedded struct are encoded as the fields of the
+		// struct
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.fields(field.Type, properties, required)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.of(field.Type)
+
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// operationID returns a unique identifier of the operation, such as
+// "put_evoting_forms_formID".
+func operationID(op Operation) string {
+	replacer := strings.NewReplacer("/", "_", "{", "", "}", "", ":", "_", ".", "_")
+
+	return fmt.Sprintf("%s%s", strings.ToLower(op.Method), replacer.Replace(op.Path))
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+var regexpRef = regexp.MustCompile(`"#/components/schemas/([^"]+)"`)
+
+func TestMissingOperations(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/evoting/forms/{formID}", AllowCORS).Methods(http.MethodGet, http.MethodPatch)
+	router.HandleFunc("/evoting/forms/{formID}", AllowCORS).Methods(http.MethodOptions)
+	router.HandleFunc("/evoting/unknown", AllowCORS).Methods(http.MethodGet)
+
+	missing, err := MissingOperations(router)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"PATCH /evoting/forms/{formID}",
+		"GET /evoting/unknown",
+	}, missing)
+}
+
+func TestNewOpenAPI(t *testing.T) {
+	buf, err := json.Marshal(NewOpenAPI())
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI string
+		Paths   map[string]map[string]struct {
+			OperationID string
+			Parameters  []struct {
+				Name string
+				In   string
+			}
+		}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{}
+				Required   []string
+			}
+		}
+	}
+
+	require.NoError(t, json.Unmarshal(buf, &doc))
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+
+	// every operation is in the document with a unique ID, and declares the
+	// parameters of its path
+	ids := map[string]bool{}
+
+	for _, op := range Operations {
+		entry, found := doc.Paths[op.Path][strings.ToLower(op.Method)]
+		require.True(t, found, "%s %s", op.Method, op.Path)
+
+		require.False(t, ids[entry.OperationID], entry.OperationID)
+		ids[entry.OperationID] = true
+
+		for _, match := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+			require.Contains(t, entry.Parameters, struct {
+				Name string
+				In   string
+			}{match[1], "path"})
+		}
+	}
+
+	// every reference has a definition
+	for _, ref := range regexpRef.FindAllStringSubmatch(string(buf), -1) {
+		require.Contains(t, doc.Components.Schemas, ref[1])
+	}
+
+	request := doc.Components.Schemas["CreateFormRequest"]
+	require.Contains(t, request.Properties, "AdminID")
+	require.Contains(t, request.Properties, "Configuration")
+	require.Equal(t, []string{"AdminID", "Configuration"}, request.Required)
+
+	// the fields of an embedded struct are flattened, and the omitted empty
+	// fields are optional
+	template := doc.Components.Schemas["GetTemplateResponse"]
+	require.Contains(t, template.Properties, "Version")
+
+	response := doc.Components.Schemas["CreateFormResponse"]
+	require.Contains(t, response.Properties, "BlockIndex")
+	require.Equal(t, []string{"FormID", "Token"}, response.Required)
+
+	require.Contains(t, doc.Components.Schemas, "HTTPError")
+}
+
+func TestOpenAPI(t *testing.T) {
+	rec := httptest.NewRecorder()
+	OpenAPI(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Contains(t, doc, "paths")
+}
@@ -0,0 +1,339 @@
+// Package ratelimit limits the rate at which the proxy accepts votes and
+// submits transactions to the pool, so that a misbehaving client, or a leaked
+// key, can't flood the pool and delay the blocks of every form.
+//
+// The limits are token buckets refilled at a rate given per minute. The bucket
+// of a user, and of the pool, holds at most a second of its rate, or one
+// token, so that the requests can't be accepted in bursts. The bucket of a
+// form holds a configurable burst, by default the size of the largest batch
+// of votes. A batch of votes must fit in the buckets, so a larger batch is
+// refused.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dvoting "go.dedis.ch/d-voting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// ScopeForm is the scope of the limit of the votes cast in a form
+	ScopeForm = "form"
+	// ScopeUser is the scope of the limit of the votes cast by a user in a
+	// form
+	ScopeUser = "user"
+	// ScopePool is the scope of the limit of the transactions submitted to
+	// the pool
+	ScopePool = "pool"
+)
+
+// pruneInterval is the interval at which the full buckets are forgotten, so
+// that the memory used by the limiter is bounded by the active forms and users.
+const pruneInterval = time.Minute
+
+// PromThrottled counts the requests refused by a limit, by scope.
+var PromThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dvoting_proxy_throttled_total",
+	Help: "number of requests refused by the rate limits of the proxy",
+},
+	[]string{"scope"},
+)
+
+func init() {
+	dvoting.PromCollectors = append(dvoting.PromCollectors, PromThrottled)
+}
+
+// ErrBatchTooLarge is returned when a batch of votes is larger than what a
+// limit accepts at once, so that it would never be accepted.
+var ErrBatchTooLarge = xerrors.New("batch too large")
+
+// admittedKey is the key of the context value marking a request whose
+// transaction was already counted by the pool limit.
+type admittedKey struct{}
+
+// WithAdmission returns a copy of the context of a request whose votes were
+// accepted by AllowVotes, so that its transaction is not counted twice by
+// AllowTransaction.
+func WithAdmission(ctx context.Context) context.Context {
+	return context.WithValue(ctx, admittedKey{}, true)
+}
+
+// Config defines the limits of the proxy. Each limit is a number of events per
+// minute, zero means no limit.
+type Config struct {
+	// FormVotes is the limit of the votes cast in a form
+	FormVotes int
+	// UserVotes is the limit of the votes cast by a user in a form
+	UserVotes int
+	// Pool is the limit of the transactions submitted to the pool, all forms
+	// included
+	Pool int
+	// FormBurst is the number of votes a form accepts at once. Zero means
+	// types.MaxVotesPerBatch, so that a batch of the maximum size fits. It is
+	// at least a second of the limit of the form.
+	FormBurst int
+}
+
+// Error is the error of a request refused by a limit. It wraps
+// ptypes.ErrTooManyRequests.
+type Error struct {
+	// Scope is the scope of the limit that refused the request
+	Scope string
+	// RetryAfter is the delay after which the request can be accepted
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s limit reached, retry in %s", e.Scope, e.RetryAfter)
+}
+
+// Unwrap returns ptypes.ErrTooManyRequests
+func (e *Error) Unwrap() error {
+	return ptypes.ErrTooManyRequests
+}
+
+// Limiter enforces the limits of a configuration. The zero configuration
+// accepts everything.
+type Limiter struct {
+	sync.Mutex
+
+	formLimit limit
+	userLimit limit
+	poolLimit limit
+
+	now    func() time.Time
+	pool   *bucket
+	forms  map[string]*bucket
+	users  map[string]*bucket
+	pruned time.Time
+}
+
+// NewLimiter returns a new limiter for the given configuration.
+func NewLimiter(config Config) *Limiter {
+	formBurst := config.FormBurst
+	if formBurst <= 0 {
+		formBurst = types.MaxVotesPerBatch
+	}
+
+	return &Limiter{
+		formLimit: newLimit(config.FormVotes, formBurst),
+		userLimit: newLimit(config.UserVotes, 0),
+		poolLimit: newLimit(config.Pool, 0),
+		now:       time.Now,
+		forms:     make(map[string]*bucket),
+		users:     make(map[string]*bucket),
+	}
+}
+
+// AllowVotes returns an *Error if the votes of the users can't be cast in the
+// form now, because of the limit of the form, of one of the users, or of the
+// pool. A user can appear several times. All the limits are checked before
+// the votes and their transaction are counted, and only if they are accepted.
+// It returns ErrBatchTooLarge if the votes would never be accepted at once.
+func (l *Limiter) AllowVotes(formID string, userIDs ...string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	counts := make(map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		counts[formID+"/"+userID]++
+	}
+
+	var form *bucket
+	if l.formLimit.enabled() {
+		form = getBucket(l.forms, formID, l.formLimit, now)
+
+		err := form.check(ScopeForm, l.formLimit, len(userIDs), now)
+		if err != nil {
+			return err
+		}
+	}
+
+	users := make(map[string]*bucket, len(counts))
+	if l.userLimit.enabled() {
+		for key := range counts {
+			user := getBucket(l.users, key, l.userLimit, now)
+
+			err := user.check(ScopeUser, l.userLimit, counts[key], now)
+			if err != nil {
+				return err
+			}
+
+			users[key] = user
+		}
+	}
+
+	var pool *bucket
+	if l.poolLimit.enabled() {
+		pool = l.getPool(now)
+
+		err := pool.check(ScopePool, l.poolLimit, 1, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	if form != nil {
+		form.take(len(userIDs))
+	}
+
+	for key, user := range users {
+		user.take(counts[key])
+	}
+
+	if pool != nil {
+		pool.take(1)
+	}
+
+	return nil
+}
+
+// AllowTransaction returns an *Error if a transaction can't be submitted to
+// the pool now. The transaction is counted if it is accepted. The
+// transactions of the requests marked by WithAdmission are already counted.
+func (l *Limiter) AllowTransaction(ctx context.Context) error {
+	if !l.poolLimit.enabled() || ctx.Value(admittedKey{}) != nil {
+		return nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	pool := l.getPool(now)
+
+	err := pool.check(ScopePool, l.poolLimit, 1, now)
+	if err != nil {
+		return err
+	}
+
+	pool.take(1)
+
+	return nil
+}
+
+// getPool returns the bucket of the pool limit. The lock must be held.
+func (l *Limiter) getPool(now time.Time) *bucket {
+	if l.pool == nil {
+		l.pool = newBucket(l.poolLimit, now)
+	}
+
+	return l.pool
+}
+
+// prune forgets the buckets that are full, which are the same as new ones.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < pruneInterval {
+		return
+	}
+
+	l.pruned = now
+
+	pruneBuckets(l.forms, l.formLimit, now)
+	pruneBuckets(l.users, l.userLimit, now)
+}
+
+// limit is a number of tokens refilled per minute, and the maximum number of
+// tokens of its buckets.
+type limit struct {
+	perMinute int
+	burst     float64
+}
+
+// newLimit returns a limit whose buckets hold the given number of tokens, and
+// at least a second of its rate, or one token.
+func newLimit(perMinute int, burst int) limit {
+	l := limit{perMinute: perMinute}
+	l.burst = math.Max(float64(burst), math.Max(1, l.rate()))
+
+	return l
+}
+
+func (l limit) enabled() bool {
+	return l.perMinute > 0
+}
+
+// rate returns the number of tokens refilled per second.
+func (l limit) rate() float64 {
+	return float64(l.perMinute) / 60
+}
+
+// bucket is a token bucket. The tokens are refilled lazily when the bucket is
+// checked.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l limit, now time.Time) *bucket {
+	return &bucket{tokens: l.burst, last: now}
+}
+
+func getBucket(buckets map[string]*bucket, key string, l limit, now time.Time) *bucket {
+	b, found := buckets[key]
+	if !found {
+		b = newBucket(l, now)
+		buckets[key] = b
+	}
+
+	return b
+}
+
+func pruneBuckets(buckets map[string]*bucket, l limit, now time.Time) {
+	for key, b := range buckets {
+		b.refill(l, now)
+
+		if b.tokens >= l.burst {
+			delete(buckets, key)
+		}
+	}
+}
+
+// check refills the bucket and returns an *Error if it has less than n
+// tokens. It returns ErrBatchTooLarge if the bucket can't hold n tokens.
+func (b *bucket) check(scope string, l limit, n int, now time.Time) error {
+	if float64(n) > l.burst {
+		return xerrors.Errorf("the %s limit accepts at most %d votes at once, got %d: %w",
+			scope, int(l.burst), n, ErrBatchTooLarge)
+	}
+
+	b.refill(l, now)
+
+	if b.tokens >= float64(n) {
+		return nil
+	}
+
+	PromThrottled.WithLabelValues(scope).Inc()
+
+	// the delay after which the tokens are available
+	missing := float64(n) - b.tokens
+	retryAfter := time.Duration(math.Ceil(missing / l.rate() * float64(time.Second)))
+
+	return &Error{Scope: scope, RetryAfter: retryAfter}
+}
+
+func (b *bucket) take(n int) {
+	b.tokens -= float64(n)
+}
+
+func (b *bucket) refill(l limit, now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate())
+	b.last = now
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+)
+
+func TestLimiter_NoLimit(t *testing.T) {
+	l := NewLimiter(Config{})
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, l.AllowVotes("form", "user"))
+		require.NoError(t, l.AllowTransaction(context.Background()))
+	}
+}
+
+func TestLimiter_UserVotes(t *testing.T) {
+	l, now := newLimiter(Config{UserVotes: 6})
+
+	throttled := testutil.ToFloat64(PromThrottled.WithLabelValues(ScopeUser))
+
+	require.NoError(t, l.AllowVotes("form", "user"))
+
+	err := l.AllowVotes("form", "user")
+	require.EqualError(t, err, "user limit reached, retry in 10s")
+	require.True(t, errors.Is(err, ptypes.ErrTooManyRequests))
+	require.Equal(t, throttled+1, testutil.ToFloat64(PromThrottled.WithLabelValues(ScopeUser)))
+
+	// the other users and the other forms are not limited
+	require.NoError(t, l.AllowVotes("form", "other"))
+	require.NoError(t, l.AllowVotes("other", "user"))
+
+	*now = now.Add(5 * time.Second)
+
+	var limitErr *Error
+	require.True(t, errors.As(l.AllowVotes("form", "user"), &limitErr))
+	require.Equal(t, ScopeUser, limitErr.Scope)
+	require.Equal(t, 5*time.Second, limitErr.RetryAfter)
+
+	*now = now.Add(5 * time.Second)
+	require.NoError(t, l.AllowVotes("form", "user"))
+}
+
+func TestLimiter_FormVotes(t *testing.T) {
+	// the bucket of the form holds a second of its limit
+	l, now := newLimiter(Config{FormVotes: 120, FormBurst: 1})
+
+	require.NoError(t, l.AllowVotes("form", "a", "b"))
+
+	err := l.AllowVotes("form", "c")
+	require.EqualError(t, err, "form limit reached, retry in 500ms")
+
+	*now = now.Add(500 * time.Millisecond)
+	require.NoError(t, l.AllowVotes("form", "c"))
+}
+
+func TestLimiter_MaxBatch(t *testing.T) {
+	l, now := newLimiter(Config{FormVotes: 600})
+
+	// the bucket of a form holds a batch of the maximum size by default
+	userIDs := make([]string, types.MaxVotesPerBatch)
+	for i := range userIDs {
+		userIDs[i] = strconv.Itoa(i)
+	}
+
+	require.NoError(t, l.AllowVotes("form", userIDs...))
+
+	err := l.AllowVotes("form", "user")
+	require.EqualError(t, err, "form limit reached, retry in 100ms")
+
+	// the batch is accepted again once the bucket is full
+	*now = now.Add(99 * time.Second)
+	require.Error(t, l.AllowVotes("form", userIDs...))
+
+	*now = now.Add(time.Second)
+	require.NoError(t, l.AllowVotes("form", userIDs...))
+
+	// unless the burst is lower
+	l, _ = newLimiter(Config{FormVotes: 600, FormBurst: 100})
+	require.True(t, errors.Is(l.AllowVotes("form", userIDs...), ErrBatchTooLarge))
+}
+
+func TestLimiter_BatchTooLarge(t *testing.T) {
+	l, _ := newLimiter(Config{FormVotes: 120, FormBurst: 1})
+
+	// the bucket of the form holds 2 votes, so the batch can't be accepted
+	// even when it is full
+	err := l.AllowVotes("form", "a", "b", "c")
+	require.EqualError(t, err, "the form limit accepts at most 2 votes at once, got 3: batch too large")
+	require.True(t, errors.Is(err, ErrBatchTooLarge))
+	require.False(t, errors.Is(err, ptypes.ErrTooManyRequests))
+
+	// the votes were not counted
+	require.NoError(t, l.AllowVotes("form", "a", "b"))
+
+	// a user can't appear more times than its limit accepts at once
+	l, _ = newLimiter(Config{UserVotes: 60})
+	require.True(t, errors.Is(l.AllowVotes("form", "user", "user"), ErrBatchTooLarge))
+}
+
+func TestLimiter_RefusedVotesAreNotCounted(t *testing.T) {
+	l, _ := newLimiter(Config{FormVotes: 120, FormBurst: 1, UserVotes: 60})
+
+	require.NoError(t, l.AllowVotes("form", "user"))
+
+	// the batch is refused because of the user, so the form is not charged
+	// and can still accept a vote
+	require.Error(t, l.AllowVotes("form", "new", "user"))
+	require.NoError(t, l.AllowVotes("form", "new"))
+	require.Error(t, l.AllowVotes("form", "other"))
+}
+
+func TestLimiter_VotesCountTransaction(t *testing.T) {
+	l, _ := newLimiter(Config{FormVotes: 60, FormBurst: 1, Pool: 60})
+
+	require.NoError(t, l.AllowVotes("form", "user"))
+
+	// the transaction of the votes was counted
+	require.Error(t, l.AllowTransaction(context.Background()))
+	require.NoError(t, l.AllowTransaction(WithAdmission(context.Background())))
+
+	// the votes are refused because of the pool, so the form is not charged
+	var limitErr *Error
+	require.True(t, errors.As(l.AllowVotes("other", "user"), &limitErr))
+	require.Equal(t, ScopePool, limitErr.Scope)
+	require.Equal(t, 1.0, l.forms["other"].tokens)
+}
+
+func TestLimiter_Pool(t *testing.T) {
+	l, now := newLimiter(Config{Pool: 60})
+
+	require.NoError(t, l.AllowTransaction(context.Background()))
+
+	var limitErr *Error
+	require.True(t, errors.As(l.AllowTransaction(context.Background()), &limitErr))
+	require.Equal(t, ScopePool, limitErr.Scope)
+	require.Equal(t, time.Second, limitErr.RetryAfter)
+
+	*now = now.Add(time.Second)
+	require.NoError(t, l.AllowTransaction(context.Background()))
+}
+
+func TestLimiter_Prune(t *testing.T) {
+	l, now := newLimiter(Config{FormVotes: 60, UserVotes: 60})
+
+	require.NoError(t, l.AllowVotes("form", "user"))
+	require.Len(t, l.forms, 1)
+	require.Len(t, l.users, 1)
+
+	*now = now.Add(pruneInterval)
+
+	require.NoError(t, l.AllowVotes("other", "user"))
+	require.Len(t, l.forms, 1)
+	require.Len(t, l.users, 1)
+	require.Contains(t, l.forms, "other")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// newLimiter returns a limiter whose time only changes when the returned time
+// is updated.
+func newLimiter(config Config) (*Limiter, *time.Time) {
+	now := time.Unix(1000, 0)
+
+	l := NewLimiter(config)
+	l.now = func() time.Time { return now }
+
+	return l, &now
+}
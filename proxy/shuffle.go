@@ -2,21 +2,19 @@ package proxy
 
 import (
 	"encoding/hex"
-	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"go.dedis.ch/d-voting/proxy/types"
 	shuffleSrv "go.dedis.ch/d-voting/services/shuffle"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
 // NewShuffle returns a new initialized shuffle
-func NewShuffle(actor shuffleSrv.Actor, pk kyber.Point) Shuffle {
+func NewShuffle(actor shuffleSrv.Actor, verifier types.Verifier) Shuffle {
 	return shuffle{
-		actor: actor,
-		pk:    pk,
+		actor:    actor,
+		verifier: verifier,
 	}
 }
 
@@ -26,8 +24,8 @@ func NewShuffle(actor shuffleSrv.Actor, pk kyber.Point) Shuffle {
 type shuffle struct {
 	// actor is the shuffle actor
 	actor shuffleSrv.Actor
-	// pk is the public key of the proxy
-	pk kyber.Point
+	// verifier verifies the signed requests
+	verifier types.Verifier
 }
 
 // EditShuffle implements proxy.Shuffle
@@ -37,14 +35,14 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 	// Read the request
 	signed, err := types.NewSignedRequest(r.Body)
 	if err != nil {
-		InternalError(w, r, newSignedErr(err), nil)
+		BadRequestError(w, r, types.CodeInvalidRequest, newSignedErr(err))
 		return
 	}
 
 	// Verify the signature and get the request
-	err = signed.GetAndVerify(s.pk, &req)
+	err = signed.GetAndVerify(s.verifier, r, &req)
 	if err != nil {
-		InternalError(w, r, getSignedErr(err), nil)
+		signedErr(w, r, err)
 		return
 	}
 
@@ -52,7 +50,7 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 
 	// check if the formID is present
 	if vars == nil || vars["formID"] == "" {
-		http.Error(w, fmt.Sprintf("formID not found: %v", vars), http.StatusInternalServerError)
+		InternalError(w, r, xerrors.Errorf("formID not found: %v", vars))
 		return
 	}
 
@@ -60,7 +58,7 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 
 	formIDBuf, err := hex.DecodeString(formID)
 	if err != nil {
-		http.Error(w, "failed to decode formID: "+formID, http.StatusInternalServerError)
+		BadRequestError(w, r, types.CodeInvalidFormID, xerrors.Errorf("failed to decode formID: %v", err))
 		return
 	}
 
@@ -69,11 +67,11 @@ func (s shuffle) EditShuffle(w http.ResponseWriter, r *http.Request) {
 	case "shuffle":
 		err = s.actor.Shuffle(formIDBuf)
 		if err != nil {
-			http.Error(w, "failed to shuffle: "+err.Error(), http.StatusInternalServerError)
+			InternalError(w, r, xerrors.Errorf("failed to shuffle: %v", err))
 			return
 		}
 	default:
-		BadRequestError(w, r, xerrors.Errorf("invalid action: %s", req.Action), nil)
+		BadRequestError(w, r, types.CodeInvalidAction, xerrors.Errorf("invalid action: %s", req.Action))
 		return
 	}
 }
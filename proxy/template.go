@@ -0,0 +1,239 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/proxy/txnmanager"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+// NewTemplate returns a new initialized template proxy
+func NewTemplate(srv ordering.Service, ctx serde.Context, verifier ptypes.Verifier,
+	txnManager txnmanager.Manager) Template {
+
+	logger := dela.Logger.With().Timestamp().Str("role", "evoting-template-proxy").Logger()
+
+	return &template{
+		logger:      logger,
+		orderingSvc: srv,
+		context:     ctx,
+		mngr:        txnManager,
+		verifier:    verifier,
+	}
+}
+
+// template defines HTTP handlers to manage the form templates
+//
+// - implements proxy.Template
+type template struct {
+	orderingSvc ordering.Service
+	logger      zerolog.Logger
+	context     serde.Context
+	mngr        txnmanager.Manager
+	verifier    ptypes.Verifier
+}
+
+// NewTemplate implements proxy.Template
+func (h *template) NewTemplate(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CreateTemplateRequest
+
+	// get the signed request
+	signed, err := ptypes.NewSignedRequest(r.Body)
+	if err != nil {
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
+		return
+	}
+
+	// get the request and verify the signature
+	err = signed.GetAndVerify(h.verifier, r, &req)
+	if err != nil {
+		signedErr(w, r, err)
+		return
+	}
+
+	saveTemplate := types.SaveTemplate{
+		Name:          req.Name,
+		AdminID:       req.AdminID,
+		Configuration: req.Configuration,
+	}
+
+	// serialize the transaction
+	data, err := saveTemplate.Serialize(h.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal SaveTemplateTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdSaveTemplate, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
+		return
+	}
+
+	// send the transaction's informations
+	h.mngr.SendSubmission(w, submission)
+}
+
+// Templates implements proxy.Template. The request should not be signed
+// because it is fetching public data.
+func (h *template) Templates(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	rd := h.orderingSvc.GetStore()
+
+	templates := []ptypes.LightTemplate{}
+
+	var templateErr error
+
+	err := types.IterateTemplates(rd, func(name string) bool {
+		latest, err := types.GetTemplateVersion(rd, name, types.LatestTemplateVersion)
+		if err != nil || latest == nil {
+			templateErr = xerrors.Errorf("failed to get template %q: %v", name, err)
+			return false
+		}
+
+		templates = append(templates, ptypes.LightTemplate{
+			Name:    name,
+			Version: latest.Version,
+			Title:   latest.Configuration.Title,
+		})
+
+		return true
+	})
+	if err == nil {
+		err = templateErr
+	}
+
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get templates: %v", err))
+		return
+	}
+
+	response := ptypes.GetTemplatesResponse{Templates: templates}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// Template implements proxy.Template. The version can be selected with the
+// "version" query parameter, otherwise the latest version is returned.
+func (h *template) Template(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+
+	vars := mux.Vars(r)
+
+	if vars == nil || vars["templateName"] == "" {
+		InternalError(w, r, xerrors.Errorf("templateName not found: %v", vars))
+		return
+	}
+
+	version := types.LatestTemplateVersion
+
+	versionStr := r.URL.Query().Get("version")
+	if versionStr != "" {
+		v, err := strconv.ParseUint(versionStr, 10, 32)
+		if err != nil {
+			BadRequestError(w, r, ptypes.CodeInvalidQuery, xerrors.Errorf("invalid version: %v", err))
+			return
+		}
+
+		version = uint(v)
+	}
+
+	name := vars["templateName"]
+	rd := h.orderingSvc.GetStore()
+
+	versions, err := types.TemplateVersions(rd, name)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get template: %v", err))
+		return
+	}
+
+	if versions == 0 {
+		NotFoundErr(w, r, ptypes.CodeTemplateNotFound,
+			xerrors.Errorf("template %q not found", name))
+		return
+	}
+
+	templateVersion, err := types.GetTemplateVersion(rd, name, version)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to get template version: %v", err))
+		return
+	}
+
+	if templateVersion == nil {
+		NotFoundErr(w, r, ptypes.CodeTemplateNotFound,
+			xerrors.Errorf("template %q has no version %d", name, version))
+		return
+	}
+
+	response := ptypes.GetTemplateResponse{
+		Name:            name,
+		TemplateVersion: *templateVersion,
+	}
+
+	txnmanager.SendResponse(w, response)
+}
+
+// NewTemplateForm implements proxy.Template. It creates a new form from a
+// version of a template.
+func (h *template) NewTemplateForm(w http.ResponseWriter, r *http.Request) {
+	var req ptypes.CreateTemplateFormRequest
+
+	// get the signed request
+	signed, err := ptypes.NewSignedRequest(r.Body)
+	if err != nil {
+		BadRequestError(w, r, ptypes.CodeInvalidRequest, newSignedErr(err))
+		return
+	}
+
+	// get the request and verify the signature
+	err = signed.GetAndVerify(h.verifier, r, &req)
+	if err != nil {
+		signedErr(w, r, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	if vars == nil || vars["templateName"] == "" {
+		InternalError(w, r, xerrors.Errorf("templateName not found: %v", vars))
+		return
+	}
+
+	createForm := types.CreateFormFromTemplate{
+		AdminID:         req.AdminID,
+		TemplateName:    vars["templateName"],
+		TemplateVersion: req.Version,
+		Title:           req.Title,
+		AdditionalInfo:  req.AdditionalInfo,
+		Schedule:        req.Schedule,
+	}
+
+	// serialize the transaction
+	data, err := createForm.Serialize(h.context)
+	if err != nil {
+		InternalError(w, r, xerrors.Errorf("failed to marshal CreateFormFromTemplateTransaction: %v", err))
+		return
+	}
+
+	// create the transaction and add it to the pool
+	submission, err := h.mngr.Submit(r, evoting.CmdCreateFormFromTemplate, evoting.FormArg, data)
+	if err != nil {
+		submitErr(w, r, err)
+		return
+	}
+
+	sendFormCreated(w, r, h.mngr, submission)
+}
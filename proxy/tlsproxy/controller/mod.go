@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"go.dedis.ch/d-voting/proxy/tlsproxy"
+	"go.dedis.ch/dela/cli"
+	"go.dedis.ch/dela/cli/node"
+)
+
+// NewController returns a new controller initializer
+func NewController() node.Initializer {
+	return controller{}
+}
+
+// controller is an initializer that stops the HTTPS proxy when the node stops.
+// It must be registered along with the controller of the HTTP proxy, before
+// the drain controller, so that the proxy is stopped once it is drained.
+//
+// - implements node.Initializer
+type controller struct{}
+
+// SetCommands implements node.Initializer.
+func (m controller) SetCommands(builder node.Builder) {}
+
+// OnStart implements node.Initializer. The HTTPS proxy is started by the
+// postinstall.
+func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
+	return nil
+}
+
+// OnStop implements node.Initializer. It stops the HTTPS proxy, if any.
+func (controller) OnStop(inj node.Injector) error {
+	var proxyhttps *tlsproxy.HTTPS
+	err := inj.Resolve(&proxyhttps)
+	if err == nil {
+		proxyhttps.Stop()
+	}
+
+	return nil
+}
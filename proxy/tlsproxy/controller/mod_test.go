@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/proxy/drain"
+	draincontroller "go.dedis.ch/d-voting/proxy/drain/controller"
+	"go.dedis.ch/d-voting/proxy/tlsproxy"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela/cli/node"
+)
+
+func TestController_OnStop(t *testing.T) {
+	c := NewController()
+
+	// nothing to stop
+	require.NoError(t, c.OnStop(node.NewInjector()))
+}
+
+func TestController_DrainOverTLS(t *testing.T) {
+	cert, client := newCertificate(t)
+
+	tracker := drain.NewTracker(fake.NewInMemoryDB(), 0)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := tracker.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	srv := tlsproxy.NewHTTPS("127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	srv.RegisterHandler("/evoting/forms", handler.ServeHTTP)
+
+	go srv.Listen()
+	defer srv.Stop()
+
+	require.Eventually(t, func() bool { return srv.GetAddr() != nil },
+		time.Second, 10*time.Millisecond)
+
+	url := "https://" + srv.GetAddr().String() + "/evoting/forms"
+
+	inj := node.NewInjector()
+	inj.Inject(tracker)
+	inj.Inject(srv)
+
+	inflight := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Post(url, "application/json", nil)
+		if err != nil {
+			t.Error(err)
+		}
+		inflight <- resp
+	}()
+
+	<-started
+
+	// the controllers are stopped in the reverse order of the node, so the
+	// drain comes first
+	stopped := make(chan error, 1)
+	go func() {
+		err := draincontroller.NewController().OnStop(inj)
+		if err == nil {
+			err = NewController().OnStop(inj)
+		}
+		stopped <- err
+	}()
+
+	// a new request is refused over TLS while the proxy drains
+	require.Eventually(t, func() bool {
+		resp, err := client.Post(url, "application/json", nil)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			return false
+		}
+
+		var httpErr ptypes.HTTPError
+		err = json.NewDecoder(resp.Body).Decode(&httpErr)
+
+		return err == nil && httpErr.Reason == ptypes.CodeNodeStopping
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+
+	select {
+	case resp := <-inflight:
+		require.NotNil(t, resp)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	case <-time.After(time.Second):
+		t.Fatal("request still in progress")
+	}
+
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("still stopping")
+	}
+
+	// the proxy is stopped once drained
+	_, err := client.Get(url)
+	require.Error(t, err)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// newCertificate returns a self-signed certificate for 127.0.0.1, and a client
+// that trusts it.
+func newCertificate(t *testing.T) (tls.Certificate, *http.Client) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	// new connections for each request, so that the refused ones go through
+	// a new TLS handshake
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots},
+		DisableKeepAlives: true,
+	}}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, client
+}
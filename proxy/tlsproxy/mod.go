@@ -0,0 +1,155 @@
+// Package tlsproxy implements a proxy server that serves HTTPS instead of
+// plain HTTP, and can require the clients, such as the web backend, to
+// authenticate with a certificate signed by a given CA.
+package tlsproxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.dedis.ch/dela"
+	"golang.org/x/xerrors"
+)
+
+// shutdownTimeout is the maximum duration to wait for the requests in
+// progress when the server stops.
+const shutdownTimeout = 10 * time.Second
+
+// NewConfig returns the TLS configuration of a server with the PEM certificate
+// and key files. If clientCAFile is not empty, the clients must present a
+// certificate signed by one of the PEM certificates of this file.
+func NewConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, xerrors.New("both the certificate and the key are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load certificate: %v", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile == "" {
+		return config, nil
+	}
+
+	caPEM, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read client CA: %v", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, xerrors.Errorf("no certificate found in %s", clientCAFile)
+	}
+
+	config.ClientCAs = clientCAs
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return config, nil
+}
+
+// NewHTTPS returns a new proxy that serves HTTPS on the address with the TLS
+// configuration.
+func NewHTTPS(listenAddr string, config *tls.Config) *HTTPS {
+	logger := dela.Logger.With().Timestamp().Str("role", "https proxy").Logger()
+
+	mux := http.NewServeMux()
+
+	return &HTTPS{
+		mux: mux,
+		server: &http.Server{
+			Addr:      listenAddr,
+			Handler:   mux,
+			TLSConfig: config,
+		},
+		logger:     logger,
+		listenAddr: listenAddr,
+		config:     config,
+	}
+}
+
+// HTTPS defines a proxy that serves HTTPS
+//
+// - implements proxy.Proxy
+type HTTPS struct {
+	sync.Mutex
+
+	mux        *http.ServeMux
+	server     *http.Server
+	logger     zerolog.Logger
+	listenAddr string
+	config     *tls.Config
+
+	ln net.Listener
+}
+
+// Listen implements proxy.Proxy. It blocks until the server is stopped.
+func (h *HTTPS) Listen() {
+	addr := h.listenAddr
+	// if the address is empty, we use a random free port
+	if addr == "" {
+		addr = ":0"
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		h.logger.Error().Err(err).Msgf("failed to listen on %s", addr)
+		return
+	}
+
+	h.Lock()
+	h.ln = ln
+	h.Unlock()
+
+	h.logger.Info().Msgf("server is ready to handle requests at %s", ln.Addr())
+
+	err = h.server.Serve(tls.NewListener(ln, h.config))
+	if err != nil && err != http.ErrServerClosed {
+		h.logger.Error().Err(err).Msg("failed to serve")
+	}
+}
+
+// Stop implements proxy.Proxy. It waits for the requests in progress, at most
+// for a few seconds. It can be called several times.
+func (h *HTTPS) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	h.server.SetKeepAlivesEnabled(false)
+
+	err := h.server.Shutdown(ctx)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("failed to shutdown gracefully")
+	}
+}
+
+// GetAddr implements proxy.Proxy. It returns nil until the server listens.
+func (h *HTTPS) GetAddr() net.Addr {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.ln == nil {
+		return nil
+	}
+
+	return h.ln.Addr()
+}
+
+// RegisterHandler implements proxy.Proxy
+func (h *HTTPS) RegisterHandler(path string, handler func(http.ResponseWriter,
+	*http.Request)) {
+
+	h.mux.HandleFunc(path, handler)
+}
@@ -0,0 +1,264 @@
+package tlsproxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	config, err := NewConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+	require.Len(t, config.Certificates, 1)
+	require.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	config, err = NewConfig(certFile, keyFile, ca.write(t, dir))
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+}
+
+func TestNewConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	_, err := NewConfig(certFile, "", "")
+	require.EqualError(t, err, "both the certificate and the key are required")
+
+	_, err = NewConfig("", "", filepath.Join(dir, "ca.pem"))
+	require.EqualError(t, err, "both the certificate and the key are required")
+
+	_, err = NewConfig(certFile, filepath.Join(dir, "missing.pem"), "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load certificate: ")
+
+	_, err = NewConfig(certFile, keyFile, filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read client CA: ")
+
+	// a key is not a certificate
+	_, err = NewConfig(certFile, keyFile, keyFile)
+	require.EqualError(t, err, "no certificate found in "+keyFile)
+}
+
+func TestHTTPS_TLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	config, err := NewConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+
+	addr := listen(t, config)
+
+	resp, err := newClient(ca, nil).Get("https://" + addr + "/ping")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// plain HTTP is refused
+	resp, err = http.Get("http://" + addr + "/ping")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// the server certificate is not trusted without its CA
+	_, err = newClient(newCA(t, "other"), nil).Get("https://" + addr + "/ping")
+	require.Error(t, err)
+}
+
+func TestHTTPS_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	clientCA := newCA(t, "client ca")
+
+	config, err := NewConfig(certFile, keyFile, clientCA.write(t, dir))
+	require.NoError(t, err)
+
+	addr := listen(t, config)
+
+	clientCert, clientKey := clientCA.issue(t, dir, "backend", x509.ExtKeyUsageClientAuth)
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+
+	resp, err := newClient(ca, &cert).Get("https://" + addr + "/ping")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// no client certificate
+	_, err = newClient(ca, nil).Get("https://" + addr + "/ping")
+	require.Error(t, err)
+
+	// a client certificate of another CA
+	otherCert, otherKey := newCA(t, "other").issue(t, dir, "other", x509.ExtKeyUsageClientAuth)
+	cert, err = tls.LoadX509KeyPair(otherCert, otherKey)
+	require.NoError(t, err)
+
+	_, err = newClient(ca, &cert).Get("https://" + addr + "/ping")
+	require.Error(t, err)
+}
+
+func TestHTTPS_Stop(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newCA(t, "ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	config, err := NewConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+
+	srv := NewHTTPS("127.0.0.1:0", config)
+	require.Nil(t, srv.GetAddr())
+
+	done := make(chan struct{})
+	go func() {
+		srv.Listen()
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return srv.GetAddr() != nil },
+		time.Second, 10*time.Millisecond)
+
+	srv.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("still listening")
+	}
+
+	// stopping again is harmless
+	srv.Stop()
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// authority is a self-signed CA generated for a test
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newCA(t *testing.T, name string) authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return authority{cert: cert, key: key, der: der}
+}
+
+// write writes the certificate of the CA in the directory, and returns the
+// path of the file.
+func (a authority) write(t *testing.T, dir string) string {
+	path := filepath.Join(dir, a.cert.Subject.CommonName+"-ca.pem")
+	writePEM(t, path, "CERTIFICATE", a.der)
+
+	return path
+}
+
+// issue writes a certificate for 127.0.0.1 signed by the CA and its key in the
+// directory, and returns the paths of the files.
+func (a authority) issue(t *testing.T, dir, name string, usage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	buf := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, buf, 0600))
+}
+
+// newClient returns a client that trusts the CA, and presents the certificate
+// if it is not nil.
+func newClient(ca authority, cert *tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	config := &tls.Config{RootCAs: roots}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+}
+
+// listen starts a server with a /ping handler, and returns its address.
+func listen(t *testing.T, config *tls.Config) string {
+	srv := NewHTTPS("127.0.0.1:0", config)
+	srv.RegisterHandler("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	go srv.Listen()
+	t.Cleanup(srv.Stop)
+
+	require.Eventually(t, func() bool { return srv.GetAddr() != nil },
+		time.Second, 10*time.Millisecond)
+
+	return srv.GetAddr().String()
+}
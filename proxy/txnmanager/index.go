@@ -0,0 +1,200 @@
+package txnmanager
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.dedis.ch/dela/core/ordering"
+	"golang.org/x/xerrors"
+)
+
+// maxIndexSize is the maximum number of transactions kept in the index, so
+// that its memory is bounded whatever the load is.
+const maxIndexSize = 100000
+
+// indexEntry is the outcome of a transaction included in a block
+type indexEntry struct {
+	blockIdx uint64
+	accepted bool
+	message  string
+}
+
+// indexItem is an item of the eviction queues of the index
+type indexItem struct {
+	txnID    string
+	blockIdx uint64
+	seen     time.Time
+}
+
+// txnIndex maps the IDs of the transactions to the block including them. The
+// transactions are evicted once they are older than the retention, or when
+// the index is full.
+type txnIndex struct {
+	sync.Mutex
+
+	retention time.Duration
+	maxSize   int
+	entries   map[string]indexEntry
+	queue     []indexItem
+
+	// pending holds the IDs of the transactions submitted by the proxy, and
+	// not yet included, and pendingQueue holds them in the order they were
+	// submitted. changed is closed and replaced each time a block is indexed.
+	pending      map[string]struct{}
+	pendingQueue []indexItem
+	changed      chan struct{}
+
+	// started is true once a block has been indexed, and first is the index
+	// of this block. The transactions of the previous blocks are unknown.
+	started bool
+	first   uint64
+
+	// evicted is true once a transaction has been evicted, and lastEvicted
+	// is the index of the block of the last one. The blocks are indexed in
+	// order, so the transactions of the blocks after it are all in the index.
+	evicted     bool
+	lastEvicted uint64
+
+	// now is used to get the time, it is replaced in the tests
+	now func() time.Time
+}
+
+// newTxnIndex returns a new empty index that keeps the transactions for the
+// given duration.
+func newTxnIndex(retention time.Duration, maxSize int) *txnIndex {
+	return &txnIndex{
+		retention: retention,
+		maxSize:   maxSize,
+		entries:   make(map[string]indexEntry),
+		pending:   make(map[string]struct{}),
+		changed:   make(chan struct{}),
+		now:       time.Now,
+	}
+}
+
+// listen indexes the blocks of the ordering service until the context is
+// done.
+func (idx *txnIndex) listen(ctx context.Context, srv ordering.Service) {
+	for event := range srv.Watch(ctx) {
+		idx.add(event)
+	}
+}
+
+// add indexes the transactions of a block.
+func (idx *txnIndex) add(event ordering.Event) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	if !idx.started {
+		idx.started = true
+		idx.first = event.Index
+	}
+
+	now := idx.now()
+
+	for _, res := range event.Transactions {
+		txnID := hex.EncodeToString(res.GetTransaction().GetID())
+		accepted, message := res.GetStatus()
+
+		idx.entries[txnID] = indexEntry{
+			blockIdx: event.Index,
+			accepted: accepted,
+			message:  message,
+		}
+
+		idx.queue = append(idx.queue, indexItem{txnID: txnID, blockIdx: event.Index, seen: now})
+
+		delete(idx.pending, txnID)
+	}
+
+	idx.prune(now)
+
+	close(idx.changed)
+	idx.changed = make(chan struct{})
+}
+
+// submit records a transaction submitted to the pool, until it is included.
+func (idx *txnIndex) submit(txnID []byte) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	id := hex.EncodeToString(txnID)
+
+	idx.pending[id] = struct{}{}
+	idx.pendingQueue = append(idx.pendingQueue, indexItem{txnID: id, seen: idx.now()})
+}
+
+// waitPending waits until the transactions submitted are included, or
+// submitted for longer than the retention, or the context is done.
+func (idx *txnIndex) waitPending(ctx context.Context) error {
+	for {
+		idx.Lock()
+		idx.prune(idx.now())
+		pending := len(idx.pending)
+		changed := idx.changed
+		idx.Unlock()
+
+		if pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return xerrors.Errorf("%d transactions still pending: %v", pending, ctx.Err())
+		}
+	}
+}
+
+// get returns the outcome of a transaction submitted after the given block.
+// The first boolean is true if the transaction is in the index. The second one
+// is true if the index covers the transaction, which means that it is not
+// included yet if it is not in the index. The index doesn't cover it if it
+// started after the block, or if transactions of the later blocks have been
+// evicted.
+func (idx *txnIndex) get(txnID []byte, lastBlockIdx uint64) (indexEntry, bool, bool) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	idx.prune(idx.now())
+
+	entry, found := idx.entries[hex.EncodeToString(txnID)]
+
+	covered := idx.started && lastBlockIdx >= idx.first &&
+		(!idx.evicted || idx.lastEvicted <= lastBlockIdx)
+
+	return entry, found, covered
+}
+
+// prune evicts the transactions older than the retention, and the oldest ones
+// if the index is too big. The pending transactions submitted for longer than
+// the retention are given up. The lock must be held.
+func (idx *txnIndex) prune(now time.Time) {
+	for len(idx.pendingQueue) > 0 {
+		item := idx.pendingQueue[0]
+
+		if now.Sub(item.seen) <= idx.retention {
+			break
+		}
+
+		// the transaction may have been included already
+		delete(idx.pending, item.txnID)
+		idx.pendingQueue = idx.pendingQueue[1:]
+	}
+
+	for len(idx.queue) > 0 {
+		item := idx.queue[0]
+
+		if len(idx.queue) <= idx.maxSize && now.Sub(item.seen) <= idx.retention {
+			return
+		}
+
+		delete(idx.entries, item.txnID)
+		idx.queue = idx.queue[1:]
+
+		idx.evicted = true
+		idx.lastEvicted = item.blockIdx
+	}
+}
@@ -0,0 +1,197 @@
+package txnmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/validation"
+)
+
+func TestTxnIndex_AddGet(t *testing.T) {
+	idx := newTxnIndex(time.Minute, 10)
+
+	_, _, covered := idx.get([]byte("tx1"), 0)
+	require.False(t, covered)
+
+	idx.add(ordering.Event{
+		Index: 3,
+		Transactions: []validation.TransactionResult{
+			fakeResult{id: "tx1", accepted: true},
+			fakeResult{id: "tx2", message: "oops"},
+		},
+	})
+
+	entry, found, covered := idx.get([]byte("tx1"), 3)
+	require.True(t, found)
+	require.True(t, covered)
+	require.Equal(t, indexEntry{blockIdx: 3, accepted: true}, entry)
+
+	entry, found, _ = idx.get([]byte("tx2"), 3)
+	require.True(t, found)
+	require.Equal(t, indexEntry{blockIdx: 3, message: "oops"}, entry)
+
+	_, found, covered = idx.get([]byte("tx3"), 3)
+	require.False(t, found)
+	require.True(t, covered)
+
+	// submitted before the first block of the index
+	_, found, covered = idx.get([]byte("tx3"), 2)
+	require.False(t, found)
+	require.False(t, covered)
+}
+
+func TestTxnIndex_Retention(t *testing.T) {
+	now := time.Now()
+
+	idx := newTxnIndex(time.Minute, 10)
+	idx.now = func() time.Time { return now }
+
+	idx.add(ordering.Event{
+		Index:        1,
+		Transactions: []validation.TransactionResult{fakeResult{id: "tx1"}},
+	})
+
+	now = now.Add(30 * time.Second)
+
+	idx.add(ordering.Event{
+		Index:        2,
+		Transactions: []validation.TransactionResult{fakeResult{id: "tx2"}},
+	})
+
+	now = now.Add(45 * time.Second)
+
+	// tx1 may have been evicted
+	_, found, covered := idx.get([]byte("tx1"), 0)
+	require.False(t, found)
+	require.False(t, covered)
+
+	_, found, _ = idx.get([]byte("tx2"), 1)
+	require.True(t, found)
+
+	// a transaction submitted after the evicted block is covered
+	_, found, covered = idx.get([]byte("tx3"), 1)
+	require.False(t, found)
+	require.True(t, covered)
+}
+
+func TestTxnIndex_MaxSize(t *testing.T) {
+	idx := newTxnIndex(time.Minute, 2)
+
+	idx.add(ordering.Event{
+		Index: 1,
+		Transactions: []validation.TransactionResult{
+			fakeResult{id: "tx1"},
+			fakeResult{id: "tx2"},
+			fakeResult{id: "tx3"},
+		},
+	})
+
+	require.Len(t, idx.entries, 2)
+
+	_, found, covered := idx.get([]byte("tx1"), 0)
+	require.False(t, found)
+	require.False(t, covered)
+
+	_, found, _ = idx.get([]byte("tx3"), 0)
+	require.True(t, found)
+}
+
+func TestTxnIndex_WaitPending(t *testing.T) {
+	idx := newTxnIndex(time.Minute, 10)
+
+	require.NoError(t, idx.waitPending(context.Background()))
+
+	idx.submit([]byte("tx1"))
+	idx.submit([]byte("tx2"))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- idx.waitPending(context.Background())
+	}()
+
+	idx.add(ordering.Event{
+		Index:        1,
+		Transactions: []validation.TransactionResult{fakeResult{id: "tx1"}},
+	})
+
+	select {
+	case <-done:
+		t.Fatal("returned with a pending transaction")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	idx.add(ordering.Event{
+		Index:        2,
+		Transactions: []validation.TransactionResult{fakeResult{id: "tx2"}},
+	})
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("still waiting")
+	}
+}
+
+func TestTxnIndex_WaitPendingRetention(t *testing.T) {
+	now := time.Now()
+
+	idx := newTxnIndex(time.Minute, 10)
+	idx.now = func() time.Time { return now }
+
+	idx.submit([]byte("tx1"))
+
+	now = now.Add(30 * time.Second)
+
+	idx.submit([]byte("tx2"))
+
+	now = now.Add(45 * time.Second)
+
+	// tx1 is given up
+	idx.prune(now)
+	require.Len(t, idx.pending, 1)
+	require.Len(t, idx.pendingQueue, 1)
+
+	now = now.Add(time.Minute)
+
+	require.NoError(t, idx.waitPending(context.Background()))
+	require.Empty(t, idx.pendingQueue)
+}
+
+func TestTxnIndex_WaitPendingTimeout(t *testing.T) {
+	idx := newTxnIndex(time.Minute, 10)
+
+	idx.submit([]byte("tx1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := idx.waitPending(ctx)
+	require.EqualError(t, err, "1 transactions still pending: context deadline exceeded")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// fakeResult
+//
+// - implements validation.TransactionResult
+type fakeResult struct {
+	validation.TransactionResult
+	id       string
+	accepted bool
+	message  string
+}
+
+func (r fakeResult) GetTransaction() txn.Transaction {
+	return fake.Transaction{Id: []byte(r.id)}
+}
+
+func (r fakeResult) GetStatus() (bool, string) {
+	return r.accepted, r.message
+}
@@ -3,23 +3,50 @@ package txnmanager
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"go.dedis.ch/d-voting/contracts/evoting"
+	"golang.org/x/xerrors"
 )
 
+// ErrInvalidWait is returned when the "wait" query parameter of a request is
+// not a valid duration.
+var ErrInvalidWait = xerrors.New("invalid wait")
+
+// MaxWait is the maximum duration a request can wait for its transaction to
+// be included.
+const MaxWait = time.Minute
+
 // Manager defines the public HTTP API of the transaction manager
 type Manager interface {
 	// GET /transactions/{token}
 	StatusHandlerGet(http.ResponseWriter, *http.Request)
 
-	// submit the transaction to the blockchain
+	// submit the transaction to the blockchain, unless the context is done
 	// return the transactionID and
 	// the index of the last block when it was submitted
 	SubmitTxn(ctx context.Context, cmd evoting.Command, cmdArg string, payload []byte) ([]byte, uint64, error)
 
+	// Submit submits the transaction of the request. If the request has a
+	// "wait" query parameter, such as "?wait=10s", it waits at most this
+	// duration for the transaction to be included in a block. It returns a
+	// *ratelimit.Error if the pool can't accept more transactions for now.
+	Submit(r *http.Request, cmd evoting.Command, cmdArg string, payload []byte) (Submission, error)
+
+	// SendSubmission sends the transaction informations of a submission
+	SendSubmission(w http.ResponseWriter, submission Submission) error
+
 	// CreateTransactionResult create the json to send to the client
 	CreateTransactionResult(txnID []byte, lastBlockIdx uint64, status TransactionStatus) (TransactionClientInfo, error)
 	SendTransactionInfo(w http.ResponseWriter, txnID []byte, lastBlockIdx uint64, status TransactionStatus) error
+
+	// LookupToken returns the current status of the transaction of a token, if
+	// the token is valid and its status was unknown when it was issued
+	LookupToken(token string) (Submission, error)
+
+	// WaitPending waits until the transactions submitted are included, or
+	// given up after the retention, or the context is done.
+	WaitPending(ctx context.Context) error
 }
 
 // TransactionStatus is the status of a transaction
@@ -44,6 +71,24 @@ type transactionInternalInfo struct {
 	Signature     []byte // signature of the Hash
 }
 
+// Submission is the result of the submission of a transaction
+type Submission struct {
+	TransactionID []byte
+	// BlockIdx is the index of the block including the transaction if its
+	// status is known, otherwise the index of the last block when it was
+	// submitted
+	BlockIdx uint64
+	Status   TransactionStatus
+	// Code identifies the reason why the transaction was rejected. If it is
+	// empty, it is derived from the message.
+	Code ErrorCode
+	// Message is the reason why the transaction was rejected
+	Message string
+	// Time is the time when the transaction was submitted, in seconds since
+	// the epoch. If it is zero, the transaction was just submitted.
+	Time int64
+}
+
 // TransactionClientInfo defines the HTTP response when sending
 // transaction infos to the client so that he can use the status
 // of the transaction to know if it has been included or not
@@ -52,4 +97,11 @@ type transactionInternalInfo struct {
 type TransactionClientInfo struct {
 	Status TransactionStatus // 0 if not yet included, 1 if included, 2 if rejected
 	Token  string
+	// BlockIndex is the index of the block including the transaction, only
+	// set if the status is known
+	BlockIndex uint64 `json:",omitempty"`
+	// Code identifies the reason why the transaction was rejected
+	Code ErrorCode `json:",omitempty"`
+	// Message is the reason why the transaction was rejected
+	Message string `json:",omitempty"`
 }
@@ -0,0 +1,46 @@
+package txnmanager
+
+import "go.dedis.ch/d-voting/contracts/evoting/types"
+
+// ErrorCode is a stable code identifying why a transaction was rejected. The
+// human-readable reason can change between versions, the code does not.
+type ErrorCode string
+
+const (
+	// CodeUnknown is used when the reason of the rejection is not recognized
+	CodeUnknown ErrorCode = "UNKNOWN"
+	// CodeExpired is used when the transaction was not included in time
+	CodeExpired ErrorCode = "EXPIRED"
+)
+
+// The codes of the rejections of the evoting contract
+const (
+	CodeUnauthorized         = ErrorCode(types.CodeUnauthorized)
+	CodeFormNotFound         = ErrorCode(types.CodeFormNotFound)
+	CodeFormAlreadyExists    = ErrorCode(types.CodeFormAlreadyExists)
+	CodeTemplateNotFound     = ErrorCode(types.CodeTemplateNotFound)
+	CodeInvalidConfiguration = ErrorCode(types.CodeInvalidConfiguration)
+	CodeFormNotInitial       = ErrorCode(types.CodeFormNotInitial)
+	CodeFormNotOpen          = ErrorCode(types.CodeFormNotOpen)
+	CodeFormNotClosed        = ErrorCode(types.CodeFormNotClosed)
+	CodeInvalidVoterWeights  = ErrorCode(types.CodeInvalidVoterWeights)
+	CodeInvalidBallot        = ErrorCode(types.CodeInvalidBallot)
+	CodeInvalidBatch         = ErrorCode(types.CodeInvalidBatch)
+	CodeVoterNotAllowed      = ErrorCode(types.CodeVoterNotAllowed)
+	CodeNotEnoughBallots     = ErrorCode(types.CodeNotEnoughBallots)
+	CodeInvalidShuffle       = ErrorCode(types.CodeInvalidShuffle)
+	CodeInvalidPubShares     = ErrorCode(types.CodeInvalidPubShares)
+	CodeInvalidDecryption    = ErrorCode(types.CodeInvalidDecryption)
+)
+
+// Reason returns the code and the message of the reason why a transaction was
+// rejected, given the message of its result. The evoting contract prefixes the
+// message with the code, which is CodeUnknown if there is none.
+func Reason(message string) (ErrorCode, string) {
+	code, message := types.ParseRejection(message)
+	if code == "" {
+		return CodeUnknown, message
+	}
+
+	return ErrorCode(code), message
+}
@@ -0,0 +1,165 @@
+package txnmanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/d-voting/services/dkg"
+	"go.dedis.ch/dela/core/access"
+	"go.dedis.ch/dela/core/execution"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/store"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/txn/signed"
+	"go.dedis.ch/dela/serde"
+	sjson "go.dedis.ch/dela/serde/json"
+	"golang.org/x/xerrors"
+)
+
+func TestReason(t *testing.T) {
+	code, message := Reason("[FORM_NOT_OPEN] failed to cast vote: the form is not open")
+	require.Equal(t, CodeFormNotOpen, code)
+	require.Equal(t, "failed to cast vote: the form is not open", message)
+
+	code, message = Reason("oops")
+	require.Equal(t, CodeUnknown, code)
+	require.Equal(t, "oops", message)
+
+	// the code must be at the beginning of the message
+	code, _ = Reason(`failed to save template: template "[FORM_NOT_OPEN] " not found`)
+	require.Equal(t, CodeUnknown, code)
+}
+
+// TestReason_Contract runs the error paths of the evoting contract through the
+// mapper of the proxy.
+func TestReason_Contract(t *testing.T) {
+	ctx := sjson.NewContext()
+	snap := fake.NewSnapshot()
+
+	roster := authority.FromAuthority(fake.NewAuthority(3, fake.NewSigner))
+	pedersen := fake.Pedersen{Actors: make(map[string]dkg.Actor)}
+
+	contract := evoting.NewContract(denyAccess{}, pedersen, fake.NewRosterFac(roster))
+
+	err := contract.Execute(snap, newStep(t, ctx, evoting.CmdOpenForm, types.OpenForm{}))
+	requireReason(t, CodeUnauthorized, err)
+
+	contract = evoting.NewContract(fakeAccess{}, pedersen, fake.NewRosterFac(roster))
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdSaveTemplate, types.SaveTemplate{}))
+	requireReason(t, CodeInvalidConfiguration, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCreateFormFromTemplate,
+		types.CreateFormFromTemplate{TemplateName: "unknown"}))
+	requireReason(t, CodeTemplateNotFound, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCastVote,
+		types.CastVote{FormID: hex.EncodeToString([]byte("unknown"))}))
+	requireReason(t, CodeFormNotFound, err)
+
+	step := newStep(t, ctx, evoting.CmdCreateForm,
+		types.CreateForm{Configuration: fake.BasicConfiguration, AdminID: "admin"})
+
+	err = contract.Execute(snap, step)
+	require.NoError(t, err)
+
+	formIDBuf := sha256.Sum256(step.Current.GetID())
+	formID := hex.EncodeToString(formIDBuf[:])
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCastVote,
+		types.CastVote{FormID: formID}))
+	requireReason(t, CodeFormNotOpen, err)
+
+	// the specific reason comes before the one of the command
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdShuffleBallots,
+		types.ShuffleBallots{FormID: formID}))
+	requireReason(t, CodeFormNotClosed, err)
+
+	_, err = pedersen.Listen(formIDBuf[:], nil)
+	require.NoError(t, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdOpenForm,
+		types.OpenForm{FormID: formID}))
+	require.NoError(t, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdOpenForm,
+		types.OpenForm{FormID: formID}))
+	requireReason(t, CodeFormNotInitial, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCastVote,
+		types.CastVote{FormID: formID, UserID: "alice"}))
+	requireReason(t, CodeInvalidBallot, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCastVotes,
+		types.CastVotes{FormID: formID}))
+	requireReason(t, CodeInvalidBatch, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCloseForm,
+		types.CloseForm{FormID: formID}))
+	requireReason(t, CodeNotEnoughBallots, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdRegisterPubShares,
+		types.RegisterPubShares{FormID: formID}))
+	requireReason(t, CodeInvalidPubShares, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, evoting.CmdCombineShares,
+		types.CombineShares{FormID: formID}))
+	requireReason(t, CodeInvalidDecryption, err)
+
+	err = contract.Execute(snap, newStep(t, ctx, "unknown", types.OpenForm{}))
+	requireReason(t, CodeUnknown, err)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// requireReason checks the code of the error returned by the contract, which
+// is the message of the result of the transaction.
+func requireReason(t *testing.T, expected ErrorCode, err error) {
+	require.Error(t, err)
+
+	code, message := Reason(err.Error())
+	require.Equal(t, expected, code, err.Error())
+	require.NotContains(t, message, "[")
+}
+
+func newStep(t *testing.T, ctx serde.Context, cmd evoting.Command,
+	msg serde.Message) execution.Step {
+
+	buf, err := msg.Serialize(ctx)
+	require.NoError(t, err)
+
+	tx, err := signed.NewTransaction(0, fake.PublicKey{},
+		signed.WithArg(evoting.CmdArg, []byte(cmd)),
+		signed.WithArg(evoting.FormArg, buf))
+	require.NoError(t, err)
+
+	return execution.Step{Current: tx, Previous: []txn.Transaction{}}
+}
+
+// fakeAccess grants the access to everyone.
+//
+// - implements access.Service
+type fakeAccess struct {
+	access.Service
+}
+
+func (fakeAccess) Match(store.Readable, access.Credential, ...access.Identity) error {
+	return nil
+}
+
+// denyAccess denies the access to everyone.
+//
+// - implements access.Service
+type denyAccess struct {
+	access.Service
+}
+
+func (denyAccess) Match(store.Readable, access.Credential, ...access.Identity) error {
+	return xerrors.New("denied")
+}
@@ -15,35 +15,51 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
 	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
 	"go.dedis.ch/dela"
 	"go.dedis.ch/dela/core/execution/native"
+	"go.dedis.ch/dela/core/ordering"
 	"go.dedis.ch/dela/core/ordering/cosipbft/blockstore"
 	"go.dedis.ch/dela/core/txn"
 	"go.dedis.ch/dela/core/txn/pool"
 	"go.dedis.ch/dela/crypto"
 	"go.dedis.ch/dela/serde"
-	"go.dedis.ch/kyber/v3"
 	"golang.org/x/xerrors"
 )
 
-const (
-	maxTimeTransactionCheck = 10 * time.Minute
-)
+// DefaultRetention is the default duration during which the status of a
+// transaction can be checked after it was submitted or last checked.
+const DefaultRetention = 10 * time.Minute
 
-// NewTransactionManager returns a new initialized transaction manager
-func NewTransactionManager(mngr txn.Manager, p pool.Pool,
-	ctx serde.Context, pk kyber.Point, blocks blockstore.BlockStore, signer crypto.Signer) Manager {
+// NewTransactionManager returns a new initialized transaction manager. It
+// indexes the transactions of the new blocks, and keeps them for the given
+// retention, or DefaultRetention if it is not positive. The submissions are
+// refused when they exceed the limit of the pool of the limiter.
+func NewTransactionManager(mngr txn.Manager, p pool.Pool, srv ordering.Service,
+	ctx serde.Context, blocks blockstore.BlockStore, signer crypto.Signer,
+	retention time.Duration, limiter *ratelimit.Limiter) Manager {
 
 	logger := dela.Logger.With().Timestamp().Str("role", "proxy-txmanager").Logger()
 
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	index := newTxnIndex(retention, maxIndexSize)
+	go index.listen(context.Background(), srv)
+
 	return &manager{
-		logger:  logger,
-		context: ctx,
-		mngr:    mngr,
-		pool:    p,
-		pk:      pk,
-		blocks:  blocks,
-		signer:  signer,
+		logger:    logger,
+		context:   ctx,
+		mngr:      mngr,
+		pool:      p,
+		srv:       srv,
+		blocks:    blocks,
+		signer:    signer,
+		retention: retention,
+		index:     index,
+		limiter:   limiter,
 	}
 }
 
@@ -57,9 +73,14 @@ type manager struct {
 	context serde.Context
 	mngr    txn.Manager
 	pool    pool.Pool
-	pk      kyber.Point
+	srv     ordering.Service
 	blocks  blockstore.BlockStore
 	signer  crypto.Signer
+
+	// retention is the duration after which a token expires
+	retention time.Duration
+	index     *txnIndex
+	limiter   *ratelimit.Limiter
 }
 
 // StatusHandlerGet checks if the transaction is included in the blockchain
@@ -69,62 +90,97 @@ func (h *manager) StatusHandlerGet(w http.ResponseWriter, r *http.Request) {
 
 	// check if the token is valid
 	if vars == nil || vars["token"] == "" {
-		http.Error(w, fmt.Sprintf("token not found: %v", vars), http.StatusInternalServerError)
+		httpErr(w, r, http.StatusInternalServerError, ptypes.CodeInternal,
+			xerrors.Errorf("token not found: %v", vars))
 		return
 	}
 
 	token := vars["token"]
 
-	// decode the token
-	marshall, err := b64.URLEncoding.DecodeString(token)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to decode token: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	// unmarshall the token to get the json with all the informations
-	var content transactionInternalInfo
-	err = json.Unmarshal(marshall, &content)
+	content, err := decodeToken(token)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to unmarshall token: %v", err), http.StatusInternalServerError)
+		httpErr(w, r, http.StatusBadRequest, ptypes.CodeInvalidToken, err)
 		return
 	}
 
 	err = content.validate(h)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid content: %v", err), http.StatusBadRequest)
+		httpErr(w, r, http.StatusBadRequest, ptypes.CodeInvalidToken,
+			xerrors.Errorf("Invalid content: %v", err))
 		return
 	}
 
-	// check if if was submited not to long ago
-	if time.Now().Unix()-content.Time > int64(maxTimeTransactionCheck) {
-		// if it was submited to long ago, we reject the transaction
-		err = h.SendTransactionInfo(w, content.TransactionID, 0, RejectedTransaction)
-		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to send transaction info: %v", err), http.StatusInternalServerError)
-			return
-		}
-		return
-	}
+	age := time.Since(time.Unix(content.Time, 0))
 
 	// check if the transaction time stamp is possible
-	if time.Now().Unix()-content.Time < 0 {
-		http.Error(w, "the transaction is from the future", http.StatusInternalServerError)
+	if age < 0 {
+		httpErr(w, r, http.StatusBadRequest, ptypes.CodeInvalidToken,
+			xerrors.New("the transaction is from the future"))
 		return
 	}
 
 	// check if the transaction is included in the blockchain
-	newStatus, idx := h.checkTxnIncluded(content.TransactionID, content.LastBlockIdx)
+	submission := h.lookupTxn(content.TransactionID, content.LastBlockIdx)
+
+	// the new token keeps the time of the submission, so that polling
+	// doesn't delay the expiration
+	submission.Time = content.Time
+
+	// if it is still unknown after too long, we reject the transaction
+	if submission.Status == UnknownTransactionStatus && age > h.retention {
+		submission = Submission{
+			TransactionID: content.TransactionID,
+			Status:        RejectedTransaction,
+			Code:          CodeExpired,
+			Message:       "the transaction was not included in time",
+		}
+	}
 
 	// send the transaction info
-	err = h.SendTransactionInfo(w, content.TransactionID, idx, newStatus)
+	err = h.SendSubmission(w, submission)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("failed to send transaction info: %v", err), http.StatusInternalServerError)
+		httpErr(w, r, http.StatusInternalServerError, ptypes.CodeInternal,
+			xerrors.Errorf("failed to send transaction info: %v", err))
 		return
 	}
 
 }
 
+// LookupToken implements Manager
+func (h *manager) LookupToken(token string) (Submission, error) {
+	content, err := decodeToken(token)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	err = content.validate(h)
+	if err != nil {
+		return Submission{}, xerrors.Errorf("invalid content: %v", err)
+	}
+
+	return h.lookupTxn(content.TransactionID, content.LastBlockIdx), nil
+}
+
+// decodeToken returns the informations of the transaction contained in the
+// token.
+func decodeToken(token string) (transactionInternalInfo, error) {
+	var content transactionInternalInfo
+
+	// decode the token
+	marshall, err := b64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return content, xerrors.Errorf("failed to decode token: %v", err)
+	}
+
+	// unmarshall the token to get the json with all the informations
+	err = json.Unmarshal(marshall, &content)
+	if err != nil {
+		return content, xerrors.Errorf("failed to unmarshall token: %v", err)
+	}
+
+	return content, nil
+}
+
 // validate checks if the transaction is valid
 func (content transactionInternalInfo) validate(h *manager) error {
 	// check if the transaction status is unknown
@@ -166,8 +222,37 @@ func (h *manager) checkSignature(Hash []byte, Signature crypto.Signature) bool {
 	return h.signer.GetPublicKey().Verify(Hash, Signature) == nil
 }
 
-// checkTxnIncluded checks if the transaction is included in the blockchain
-func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (TransactionStatus, uint64) {
+// lookupTxn returns the status of the transaction from the index. The blocks
+// are only scanned if the transaction is not in the index and the index
+// doesn't cover it, because it was submitted before the index started or it
+// may have been evicted.
+func (h *manager) lookupTxn(transactionID []byte, lastBlockIdx uint64) Submission {
+	entry, found, covered := h.index.get(transactionID, lastBlockIdx)
+	if !found {
+		if !covered {
+			return h.checkTxnIncluded(transactionID, lastBlockIdx)
+		}
+
+		return Submission{TransactionID: transactionID, BlockIdx: lastBlockIdx}
+	}
+
+	submission := Submission{
+		TransactionID: transactionID,
+		BlockIdx:      entry.blockIdx,
+		Status:        IncludedTransaction,
+	}
+
+	if !entry.accepted {
+		submission.Status = RejectedTransaction
+		submission.Message = entry.message
+	}
+
+	return submission
+}
+
+// checkTxnIncluded checks if the transaction is included in the blockchain.
+// If it was rejected, the submission contains the reason.
+func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) Submission {
 	// we start at the last block index
 	// which is the index of the last block that was checked
 	// or the last block before the transaction was submited
@@ -179,16 +264,29 @@ func (h *manager) checkTxnIncluded(transactionID []byte, lastBlockIdx uint64) (T
 
 		// if we reached the end of the blockchain
 		if err != nil {
-			return UnknownTransactionStatus, idx - 1
+			return Submission{TransactionID: transactionID, BlockIdx: idx - 1}
 		}
 
 		// check if the transaction is in the block
-		transactions := blockLink.GetBlock().GetTransactions()
-		for _, txn := range transactions {
-			if bytes.Equal(txn.GetID(), transactionID) {
-				return IncludedTransaction, blockLink.GetBlock().GetIndex()
+		results := blockLink.GetBlock().GetData().GetTransactionResults()
+		for _, res := range results {
+			if !bytes.Equal(res.GetTransaction().GetID(), transactionID) {
+				continue
 			}
 
+			submission := Submission{
+				TransactionID: transactionID,
+				BlockIdx:      blockLink.GetBlock().GetIndex(),
+				Status:        IncludedTransaction,
+			}
+
+			accepted, message := res.GetStatus()
+			if !accepted {
+				submission.Status = RejectedTransaction
+				submission.Message = message
+			}
+
+			return submission
 		}
 
 		idx++
@@ -203,7 +301,14 @@ func (h *manager) SubmitTxn(ctx context.Context, cmd evoting.Command,
 	h.Lock()
 	defer h.Unlock()
 
-	tx, err := createTransaction(h.mngr, cmd, cmdArg, payload)
+	// the request may have been canceled while waiting for the lock, in which
+	// case nobody would get the token of the transaction
+	err := ctx.Err()
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to submit transaction: %v", err)
+	}
+
+	tx, err := CreateTransaction(h.mngr, cmd, cmdArg, payload)
 	if err != nil {
 		return nil, 0, xerrors.Errorf("failed to create transaction: %v", err)
 	}
@@ -220,9 +325,129 @@ func (h *manager) SubmitTxn(ctx context.Context, cmd evoting.Command,
 		return nil, 0, xerrors.Errorf("failed to add transaction to the pool: %v", err)
 	}
 
+	h.index.submit(tx.GetID())
+
 	return tx.GetID(), lastBlockIdx, nil
 }
 
+// WaitPending implements Manager
+func (h *manager) WaitPending(ctx context.Context) error {
+	return h.index.waitPending(ctx)
+}
+
+// Submit implements Manager
+func (h *manager) Submit(r *http.Request, cmd evoting.Command, cmdArg string,
+	payload []byte) (Submission, error) {
+
+	wait, err := parseWait(r)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	err = h.limiter.AllowTransaction(r.Context())
+	if err != nil {
+		return Submission{}, err
+	}
+
+	if wait == 0 {
+		txnID, lastBlockIdx, err := h.SubmitTxn(r.Context(), cmd, cmdArg, payload)
+		if err != nil {
+			return Submission{}, err
+		}
+
+		return Submission{TransactionID: txnID, BlockIdx: lastBlockIdx}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+
+	// the blocks are watched before the submission so that the block
+	// including the transaction is not missed
+	events := h.srv.Watch(ctx)
+
+	txnID, lastBlockIdx, err := h.SubmitTxn(r.Context(), cmd, cmdArg, payload)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	submission := Submission{
+		TransactionID: txnID,
+		BlockIdx:      lastBlockIdx,
+	}
+
+	for event := range events {
+		for _, res := range event.Transactions {
+			if !bytes.Equal(res.GetTransaction().GetID(), txnID) {
+				continue
+			}
+
+			accepted, message := res.GetStatus()
+
+			submission.BlockIdx = event.Index
+			submission.Status = IncludedTransaction
+
+			if !accepted {
+				submission.Status = RejectedTransaction
+				submission.Message = message
+			}
+
+			return submission, nil
+		}
+	}
+
+	// the wait is over and the transaction is not included yet
+	return submission, nil
+}
+
+// SendSubmission implements Manager
+func (h *manager) SendSubmission(w http.ResponseWriter, submission Submission) error {
+	submitted := submission.Time
+	if submitted == 0 {
+		submitted = time.Now().Unix()
+	}
+
+	response, err := h.createToken(submission.TransactionID, submission.BlockIdx,
+		submission.Status, submitted)
+	if err != nil {
+		return xerrors.Errorf("failed to create transaction info: %v", err)
+	}
+
+	if submission.Status != UnknownTransactionStatus {
+		response.BlockIndex = submission.BlockIdx
+		response.Message = submission.Message
+	}
+
+	if submission.Status == RejectedTransaction {
+		response.Code = submission.Code
+
+		if response.Code == "" {
+			response.Code, response.Message = Reason(submission.Message)
+		}
+	}
+
+	return SendResponse(w, response)
+}
+
+// parseWait returns the duration of the "wait" query parameter, or 0 if
+// there is none.
+func parseWait(r *http.Request) (time.Duration, error) {
+	waitStr := r.URL.Query().Get("wait")
+	if waitStr == "" {
+		return 0, nil
+	}
+
+	wait, err := time.ParseDuration(waitStr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidWait, err)
+	}
+
+	if wait < 0 || wait > MaxWait {
+		return 0, fmt.Errorf("%w: must be between 0 and %s", ErrInvalidWait, MaxWait)
+	}
+
+	return wait, nil
+}
+
 func (h *manager) SendTransactionInfo(w http.ResponseWriter, txnID []byte, lastBlockIdx uint64, status TransactionStatus) error {
 
 	response, err := h.CreateTransactionResult(txnID, lastBlockIdx, status)
@@ -234,9 +459,15 @@ func (h *manager) SendTransactionInfo(w http.ResponseWriter, txnID []byte, lastB
 }
 
 func (h *manager) CreateTransactionResult(txnID []byte, lastBlockIdx uint64, status TransactionStatus) (TransactionClientInfo, error) {
+	return h.createToken(txnID, lastBlockIdx, status, time.Now().Unix())
+}
 
-	time := time.Now().Unix()
-	hash := hashInfos(status, txnID, lastBlockIdx, time)
+// createToken returns the transaction info of a transaction submitted at the
+// given time, in seconds since the epoch.
+func (h *manager) createToken(txnID []byte, lastBlockIdx uint64, status TransactionStatus,
+	submitted int64) (TransactionClientInfo, error) {
+
+	hash := hashInfos(status, txnID, lastBlockIdx, submitted)
 	signature, err := h.signer.Sign(hash)
 
 	if err != nil {
@@ -253,7 +484,7 @@ func (h *manager) CreateTransactionResult(txnID []byte, lastBlockIdx uint64, sta
 		Status:        status,
 		TransactionID: txnID,
 		LastBlockIdx:  lastBlockIdx,
-		Time:          time,
+		Time:          submitted,
 		Hash:          hash,
 		Signature:     signatureBin,
 	}
@@ -280,16 +511,21 @@ func SendResponse(w http.ResponseWriter, response any) error {
 
 	err := json.NewEncoder(w).Encode(response)
 	if err != nil {
-		http.Error(w, "failed to write in ResponseWriter: "+err.Error(),
-			http.StatusInternalServerError)
+		ptypes.HTTPError{
+			Title:   http.StatusText(http.StatusInternalServerError),
+			Code:    http.StatusInternalServerError,
+			Message: "failed to write in ResponseWriter: " + err.Error(),
+			Reason:  ptypes.CodeInternal,
+		}.Write(w)
 		return nil
 	}
 
 	return nil
 }
 
-// createTransaction creates a transaction with the given command and payload.
-func createTransaction(manager txn.Manager, commandType evoting.Command,
+// CreateTransaction creates a transaction of the evoting contract with the
+// given command and payload.
+func CreateTransaction(manager txn.Manager, commandType evoting.Command,
 	commandArg string, buf []byte) (txn.Transaction, error) {
 
 	args := []txn.Arg{
@@ -326,3 +562,7 @@ func hashInfos(status TransactionStatus, txnID []byte, lastBlockIdx uint64, time
 
 	return hash.Sum(nil)
 }
+
+func httpErr(w http.ResponseWriter, r *http.Request, status int, code ptypes.ErrorCode, err error) {
+	ptypes.NewHTTPError(r, status, code, err).Write(w)
+}
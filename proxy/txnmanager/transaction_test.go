@@ -0,0 +1,221 @@
+package txnmanager
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/proxy/ratelimit"
+	ptypes "go.dedis.ch/d-voting/proxy/types"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/crypto/bls"
+	sjson "go.dedis.ch/dela/serde/json"
+)
+
+func TestParseWait(t *testing.T) {
+	wait, err := parseWait(httptest.NewRequest("POST", "/evoting/forms", nil))
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), wait)
+
+	wait, err = parseWait(httptest.NewRequest("POST", "/evoting/forms?wait=10s", nil))
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, wait)
+
+	_, err = parseWait(httptest.NewRequest("POST", "/evoting/forms?wait=abc", nil))
+	require.True(t, errors.Is(err, ErrInvalidWait))
+
+	_, err = parseWait(httptest.NewRequest("POST", "/evoting/forms?wait=2m", nil))
+	require.EqualError(t, err, "invalid wait: must be between 0 and 1m0s")
+
+	_, err = parseWait(httptest.NewRequest("POST", "/evoting/forms?wait=-1s", nil))
+	require.True(t, errors.Is(err, ErrInvalidWait))
+}
+
+func TestSubmit_PoolLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Config{Pool: 1})
+	require.NoError(t, limiter.AllowTransaction(context.Background()))
+
+	h := &manager{limiter: limiter}
+
+	_, err := h.Submit(httptest.NewRequest("POST", "/evoting/forms", nil), evoting.CmdCastVote,
+		evoting.FormArg, nil)
+	require.True(t, errors.Is(err, ptypes.ErrTooManyRequests))
+
+	var limitErr *ratelimit.Error
+	require.True(t, errors.As(err, &limitErr))
+	require.Equal(t, ratelimit.ScopePool, limitErr.Scope)
+}
+
+func TestSubmitTxn_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	h := &manager{}
+
+	_, _, err := h.SubmitTxn(ctx, evoting.CmdCastVote, evoting.FormArg, nil)
+	require.EqualError(t, err, "failed to submit transaction: context canceled")
+}
+
+func TestStatusHandlerGet_Errors(t *testing.T) {
+	h := &manager{
+		context: sjson.NewContext(),
+		signer:  bls.NewSigner(),
+	}
+
+	included, err := h.CreateTransactionResult([]byte("txn"), 0, IncludedTransaction)
+	require.NoError(t, err)
+
+	other := &manager{
+		context: sjson.NewContext(),
+		signer:  bls.NewSigner(),
+	}
+
+	forged, err := other.CreateTransactionResult([]byte("txn"), 0, UnknownTransactionStatus)
+	require.NoError(t, err)
+
+	future := newToken(t, h, time.Now().Add(time.Hour))
+
+	testCases := []struct {
+		name   string
+		token  string
+		status int
+		code   ptypes.ErrorCode
+	}{
+		{"missing token", "", http.StatusInternalServerError, ptypes.CodeInternal},
+		{"not base64", "???", http.StatusBadRequest, ptypes.CodeInvalidToken},
+		{"not json", b64.URLEncoding.EncodeToString([]byte("abc")), http.StatusBadRequest, ptypes.CodeInvalidToken},
+		{"known status", included.Token, http.StatusBadRequest, ptypes.CodeInvalidToken},
+		{"forged", forged.Token, http.StatusBadRequest, ptypes.CodeInvalidToken},
+		{"from the future", future, http.StatusBadRequest, ptypes.CodeInvalidToken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/evoting/transactions/token", nil)
+			if tc.token != "" {
+				r = mux.SetURLVars(r, map[string]string{"token": tc.token})
+			}
+
+			rec := httptest.NewRecorder()
+			h.StatusHandlerGet(rec, r)
+
+			require.Equal(t, tc.status, rec.Code)
+
+			var httpErr ptypes.HTTPError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &httpErr))
+			require.Equal(t, uint(tc.status), httpErr.Code)
+			require.Equal(t, tc.code, httpErr.Reason)
+		})
+	}
+}
+
+func TestStatusHandlerGet_Expired(t *testing.T) {
+	idx := newTxnIndex(time.Hour, 10)
+	idx.add(ordering.Event{Index: 0})
+
+	h := &manager{
+		context:   sjson.NewContext(),
+		signer:    bls.NewSigner(),
+		retention: time.Minute,
+		index:     idx,
+	}
+
+	token := newToken(t, h, time.Now().Add(-2*time.Minute))
+
+	// the transaction is still unknown, so the token expired
+	info := getStatus(t, h, token)
+	require.Equal(t, RejectedTransaction, info.Status)
+	require.Equal(t, CodeExpired, info.Code)
+
+	// the transaction was included, so it is not expired
+	idx.add(ordering.Event{
+		Index:        1,
+		Transactions: []validation.TransactionResult{fakeResult{id: "txn", accepted: true}},
+	})
+
+	info = getStatus(t, h, token)
+	require.Equal(t, IncludedTransaction, info.Status)
+	require.Equal(t, uint64(1), info.BlockIndex)
+}
+
+func TestStatusHandlerGet_PollingDoesNotDelayExpiration(t *testing.T) {
+	idx := newTxnIndex(time.Hour, 10)
+	idx.add(ordering.Event{Index: 0})
+
+	h := &manager{
+		context:   sjson.NewContext(),
+		signer:    bls.NewSigner(),
+		retention: time.Minute,
+		index:     idx,
+	}
+
+	submitted := time.Now().Add(-50 * time.Second)
+	token := newToken(t, h, submitted)
+
+	// each poll returns a new token with the time of the submission
+	for i := 0; i < 3; i++ {
+		info := getStatus(t, h, token)
+		require.Equal(t, UnknownTransactionStatus, info.Status)
+
+		content, err := decodeToken(info.Token)
+		require.NoError(t, err)
+		require.Equal(t, submitted.Unix(), content.Time)
+
+		token = info.Token
+	}
+
+	// the last token expires as the first one would
+	h.retention = 40 * time.Second
+
+	info := getStatus(t, h, token)
+	require.Equal(t, RejectedTransaction, info.Status)
+	require.Equal(t, CodeExpired, info.Code)
+}
+
+// getStatus returns the status of the transaction of the token.
+func getStatus(t *testing.T, h *manager, token string) TransactionClientInfo {
+	r := httptest.NewRequest(http.MethodGet, "/evoting/transactions/token", nil)
+	r = mux.SetURLVars(r, map[string]string{"token": token})
+
+	rec := httptest.NewRecorder()
+	h.StatusHandlerGet(rec, r)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var info TransactionClientInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+
+	return info
+}
+
+// newToken returns the token of a pending transaction submitted at the given
+// time.
+func newToken(t *testing.T, h *manager, submitted time.Time) string {
+	hash := hashInfos(UnknownTransactionStatus, []byte("txn"), 0, submitted.Unix())
+
+	signature, err := h.signer.Sign(hash)
+	require.NoError(t, err)
+
+	signatureBuf, err := signature.Serialize(h.context)
+	require.NoError(t, err)
+
+	buf, err := json.Marshal(transactionInternalInfo{
+		Status:        UnknownTransactionStatus,
+		TransactionID: []byte("txn"),
+		Time:          submitted.Unix(),
+		Hash:          hash,
+		Signature:     signatureBuf,
+	})
+	require.NoError(t, err)
+
+	return b64.URLEncoding.EncodeToString(buf)
+}
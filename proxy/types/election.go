@@ -14,6 +14,12 @@ type CreateFormRequest struct {
 type CreateFormResponse struct {
 	FormID string // hex-encoded
 	Token  string
+	// Status, BlockIndex, Code and Message are only set if the request waited for
+	// the transaction to be included, see the "wait" query parameter
+	Status     byte   `json:",omitempty"`
+	BlockIndex uint64 `json:",omitempty"`
+	Code       string `json:",omitempty"`
+	Message    string `json:",omitempty"`
 }
 
 // CastVoteRequest defines the HTTP request for casting a vote
@@ -23,6 +29,12 @@ type CastVoteRequest struct {
 	Ballot CiphervoteJSON
 }
 
+// CastVotesRequest defines the HTTP request for casting a batch of votes in
+// a single transaction
+type CastVotesRequest struct {
+	Votes []CastVoteRequest
+}
+
 // CiphervoteJSON is the JSON representation of a ciphervote
 type CiphervoteJSON []EGPairJSON
 
@@ -35,6 +47,11 @@ type EGPairJSON struct {
 // UpdateFormRequest defines the HTTP request for updating a form
 type UpdateFormRequest struct {
 	Action string
+	// Configuration is only used by the "updateConfiguration" action
+	Configuration *etypes.Configuration `json:",omitempty"`
+	// VoterWeights is only used by the "setVoterWeights" action. It lists
+	// the UserID of each voter with the weight of its ballot.
+	VoterWeights []etypes.VoterWeight `json:",omitempty"`
 }
 
 // GetFormResponse defines the HTTP response when getting the form info
@@ -49,6 +66,7 @@ type GetFormResponse struct {
 	ChunksPerBallot int
 	BallotSize      int
 	Voters          []string
+	VoterWeights    map[string]uint32 `json:",omitempty"`
 }
 
 // LightForm represents a light version of the form
@@ -63,12 +81,7 @@ type LightForm struct {
 // infos.
 type GetFormsResponse struct {
 	Forms []LightForm
-}
-
-// HTTPError defines the standard error format
-type HTTPError struct {
-	Title   string
-	Code    uint
-	Message string
-	Args    map[string]interface{}
+	// NextCursor is the cursor to get the next page, empty if this is the
+	// last page
+	NextCursor string `json:",omitempty"`
 }
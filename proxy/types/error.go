@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotVerified is wrapped by the error of GetAndVerify when the verifier
+// doesn't accept the signed request.
+var ErrNotVerified = errors.New("failed to verify")
+
+// ErrTooManyRequests is wrapped by the error of a verifier that can't accept
+// more requests for now.
+var ErrTooManyRequests = errors.New("too many requests")
+
+// ErrorCode is a stable code identifying why the proxy refused a request.
+// Clients should rely on it rather than on the message of the error.
+type ErrorCode string
+
+const (
+	// CodeInternal is the code of an error of the node
+	CodeInternal ErrorCode = "INTERNAL"
+	// CodeInvalidRequest is the code of a request whose body can't be decoded
+	// or is incomplete
+	CodeInvalidRequest ErrorCode = "INVALID_REQUEST"
+	// CodeInvalidSignature is the code of a signed request that is not signed
+	// by an accepted key, or that is stale or replayed
+	CodeInvalidSignature ErrorCode = "INVALID_SIGNATURE"
+	// CodeTooManyRequests is the code of a request refused because the proxy
+	// is overloaded
+	CodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+	// CodeNodeStopping is the code of a request refused because the node is
+	// stopping
+	CodeNodeStopping ErrorCode = "NODE_STOPPING"
+	// CodeInvalidFormID is the code of a form ID that is not hex-encoded
+	CodeInvalidFormID ErrorCode = "INVALID_FORM_ID"
+	// CodeFormNotFound is the code of a form that doesn't exist
+	CodeFormNotFound ErrorCode = "FORM_NOT_FOUND"
+	// CodeInvalidFormStatus is the code of an action that the status of the
+	// form doesn't allow
+	CodeInvalidFormStatus ErrorCode = "INVALID_FORM_STATUS"
+	// CodeInvalidAction is the code of an unknown action
+	CodeInvalidAction ErrorCode = "INVALID_ACTION"
+	// CodeInvalidBallot is the code of a ballot that can't be decoded
+	CodeInvalidBallot ErrorCode = "INVALID_BALLOT"
+	// CodeInvalidBatch is the code of a batch of votes that is empty or too
+	// big
+	CodeInvalidBatch ErrorCode = "INVALID_BATCH"
+	// CodeInvalidQuery is the code of invalid query parameters
+	CodeInvalidQuery ErrorCode = "INVALID_QUERY"
+	// CodeInvalidWait is the code of an invalid "wait" query parameter
+	CodeInvalidWait ErrorCode = "INVALID_WAIT"
+	// CodeInvalidToken is the code of a transaction token that is malformed,
+	// forged, or whose status is already known
+	CodeInvalidToken ErrorCode = "INVALID_TOKEN"
+	// CodeTemplateNotFound is the code of a template, or a version of a
+	// template, that doesn't exist
+	CodeTemplateNotFound ErrorCode = "TEMPLATE_NOT_FOUND"
+	// CodeActorNotFound is the code of a DKG actor that is not started on the
+	// node
+	CodeActorNotFound ErrorCode = "ACTOR_NOT_FOUND"
+	// CodeRouteNotFound is the code of a request to an unknown endpoint
+	CodeRouteNotFound ErrorCode = "ROUTE_NOT_FOUND"
+	// CodeMethodNotAllowed is the code of a request with a method that the
+	// endpoint doesn't support
+	CodeMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+)
+
+// HTTPError defines the standard error format
+type HTTPError struct {
+	Title   string
+	Code    uint
+	Message string
+	Args    map[string]interface{}
+	// Reason is the stable code of the error, it is empty for the errors that
+	// are not the response to a request
+	Reason ErrorCode `json:",omitempty"`
+}
+
+// NewHTTPError returns the error of the response to r with the given HTTP
+// status. The URL and the method of the request are in the arguments, along
+// with the error.
+func NewHTTPError(r *http.Request, status int, reason ErrorCode, err error) HTTPError {
+	return HTTPError{
+		Title:   http.StatusText(status),
+		Code:    uint(status),
+		Message: err.Error(),
+		Args: map[string]interface{}{
+			"error":  err.Error(),
+			"url":    r.URL.String(),
+			"method": r.Method,
+		},
+		Reason: reason,
+	}
+}
+
+// Write writes the error as the JSON response, with its code as the HTTP
+// status.
+func (e HTTPError) Write(w http.ResponseWriter) {
+	buf, _ := json.MarshalIndent(&e, "", "  ")
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(int(e.Code))
+	fmt.Fprintln(w, string(buf))
+}
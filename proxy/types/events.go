@@ -0,0 +1,27 @@
+package types
+
+// FormEvent is the "form" event sent when the state of a subscribed form
+// changes.
+type FormEvent struct {
+	FormID string
+	// BlockIndex is the index of the block that changed the form, it is 0 for
+	// the event sent with the current state at subscription
+	BlockIndex           uint64
+	Status               uint16
+	BallotCount          uint32
+	ShuffleRounds        int
+	PubsharesSubmissions int
+}
+
+// TransactionEvent is the "transaction" event sent when a subscribed
+// transaction is included in a block.
+type TransactionEvent struct {
+	Token      string
+	BlockIndex uint64
+	// Status is 1 if the transaction was accepted and 2 if it was rejected
+	Status byte
+	// Code identifies the reason why the transaction was rejected
+	Code string `json:",omitempty"`
+	// Message is the reason why the transaction was rejected
+	Message string `json:",omitempty"`
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/sign/schnorr"
@@ -32,6 +34,115 @@ func NewSignedRequest(r io.Reader) (SignedRequest, error) {
 type SignedRequest struct {
 	Payload   string // url base64 encoded json message
 	Signature string // hex encoded signature on sha256(Payload)
+	// KeyID is the ID of the key of the keyring that signed the request. It is
+	// empty if the request is signed by the default key of the proxy.
+	KeyID string `json:",omitempty"`
+}
+
+// RequestMetadata are the fields of a signed payload that protect it against
+// replays. They are set in the JSON payload along with the fields of the
+// request.
+type RequestMetadata struct {
+	// Timestamp is the UNIX time, in seconds, at which the request was signed
+	Timestamp int64 `json:",omitempty"`
+	// Nonce is a random string unique to the request
+	Nonce string `json:",omitempty"`
+	// Method is the HTTP method of the request, for example "PUT"
+	Method string `json:",omitempty"`
+	// Path is the path of the URL of the request, for example
+	// "/evoting/forms/<formID>"
+	Path string `json:",omitempty"`
+}
+
+// IsLegacy returns true if the payload has none of the replay protection
+// fields. Such payloads are deprecated.
+func (m RequestMetadata) IsLegacy() bool {
+	return m == RequestMetadata{}
+}
+
+// Match returns an error if the request was signed for another HTTP method or
+// path than the ones of the given request.
+func (m RequestMetadata) Match(r *http.Request) error {
+	if m.Method != r.Method {
+		return xerrors.Errorf("the request was signed for method %q, not %q",
+			m.Method, r.Method)
+	}
+
+	if m.Path != r.URL.Path {
+		return xerrors.Errorf("the request was signed for path %q, not %q",
+			m.Path, r.URL.Path)
+	}
+
+	return nil
+}
+
+// Verifier verifies the signed requests
+type Verifier interface {
+	// Key returns the accepted key with the given ID. The empty ID is the
+	// default key.
+	Key(keyID string) (kyber.Point, error)
+
+	// Verify returns an error if the signed request is not signed by an
+	// accepted key, if it was signed for another HTTP request than r, or if it
+	// can't be accepted anymore.
+	Verify(req SignedRequest, r *http.Request) error
+
+	// AllowsLegacy returns true if the deprecated requests, which can be
+	// replayed, are accepted.
+	AllowsLegacy() bool
+}
+
+// NewKeyVerifier returns a verifier that accepts the requests signed by the
+// given key.
+func NewKeyVerifier(pk kyber.Point) Verifier {
+	return keyVerifier{pk: pk}
+}
+
+// keyVerifier verifies the requests with a single key
+//
+// - implements Verifier
+type keyVerifier struct {
+	pk kyber.Point
+}
+
+// Key implements Verifier. Only the default key is accepted.
+func (v keyVerifier) Key(keyID string) (kyber.Point, error) {
+	if keyID != "" {
+		return nil, xerrors.Errorf("unknown key %q", keyID)
+	}
+
+	return v.pk, nil
+}
+
+// AllowsLegacy implements Verifier. The deprecated requests are refused.
+func (v keyVerifier) AllowsLegacy() bool {
+	return false
+}
+
+// Verify implements Verifier. The payload must have the HTTP method and path
+// of the request, but the replays are not detected.
+func (v keyVerifier) Verify(s SignedRequest, r *http.Request) error {
+	pk, err := v.Key(s.KeyID)
+	if err != nil {
+		return err
+	}
+
+	err = s.Verify(pk)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := s.GetMetadata()
+	if err != nil {
+		return err
+	}
+
+	if metadata.IsLegacy() {
+		return xerrors.Errorf("the request must have a timestamp, a nonce, " +
+			"a method and a path")
+	}
+
+	return metadata.Match(r)
 }
 
 // GetMessage JSON unmarshals the payload to the given element. The given
@@ -50,6 +161,18 @@ func (s SignedRequest) GetMessage(el interface{}) error {
 	return nil
 }
 
+// GetMetadata returns the replay protection fields of the payload.
+func (s SignedRequest) GetMetadata() (RequestMetadata, error) {
+	var metadata RequestMetadata
+
+	err := s.GetMessage(&metadata)
+	if err != nil {
+		return metadata, xerrors.Errorf("failed to get metadata: %v", err)
+	}
+
+	return metadata, nil
+}
+
 // Verify checks the signature. The signature should be on the sha256 of the
 // payload.
 func (s SignedRequest) Verify(pk kyber.Point) error {
@@ -75,12 +198,12 @@ func (s SignedRequest) Verify(pk kyber.Point) error {
 	return nil
 }
 
-// GetAndVerify is a shorthand function to verify the signed request and extract
-// the payload. el MUST be a pointer.
-func (s SignedRequest) GetAndVerify(pk kyber.Point, el interface{}) error {
-	err := s.Verify(pk)
+// GetAndVerify is a shorthand function to verify the signed request sent with
+// the HTTP request r and extract the payload. el MUST be a pointer.
+func (s SignedRequest) GetAndVerify(v Verifier, r *http.Request, el interface{}) error {
+	err := v.Verify(s, r)
 	if err != nil {
-		return xerrors.Errorf("failed to verify: %v", err)
+		return fmt.Errorf("%w: %w", ErrNotVerified, err)
 	}
 
 	err = s.GetMessage(el)
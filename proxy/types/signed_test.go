@@ -5,6 +5,8 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -152,7 +154,7 @@ func TestGetAndVerify_verify_invalid_signature(t *testing.T) {
 
 	var req map[string]interface{}
 
-	err := signed.GetAndVerify(pk, &req)
+	err := signed.GetAndVerify(NewKeyVerifier(pk), newHTTPRequest(), &req)
 	require.EqualError(t, err, "failed to verify: cannot verify empty payload")
 }
 
@@ -177,9 +179,9 @@ func TestGetAndVerify_wrong_message(t *testing.T) {
 
 	var req map[string]interface{}
 
-	err = signed.GetAndVerify(pk, &req)
-	require.EqualError(t, err, "failed to get message: failed to unmarshal "+
-		"json \"{invalid json}\" to *map[string]interface {}: invalid "+
+	err = signed.GetAndVerify(NewKeyVerifier(pk), newHTTPRequest(), &req)
+	require.EqualError(t, err, "failed to verify: failed to get metadata: "+
+		"failed to unmarshal json \"{invalid json}\" to *types.RequestMetadata: invalid "+
 		"character 'i' looking for beginning of object key string")
 }
 
@@ -187,7 +189,7 @@ func TestGetAndVerify_ok(t *testing.T) {
 	secret := suite.Scalar().Pick(suite.RandomStream())
 	pk := suite.Point().Mul(secret, nil)
 
-	msg := `{"Foo": "bar"}`
+	msg := `{"Foo": "bar", "Method": "PUT", "Path": "/evoting/forms/aa"}`
 	payload := base64.URLEncoding.EncodeToString([]byte(msg))
 
 	hash := sha256.New()
@@ -208,7 +210,7 @@ func TestGetAndVerify_ok(t *testing.T) {
 
 	var req dummy
 
-	err = signed.GetAndVerify(pk, &req)
+	err = signed.GetAndVerify(NewKeyVerifier(pk), newHTTPRequest(), &req)
 	require.NoError(t, err)
 
 	expected := dummy{
@@ -217,3 +219,67 @@ func TestGetAndVerify_ok(t *testing.T) {
 
 	require.Equal(t, expected, req)
 }
+
+func TestGetAndVerify_wrong_request(t *testing.T) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pk := suite.Point().Mul(secret, nil)
+
+	msg := `{"Foo": "bar", "Method": "DELETE", "Path": "/evoting/forms/aa"}`
+	payload := base64.URLEncoding.EncodeToString([]byte(msg))
+
+	hash := sha256.Sum256([]byte(payload))
+
+	signature, err := schnorr.Sign(suite, secret, hash[:])
+	require.NoError(t, err)
+
+	signed := SignedRequest{
+		Signature: hex.EncodeToString(signature),
+		Payload:   payload,
+	}
+
+	var req map[string]interface{}
+
+	err = signed.GetAndVerify(NewKeyVerifier(pk), newHTTPRequest(), &req)
+	require.EqualError(t, err, `failed to verify: the request was signed for `+
+		`method "DELETE", not "PUT"`)
+
+	r := httptest.NewRequest(http.MethodDelete, "/evoting/forms/bb", nil)
+
+	err = signed.GetAndVerify(NewKeyVerifier(pk), r, &req)
+	require.EqualError(t, err, `failed to verify: the request was signed for `+
+		`path "/evoting/forms/aa", not "/evoting/forms/bb"`)
+
+	r = httptest.NewRequest(http.MethodDelete, "/evoting/forms/aa", nil)
+
+	err = signed.GetAndVerify(NewKeyVerifier(pk), r, &req)
+	require.NoError(t, err)
+}
+
+func TestGetAndVerify_legacy(t *testing.T) {
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	pk := suite.Point().Mul(secret, nil)
+
+	payload := base64.URLEncoding.EncodeToString([]byte(`{"Foo": "bar"}`))
+	hash := sha256.Sum256([]byte(payload))
+
+	signature, err := schnorr.Sign(suite, secret, hash[:])
+	require.NoError(t, err)
+
+	signed := SignedRequest{
+		Signature: hex.EncodeToString(signature),
+		Payload:   payload,
+	}
+
+	var req map[string]interface{}
+
+	err = signed.GetAndVerify(NewKeyVerifier(pk), newHTTPRequest(), &req)
+	require.EqualError(t, err, "failed to verify: the request must have a "+
+		"timestamp, a nonce, a method and a path")
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+func newHTTPRequest() *http.Request {
+	return httptest.NewRequest(http.MethodPut, "/evoting/forms/aa", nil)
+}
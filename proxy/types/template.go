@@ -0,0 +1,57 @@
+package types
+
+import (
+	etypes "go.dedis.ch/d-voting/contracts/evoting/types"
+)
+
+// CloneFormRequest defines the HTTP request for creating a form with the
+// configuration of an existing form
+type CloneFormRequest struct {
+	AdminID string
+	// Title, AdditionalInfo and Schedule override the ones of the cloned form if
+	// set
+	Title          *etypes.Title    `json:",omitempty"`
+	AdditionalInfo etypes.LangMap   `json:",omitempty"`
+	Schedule       *etypes.Schedule `json:",omitempty"`
+}
+
+// CreateTemplateRequest defines the HTTP request for saving a new version of
+// a template
+type CreateTemplateRequest struct {
+	Name          string
+	AdminID       string
+	Configuration etypes.Configuration
+}
+
+// CreateTemplateFormRequest defines the HTTP request for creating a form from
+// a template
+type CreateTemplateFormRequest struct {
+	AdminID string
+	// Version is the version of the template, 0 for the latest one
+	Version uint
+	// Title, AdditionalInfo and Schedule override the ones of the template if
+	// set
+	Title          *etypes.Title    `json:",omitempty"`
+	AdditionalInfo etypes.LangMap   `json:",omitempty"`
+	Schedule       *etypes.Schedule `json:",omitempty"`
+}
+
+// LightTemplate represents a light version of a template
+type LightTemplate struct {
+	Name string
+	// Version is the latest version of the template
+	Version uint
+	Title   etypes.Title
+}
+
+// GetTemplatesResponse defines the HTTP response when getting all templates
+type GetTemplatesResponse struct {
+	Templates []LightTemplate
+}
+
+// GetTemplateResponse defines the HTTP response when getting a version of a
+// template
+type GetTemplateResponse struct {
+	Name string
+	etypes.TemplateVersion
+}
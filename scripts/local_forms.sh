@@ -4,7 +4,7 @@ SCRIPT_DIR=$(cd -- "$(dirname -- "${BASH_SOURCE[0]}")" &>/dev/null && pwd)
 . "$SCRIPT_DIR/local_login.sh"
 
 echo "add form"
-FORMJSON='{"Configuration":{"Title":{"En":"title","Fr":"","De":"","URL":""},"Scaffold":[{"ID":"ozCI7gKv","Title":{"En":"subtitle","Fr":"","De":"","URL":""},"Order":["nVjQ0jMK"],"Ranks":[],"Selects":[{"ID":"nVjQ0jMK","Title":{"En":"vote","Fr":"","De":"","URL":""},"MaxN":3,"MinN":1,"Choices":[{"Choice":"{\"en\":\"one\"}","URL":""},{"Choice":"{\"en\":\"two\"}","URL":""},{"Choice":"{\"en\":\"three\"}","URL":""}],"Hint":{"En":"","Fr":"","De":""}}],"Texts":[],"Subjects":[]}],"AdditionalInfo":""}}'
+FORMJSON='{"Configuration":{"Title":{"en":"title","URL":""},"Scaffold":[{"ID":"ozCI7gKv","Title":{"en":"subtitle","URL":""},"Order":["nVjQ0jMK"],"Ranks":[],"Selects":[{"ID":"nVjQ0jMK","Title":{"en":"vote","URL":""},"MaxN":3,"MinN":1,"Choices":[{"Choice":{"en":"one"},"URL":""},{"Choice":{"en":"two"},"URL":""},{"Choice":{"en":"three"},"URL":""}],"Hint":{}}],"Texts":[],"Subjects":[]}],"AdditionalInfo":""}}'
 RESP=$(curl -sk "$FRONTEND_URL/api/evoting/forms" -X POST -H 'Content-Type: application/json' -b cookies.txt --data-raw "$FORMJSON")
 FORMID=$(echo "$RESP" | jq -r .FormID)
 echo "FORMID=$FORMID" > "$SCRIPT_DIR/formid.env"
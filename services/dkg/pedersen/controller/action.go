@@ -24,6 +24,8 @@ import (
 	"golang.org/x/xerrors"
 
 	eproxy "go.dedis.ch/d-voting/proxy"
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/keyring"
 )
 
 var suite = suites.MustFind("Ed25519")
@@ -262,23 +264,42 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 
 	mngr := signed.NewManager(signer, &client)
 
-	proxykeyHex := ctx.Flags.String("proxykey")
+	var keys *keyring.Keyring
+	err = ctx.Injector.Resolve(&keys)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
+	}
+
+	// the proxy key is the default key of the keyring
+	err = keys.SetDefault(ctx.Flags.String("proxykey"))
+	if err != nil {
+		return xerrors.Errorf("failed to set proxy key: %v", err)
+	}
 
-	proxykeyBuf, err := hex.DecodeString(proxykeyHex)
+	var tracker *drain.Tracker
+	err = ctx.Injector.Resolve(&tracker)
 	if err != nil {
-		return xerrors.Errorf("failed to decode proxykeyHex: %v", err)
+		return xerrors.Errorf("failed to resolve tracker: %v", err)
 	}
 
-	proxykey := suite.Point()
+	router := newRouter(eproxy.NewDKG(mngr, dkg, keys, tracker))
+
+	proxy.RegisterHandler("/evoting/services/dkg/", tracker.Handler(router).ServeHTTP)
 
-	err = proxykey.UnmarshalBinary(proxykeyBuf)
+	err = eproxy.ResumeDKGSetups(dkg, tracker)
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
+		return xerrors.Errorf("failed to resume DKG setups: %v", err)
 	}
 
-	router := mux.NewRouter()
+	dela.Logger.Info().Msg("DKG handler registered")
 
-	ep := eproxy.NewDKG(mngr, dkg, proxykey)
+	return nil
+}
+
+// newRouter returns the router of the DKG handlers. Each route must be
+// described in eproxy.Operations.
+func newRouter(ep eproxy.DKG) *mux.Router {
+	router := mux.NewRouter()
 
 	// Link the request to the proxy
 	router.HandleFunc("/evoting/services/dkg/actors", ep.NewDKGActor).Methods("POST")
@@ -290,11 +311,7 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
 	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
 
-	proxy.RegisterHandler("/evoting/services/dkg/", router.ServeHTTP)
-
-	dela.Logger.Info().Msg("DKG handler registered")
-
-	return nil
+	return router
 }
 
 func makeClient(inj node.Injector) (client, error) {
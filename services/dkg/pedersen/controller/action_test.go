@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/d-voting/internal/testing/fake"
+	eproxy "go.dedis.ch/d-voting/proxy"
 	"go.dedis.ch/d-voting/services/dkg"
 	"go.dedis.ch/dela/cli"
 	"go.dedis.ch/dela/cli/node"
@@ -16,7 +17,8 @@ import (
 
 func TestInitAction_Execute(t *testing.T) {
 
-	flags := fakeFlags{strings: make(map[string]string)}
+	// the private key of the node is created in the config directory
+	flags := fakeFlags{strings: map[string]string{"config": t.TempDir()}}
 
 	ctx := node.Context{
 		Injector: node.NewInjector(),
@@ -123,6 +125,12 @@ func TestExportInfoAction_Execute(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewRouter_OpenAPI(t *testing.T) {
+	missing, err := eproxy.MissingOperations(newRouter(eproxy.NewDKG(nil, nil, nil, nil)))
+	require.NoError(t, err)
+	require.Empty(t, missing, "routes without an OpenAPI operation in proxy/openapi.go")
+}
+
 // -----------------------------------------------------------------------------
 // Utility functions
 
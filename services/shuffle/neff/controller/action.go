@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"encoding/hex"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -17,6 +16,8 @@ import (
 	"golang.org/x/xerrors"
 
 	eproxy "go.dedis.ch/d-voting/proxy"
+	"go.dedis.ch/d-voting/proxy/drain"
+	"go.dedis.ch/d-voting/proxy/keyring"
 )
 
 var suite = suites.MustFind("ed25519")
@@ -82,30 +83,27 @@ func (a *RegisterHandlersAction) Execute(ctx node.Context) error {
 		return xerrors.Errorf("failed to resolve dkg.DKG: %v", err)
 	}
 
-	proxykeyHex := ctx.Flags.String("proxykey")
-
-	proxykeyBuf, err := hex.DecodeString(proxykeyHex)
+	var keys *keyring.Keyring
+	err = ctx.Injector.Resolve(&keys)
 	if err != nil {
-		return xerrors.Errorf("failed to decode proxykeyHex: %v", err)
+		return xerrors.Errorf("failed to resolve keyring: %v", err)
 	}
 
-	proxykey := suite.Point()
-
-	err = proxykey.UnmarshalBinary(proxykeyBuf)
+	// the proxy key is the default key of the keyring
+	err = keys.SetDefault(ctx.Flags.String("proxykey"))
 	if err != nil {
-		return xerrors.Errorf("failed to unmarshal proxy key: %v", err)
+		return xerrors.Errorf("failed to set proxy key: %v", err)
 	}
 
-	router := mux.NewRouter()
-
-	ep := eproxy.NewShuffle(actor, proxykey)
-
-	router.HandleFunc("/evoting/services/shuffle/{formID}", ep.EditShuffle).Methods("PUT")
+	var tracker *drain.Tracker
+	err = ctx.Injector.Resolve(&tracker)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve tracker: %v", err)
+	}
 
-	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
-	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
+	router := newRouter(eproxy.NewShuffle(actor, keys))
 
-	proxy.RegisterHandler("/evoting/services/shuffle/", router.ServeHTTP)
+	proxy.RegisterHandler("/evoting/services/shuffle/", tracker.Handler(router).ServeHTTP)
 
 	dela.Logger.Info().Msg("DKG handler registered")
 
@@ -153,3 +151,16 @@ func (c *client) GetNonce(id access.Identity) (uint64, error) {
 
 	return nonce, nil
 }
+
+// newRouter returns the router of the shuffle handlers. Each route must be
+// described in eproxy.Operations.
+func newRouter(ep eproxy.Shuffle) *mux.Router {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/evoting/services/shuffle/{formID}", ep.EditShuffle).Methods("PUT")
+
+	router.NotFoundHandler = http.HandlerFunc(eproxy.NotFoundHandler)
+	router.MethodNotAllowedHandler = http.HandlerFunc(eproxy.NotAllowedHandler)
+
+	return router
+}
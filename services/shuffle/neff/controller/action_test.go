@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	eproxy "go.dedis.ch/d-voting/proxy"
 	"go.dedis.ch/dela/cli/node"
 )
 
@@ -21,3 +22,9 @@ func TestInitAction_Execute(t *testing.T) {
 	require.EqualError(t, err, "failed to resolve shuffle: couldn't find "+
 		"dependency for 'shuffle.Shuffle'")
 }
+
+func TestNewRouter_OpenAPI(t *testing.T) {
+	missing, err := eproxy.MissingOperations(newRouter(eproxy.NewShuffle(nil, nil)))
+	require.NoError(t, err)
+	require.Empty(t, missing, "routes without an OpenAPI operation in proxy/openapi.go")
+}
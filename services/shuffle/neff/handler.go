@@ -177,9 +177,9 @@ func (h *Handler) makeTx(form *etypes.Form) (txn.Transaction, error) {
 		return nil, xerrors.Errorf("could not create semi-random stream: %v", err)
 	}
 
-	e := make([]kyber.Scalar, form.ChunksPerBallot())
+	e := make([]kyber.Scalar, form.CiphervoteSize())
 
-	for i := 0; i < form.ChunksPerBallot(); i++ {
+	for i := 0; i < form.CiphervoteSize(); i++ {
 		v := suite.Scalar().Pick(semiRandomStream)
 		e[i] = v
 	}
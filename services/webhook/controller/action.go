@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"fmt"
+
+	"go.dedis.ch/d-voting/services/webhook"
+	"go.dedis.ch/dela/cli/node"
+	"golang.org/x/xerrors"
+)
+
+// addAction is an action to add a webhook
+//
+// - implements node.ActionTemplate
+type addAction struct{}
+
+// Execute implements node.ActionTemplate. It saves the webhook and prints its
+// ID and secret.
+func (a *addAction) Execute(ctx node.Context) error {
+	var webhooks *webhook.Service
+	err := ctx.Injector.Resolve(&webhooks)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve webhook service: %v", err)
+	}
+
+	events := webhook.Events
+
+	names := ctx.Flags.StringSlice("events")
+	if len(names) > 0 {
+		events = make([]webhook.Event, len(names))
+
+		for i, name := range names {
+			events[i], err = webhook.ParseEvent(name)
+			if err != nil {
+				return xerrors.Errorf("invalid events: %v", err)
+			}
+		}
+	}
+
+	hook, err := webhooks.Add(ctx.Flags.String("url"), events, ctx.Flags.String("secret"))
+	if err != nil {
+		return xerrors.Errorf("failed to add webhook: %v", err)
+	}
+
+	fmt.Fprintf(ctx.Out, "id: %s\nsecret: %s\n", hook.ID, hook.Secret)
+
+	return nil
+}
+
+// removeAction is an action to remove a webhook
+//
+// - implements node.ActionTemplate
+type removeAction struct{}
+
+// Execute implements node.ActionTemplate.
+func (a *removeAction) Execute(ctx node.Context) error {
+	var webhooks *webhook.Service
+	err := ctx.Injector.Resolve(&webhooks)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve webhook service: %v", err)
+	}
+
+	err = webhooks.Remove(ctx.Flags.String("id"))
+	if err != nil {
+		return xerrors.Errorf("failed to remove webhook: %v", err)
+	}
+
+	return nil
+}
+
+// listAction is an action to list the webhooks
+//
+// - implements node.ActionTemplate
+type listAction struct{}
+
+// Execute implements node.ActionTemplate. It prints one webhook per line.
+func (a *listAction) Execute(ctx node.Context) error {
+	var webhooks *webhook.Service
+	err := ctx.Injector.Resolve(&webhooks)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve webhook service: %v", err)
+	}
+
+	for _, hook := range webhooks.List() {
+		fmt.Fprintln(ctx.Out, hook)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/services/webhook"
+	"go.dedis.ch/dela/cli"
+	"go.dedis.ch/dela/cli/node"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/store/kv"
+	"go.dedis.ch/dela/serde/json"
+	"golang.org/x/xerrors"
+)
+
+// NewController returns a new controller initializer
+func NewController() node.Initializer {
+	return controller{}
+}
+
+// controller is an initializer with a set of commands.
+//
+// - implements node.Initializer
+type controller struct{}
+
+// SetCommands implements node.Initializer.
+func (m controller) SetCommands(builder node.Builder) {
+	cmd := builder.SetCommand("webhooks")
+	cmd.SetDescription("manage the webhooks notified of the forms lifecycle events")
+
+	// dvoting --config /tmp/node1 webhooks add --url http://localhost:8080 \
+	//   --events form.closed --events form.resultAvailable
+	sub := cmd.SetSubCommand("add")
+	sub.SetDescription("add a webhook")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "url",
+			Usage:    "the URL to POST the events to",
+			Required: true,
+		},
+		cli.StringSliceFlag{
+			Name:     "events",
+			Usage:    "the events to subscribe to, all by default",
+			Required: false,
+		},
+		cli.StringFlag{
+			Name:     "secret",
+			Usage:    "the key used to sign the payloads, randomly created by default",
+			Required: false,
+		},
+	)
+	sub.SetAction(builder.MakeAction(&addAction{}))
+
+	// dvoting --config /tmp/node1 webhooks remove --id id
+	sub = cmd.SetSubCommand("remove")
+	sub.SetDescription("remove a webhook")
+	sub.SetFlags(
+		cli.StringFlag{
+			Name:     "id",
+			Usage:    "the ID of the webhook",
+			Required: true,
+		},
+	)
+	sub.SetAction(builder.MakeAction(&removeAction{}))
+
+	// dvoting --config /tmp/node1 webhooks list
+	sub = cmd.SetSubCommand("list")
+	sub.SetDescription("list the webhooks")
+	sub.SetAction(builder.MakeAction(&listAction{}))
+}
+
+// OnStart implements node.Initializer. It creates the webhook service and
+// starts to watch the blocks.
+func (m controller) OnStart(ctx cli.Flags, inj node.Injector) error {
+	var service ordering.Service
+	err := inj.Resolve(&service)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve ordering.Service: %v", err)
+	}
+
+	var db kv.DB
+	err = inj.Resolve(&db)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve db: %v", err)
+	}
+
+	var rosterFac authority.Factory
+	err = inj.Resolve(&rosterFac)
+	if err != nil {
+		return xerrors.Errorf("failed to resolve authority.Factory: %v", err)
+	}
+
+	ciphervoteFac := types.CiphervoteFactory{}
+	formFac := types.NewFormFactory(ciphervoteFac, rosterFac)
+	txFac := types.NewTransactionFactory(ciphervoteFac)
+
+	webhooks, err := webhook.NewService(service, json.NewContext(), formFac, txFac, db)
+	if err != nil {
+		return xerrors.Errorf("failed to create webhook service: %v", err)
+	}
+
+	webhooks.Listen()
+
+	inj.Inject(webhooks)
+
+	return nil
+}
+
+// OnStop implements node.Initializer. It stops the webhook service.
+func (controller) OnStop(inj node.Injector) error {
+	var webhooks *webhook.Service
+
+	err := inj.Resolve(&webhooks)
+	if err == nil {
+		webhooks.Stop()
+	}
+
+	return nil
+}
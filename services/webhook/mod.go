@@ -0,0 +1,115 @@
+// Package webhook implements a node-side service that notifies HTTP endpoints
+// of the lifecycle events of the forms. The service watches the blocks of the
+// ordering service and POSTs a signed JSON payload to the webhooks that
+// subscribed to the event.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// Event is a lifecycle event of a form
+type Event string
+
+const (
+	// FormCreated is sent when a form is created
+	FormCreated Event = "form.created"
+	// FormOpened is sent when a form is opened
+	FormOpened Event = "form.opened"
+	// FormClosed is sent when a form is closed
+	FormClosed Event = "form.closed"
+	// FormShuffled is sent when the last shuffle of a form is done
+	FormShuffled Event = "form.shuffled"
+	// FormPubSharesSubmitted is sent when all the nodes submitted their public
+	// shares
+	FormPubSharesSubmitted Event = "form.pubSharesSubmitted"
+	// FormResultAvailable is sent when the ballots of a form are decrypted
+	FormResultAvailable Event = "form.resultAvailable"
+	// FormCanceled is sent when a form is canceled
+	FormCanceled Event = "form.canceled"
+	// FormDeleted is sent when a form is deleted
+	FormDeleted Event = "form.deleted"
+)
+
+// Events lists all the events a webhook can subscribe to
+var Events = []Event{
+	FormCreated,
+	FormOpened,
+	FormClosed,
+	FormShuffled,
+	FormPubSharesSubmitted,
+	FormResultAvailable,
+	FormCanceled,
+	FormDeleted,
+}
+
+const (
+	// EventHeader is the HTTP header containing the event of the payload
+	EventHeader = "X-DVoting-Event"
+	// SignatureHeader is the HTTP header containing the hex-encoded
+	// HMAC-SHA256 of the body, keyed with the secret of the webhook
+	SignatureHeader = "X-DVoting-Signature"
+)
+
+// Webhook is an HTTP endpoint notified of the events it subscribed to
+type Webhook struct {
+	ID     string
+	URL    string
+	Events []Event
+	// Secret is the key used to sign the payloads
+	Secret string
+}
+
+// Subscribed returns true if the webhook subscribed to the event.
+func (w Webhook) Subscribed(event Event) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String implements fmt.Stringer. It does not show the secret.
+func (w Webhook) String() string {
+	return fmt.Sprintf("%s %s %v", w.ID, w.URL, w.Events)
+}
+
+// Payload is the JSON body POSTed to the webhooks
+type Payload struct {
+	// ID is the unique ID of the notification. It is the same for all the
+	// attempts to deliver it, so that the receiver can ignore duplicates.
+	ID         string
+	Event      Event
+	FormID     string
+	BlockIndex uint64
+	// Time is the UNIX time of the local clock of the node when it handled the
+	// block, as the blocks have no timestamp. It is later than the time of the
+	// block if the node is catching up.
+	Time int64
+}
+
+// ParseEvent returns the event with the given name.
+func ParseEvent(name string) (Event, error) {
+	for _, e := range Events {
+		if string(e) == name {
+			return e, nil
+		}
+	}
+
+	return "", xerrors.Errorf("unknown event %q", name)
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of the body keyed with the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent("form.closed")
+	require.NoError(t, err)
+	require.Equal(t, FormClosed, event)
+
+	_, err = ParseEvent("form.unknown")
+	require.EqualError(t, err, `unknown event "form.unknown"`)
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	webhook := Webhook{ID: "id", URL: "http://example.com", Events: []Event{FormClosed},
+		Secret: "secret"}
+
+	require.True(t, webhook.Subscribed(FormClosed))
+	require.False(t, webhook.Subscribed(FormOpened))
+	require.Equal(t, "id http://example.com [form.closed]", webhook.String())
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test vector from RFC 4231, test case 2
+	require.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Sign("Jefe", []byte("what do ya want for nothing?")))
+}
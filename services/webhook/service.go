@@ -0,0 +1,440 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/dela"
+	"go.dedis.ch/dela/core/execution/native"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/store/kv"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/serde"
+	"golang.org/x/xerrors"
+)
+
+// BucketName is the name of the bucket in the database.
+const BucketName = "webhooks"
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	requestTimeout     = 10 * time.Second
+)
+
+// Service notifies the webhooks of the lifecycle events of the forms. The
+// webhooks are saved in the database of the node.
+type Service struct {
+	sync.Mutex
+
+	logger      zerolog.Logger
+	orderingSvc ordering.Service
+	context     serde.Context
+	formFac     serde.Factory
+	txFac       serde.Factory
+	db          kv.DB
+	client      *http.Client
+	webhooks    map[string]Webhook
+
+	// maxAttempts is the number of times a delivery is tried
+	maxAttempts int
+	// backoff is the delay before the first retry, it doubles at each retry
+	backoff time.Duration
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	deliveries sync.WaitGroup
+}
+
+// NewService returns a new webhook service with the webhooks saved in the
+// database.
+func NewService(srv ordering.Service, ctx serde.Context, formFac, txFac serde.Factory,
+	db kv.DB) (*Service, error) {
+
+	logger := dela.Logger.With().Timestamp().Str("role", "webhook").Logger()
+
+	serviceCtx, cancel := context.WithCancel(context.Background())
+
+	s := &Service{
+		logger:      logger,
+		orderingSvc: srv,
+		context:     ctx,
+		formFac:     formFac,
+		txFac:       txFac,
+		db:          db,
+		client:      &http.Client{Timeout: requestTimeout},
+		webhooks:    make(map[string]Webhook),
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		ctx:         serviceCtx,
+		cancel:      cancel,
+	}
+
+	err := db.View(func(tx kv.ReadableTx) error {
+		bucket := tx.GetBucket([]byte(BucketName))
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(key, value []byte) error {
+			var webhook Webhook
+
+			err := json.Unmarshal(value, &webhook)
+			if err != nil {
+				return xerrors.Errorf("failed to unmarshal webhook: %v", err)
+			}
+
+			s.webhooks[webhook.ID] = webhook
+
+			return nil
+		})
+	})
+	if err != nil {
+		cancel()
+		return nil, xerrors.Errorf("failed to read webhooks: %v", err)
+	}
+
+	return s, nil
+}
+
+// Add saves a new webhook that is notified of the given events. A random
+// secret is generated if none is given.
+func (s *Service) Add(rawURL string, events []Event, secret string) (Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Webhook{}, xerrors.Errorf("invalid url: %v", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return Webhook{}, xerrors.Errorf("invalid url: unsupported scheme %q", u.Scheme)
+	}
+
+	if len(events) == 0 {
+		return Webhook{}, xerrors.Errorf("no event to subscribe to")
+	}
+
+	id, err := types.RandomID()
+	if err != nil {
+		return Webhook{}, xerrors.Errorf("failed to create id: %v", err)
+	}
+
+	if secret == "" {
+		secret, err = types.RandomID()
+		if err != nil {
+			return Webhook{}, xerrors.Errorf("failed to create secret: %v", err)
+		}
+	}
+
+	webhook := Webhook{
+		ID:     id[:16],
+		URL:    rawURL,
+		Events: events,
+		Secret: secret,
+	}
+
+	buf, err := json.Marshal(webhook)
+	if err != nil {
+		return Webhook{}, xerrors.Errorf("failed to marshal webhook: %v", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	err = s.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(BucketName))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Set([]byte(webhook.ID), buf)
+	})
+	if err != nil {
+		return Webhook{}, xerrors.Errorf("failed to save webhook: %v", err)
+	}
+
+	s.webhooks[webhook.ID] = webhook
+
+	return webhook, nil
+}
+
+// Remove deletes a webhook.
+func (s *Service) Remove(id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	_, found := s.webhooks[id]
+	if !found {
+		return xerrors.Errorf("webhook %q not found", id)
+	}
+
+	err := s.db.Update(func(tx kv.WritableTx) error {
+		bucket, err := tx.GetBucketOrCreate([]byte(BucketName))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Delete([]byte(id))
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to delete webhook: %v", err)
+	}
+
+	delete(s.webhooks, id)
+
+	return nil
+}
+
+// List returns the webhooks sorted by URL.
+func (s *Service) List() []Webhook {
+	s.Lock()
+	defer s.Unlock()
+
+	webhooks := make([]Webhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		if webhooks[i].URL == webhooks[j].URL {
+			return webhooks[i].ID < webhooks[j].ID
+		}
+
+		return webhooks[i].URL < webhooks[j].URL
+	})
+
+	return webhooks
+}
+
+// Listen starts to watch the blocks in the background until the service is
+// stopped.
+func (s *Service) Listen() {
+	blocks := s.orderingSvc.Watch(s.ctx)
+
+	go func() {
+		for block := range blocks {
+			s.handleBlock(block)
+		}
+	}()
+}
+
+// Stop stops watching the blocks and waits for the deliveries in progress,
+// which are not retried anymore.
+func (s *Service) Stop() {
+	s.cancel()
+	s.deliveries.Wait()
+}
+
+// handleBlock notifies the webhooks of the events of the accepted
+// transactions of the block. An event is only sent once per form and block.
+func (s *Service) handleBlock(block ordering.Event) {
+	sent := make(map[string]struct{})
+
+	for _, res := range block.Transactions {
+		accepted, _ := res.GetStatus()
+		if !accepted {
+			continue
+		}
+
+		formID, event, found := s.formEvent(res.GetTransaction())
+		if !found {
+			continue
+		}
+
+		key := formID + string(event)
+
+		_, found = sent[key]
+		if found {
+			continue
+		}
+
+		sent[key] = struct{}{}
+
+		// the blocks have no timestamp, the local clock is used instead
+		s.notify(Payload{
+			Event:      event,
+			FormID:     formID,
+			BlockIndex: block.Index,
+			Time:       time.Now().Unix(),
+		})
+	}
+}
+
+// formEvent returns the lifecycle event caused by an accepted transaction of
+// the evoting contract, if any.
+func (s *Service) formEvent(tx txn.Transaction) (string, Event, bool) {
+	if string(tx.GetArg(native.ContractArg)) != evoting.ContractName {
+		return "", "", false
+	}
+
+	msg, err := s.txFac.Deserialize(s.context, tx.GetArg(evoting.FormArg))
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("failed to deserialize transaction")
+		return "", "", false
+	}
+
+	switch m := msg.(type) {
+	case types.CreateForm, types.CreateFormFromTemplate:
+		// the ID of a new form is the hash of the transaction ID
+		hash := sha256.Sum256(tx.GetID())
+		return hex.EncodeToString(hash[:]), FormCreated, true
+	case types.OpenForm:
+		return m.FormID, FormOpened, true
+	case types.CloseForm:
+		return m.FormID, FormClosed, true
+	case types.ShuffleBallots:
+		// only the last shuffle changes the status of the form
+		return m.FormID, FormShuffled, s.isLastShuffle(m)
+	case types.RegisterPubShares:
+		// only the last submission changes the status of the form
+		return m.FormID, FormPubSharesSubmitted, s.isLastPubShares(m)
+	case types.CombineShares:
+		return m.FormID, FormResultAvailable, true
+	case types.CancelForm:
+		return m.FormID, FormCanceled, true
+	case types.DeleteForm:
+		return m.FormID, FormDeleted, true
+	}
+
+	return "", "", false
+}
+
+// isLastShuffle returns true if the accepted shuffle is the one that completed
+// the shuffle of the form. The answer doesn't depend on the blocks accepted
+// since then, as the shuffles are only appended to the form, so that a lagging
+// watcher notifies the same block.
+func (s *Service) isLastShuffle(tx types.ShuffleBallots) bool {
+	form, err := types.FormFromStore(s.context, s.formFac, tx.FormID,
+		s.orderingSvc.GetStore())
+	if err != nil {
+		s.logger.Warn().Err(err).Str("formID", tx.FormID).Msg("failed to get form")
+		return false
+	}
+
+	// the rounds start at 0
+	return len(form.ShuffleInstances) >= form.ShuffleThreshold &&
+		tx.Round == form.ShuffleThreshold-1
+}
+
+// isLastPubShares returns true if the accepted submission of public shares is
+// the one that completed the submissions of the form. As for the shuffles, the
+// submissions are only appended to the form.
+func (s *Service) isLastPubShares(tx types.RegisterPubShares) bool {
+	form, err := types.FormFromStore(s.context, s.formFac, tx.FormID,
+		s.orderingSvc.GetStore())
+	if err != nil {
+		s.logger.Warn().Err(err).Str("formID", tx.FormID).Msg("failed to get form")
+		return false
+	}
+
+	last := form.ShuffleThreshold - 1
+	pubKeys := form.PubsharesUnits.PubKeys
+
+	return last >= 0 && last < len(pubKeys) && bytes.Equal(pubKeys[last], tx.PublicKey)
+}
+
+// notify delivers the payload in the background to the webhooks that
+// subscribed to its event.
+func (s *Service) notify(payload Payload) {
+	id, err := types.RandomID()
+	if err != nil {
+		s.logger.Err(err).Msg("failed to create notification id")
+		return
+	}
+
+	payload.ID = id[:16]
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Err(err).Msg("failed to marshal payload")
+		return
+	}
+
+	for _, webhook := range s.List() {
+		if !webhook.Subscribed(payload.Event) {
+			continue
+		}
+
+		s.deliveries.Add(1)
+
+		go func(webhook Webhook) {
+			defer s.deliveries.Done()
+
+			err := s.deliver(webhook, payload.Event, body)
+			if err != nil {
+				s.logger.Warn().Err(err).Str("webhook", webhook.ID).
+					Str("event", string(payload.Event)).Msg("failed to notify webhook")
+			}
+		}(webhook)
+	}
+}
+
+// deliver POSTs the body to the webhook, and retries with an exponential
+// backoff until it succeeds or the maximum number of attempts is reached.
+func (s *Service) deliver(webhook Webhook, event Event, body []byte) error {
+	backoff := s.backoff
+
+	var err error
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		err = s.post(webhook, event, body)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == s.maxAttempts {
+			break
+		}
+
+		s.logger.Info().Err(err).Str("webhook", webhook.ID).
+			Msgf("attempt %d failed, retrying in %s", attempt, backoff)
+
+		select {
+		case <-s.ctx.Done():
+			return xerrors.Errorf("service stopped: %v", err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+
+	return xerrors.Errorf("failed after %d attempts: %v", s.maxAttempts, err)
+}
+
+// post sends the signed body to the webhook. Any response that is not 2xx is
+// an error.
+func (s *Service) post(webhook Webhook, event Event, body []byte) error {
+	// the request is not canceled when the service stops, so that the
+	// attempt in progress is completed
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("failed to post: %v", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return xerrors.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return nil
+}
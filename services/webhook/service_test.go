@@ -0,0 +1,286 @@
+package webhook
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/d-voting/contracts/evoting"
+	"go.dedis.ch/d-voting/contracts/evoting/types"
+	"go.dedis.ch/d-voting/internal/testing/fake"
+	"go.dedis.ch/dela/core/execution/native"
+	"go.dedis.ch/dela/core/ordering"
+	"go.dedis.ch/dela/core/ordering/cosipbft/authority"
+	"go.dedis.ch/dela/core/txn"
+	"go.dedis.ch/dela/core/validation"
+	"go.dedis.ch/dela/serde"
+	sjson "go.dedis.ch/dela/serde/json"
+
+	_ "go.dedis.ch/d-voting/contracts/evoting/json"
+)
+
+var ctx = sjson.NewContext()
+
+var formID = hex.EncodeToString([]byte("form"))
+
+func TestService_AddRemoveList(t *testing.T) {
+	db := fake.NewInMemoryDB()
+
+	s, err := NewService(&fake.Service{}, ctx, nil, nil, db)
+	require.NoError(t, err)
+
+	_, err = s.Add("ftp://example.com", Events, "")
+	require.EqualError(t, err, `invalid url: unsupported scheme "ftp"`)
+
+	_, err = s.Add("http://example.com", nil, "")
+	require.EqualError(t, err, "no event to subscribe to")
+
+	hook1, err := s.Add("http://b.example.com", []Event{FormClosed}, "secret")
+	require.NoError(t, err)
+	require.Equal(t, "secret", hook1.Secret)
+
+	hook2, err := s.Add("http://a.example.com", Events, "")
+	require.NoError(t, err)
+	require.Len(t, hook2.Secret, 64)
+
+	require.Equal(t, []Webhook{hook2, hook1}, s.List())
+
+	// the webhooks are loaded from the database
+	s, err = NewService(&fake.Service{}, ctx, nil, nil, db)
+	require.NoError(t, err)
+	require.Equal(t, []Webhook{hook2, hook1}, s.List())
+
+	err = s.Remove(hook2.ID)
+	require.NoError(t, err)
+
+	err = s.Remove(hook2.ID)
+	require.EqualError(t, err, `webhook "`+hook2.ID+`" not found`)
+
+	s, err = NewService(&fake.Service{}, ctx, nil, nil, db)
+	require.NoError(t, err)
+	require.Equal(t, []Webhook{hook1}, s.List())
+
+	_, err = NewService(&fake.Service{}, ctx, nil, nil, fake.NewBadViewDB())
+	require.Error(t, err)
+}
+
+func TestService_HandleBlock(t *testing.T) {
+	var lock sync.Mutex
+
+	var received []*http.Request
+	var bodies [][]byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		lock.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		lock.Unlock()
+	}))
+	defer srv.Close()
+
+	s := newService(t, types.Form{FormID: formID, ShuffleThreshold: 2})
+
+	hook, err := s.Add(srv.URL, []Event{FormOpened, FormShuffled, FormCreated}, "secret")
+	require.NoError(t, err)
+
+	s.handleBlock(ordering.Event{
+		Index: 3,
+		Transactions: []validation.TransactionResult{
+			newResult(t, types.OpenForm{FormID: formID}, true),
+			// the event is only sent once per block
+			newResult(t, types.OpenForm{FormID: formID}, true),
+			// not subscribed
+			newResult(t, types.CloseForm{FormID: formID}, true),
+			// rejected
+			newResult(t, types.CancelForm{FormID: formID}, false),
+			// it is not the last shuffle
+			newResult(t, types.ShuffleBallots{FormID: formID, Round: 0}, true),
+			// not a transaction of the evoting contract
+			fakeResult{tx: fakeTx{}, accepted: true},
+		},
+	})
+
+	s.Stop()
+
+	require.Len(t, received, 1)
+	require.Equal(t, string(FormOpened), received[0].Header.Get(EventHeader))
+	require.Equal(t, Sign(hook.Secret, bodies[0]), received[0].Header.Get(SignatureHeader))
+
+	var payload Payload
+
+	err = json.Unmarshal(bodies[0], &payload)
+	require.NoError(t, err)
+	require.Equal(t, FormOpened, payload.Event)
+	require.Equal(t, formID, payload.FormID)
+	require.Equal(t, uint64(3), payload.BlockIndex)
+	require.NotEmpty(t, payload.ID)
+}
+
+func TestService_HandleBlockLagging(t *testing.T) {
+	var lock sync.Mutex
+
+	var payloads []Payload
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload Payload
+
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		require.NoError(t, err)
+
+		lock.Lock()
+		payloads = append(payloads, payload)
+		lock.Unlock()
+	}))
+	defer srv.Close()
+
+	// the store already contains the state after all the blocks below, as if
+	// the watcher was behind
+	s := newService(t, types.Form{
+		FormID:           formID,
+		ShuffleThreshold: 2,
+		ShuffleInstances: []types.ShuffleInstance{{}, {}},
+		PubsharesUnits: types.PubsharesUnits{
+			Pubshares: []types.PubsharesUnit{{}, {}},
+			PubKeys:   [][]byte{[]byte("node1"), []byte("node2")},
+			Indexes:   []int{0, 1},
+		},
+	})
+
+	_, err := s.Add(srv.URL, []Event{FormShuffled, FormPubSharesSubmitted}, "")
+	require.NoError(t, err)
+
+	blocks := []validation.TransactionResult{
+		newResult(t, types.ShuffleBallots{FormID: formID, Round: 0}, true),
+		newResult(t, types.ShuffleBallots{FormID: formID, Round: 1}, true),
+		newResult(t, types.RegisterPubShares{FormID: formID, PublicKey: []byte("node1")}, true),
+		newResult(t, types.RegisterPubShares{FormID: formID, PublicKey: []byte("node2")}, true),
+	}
+
+	for i, res := range blocks {
+		s.handleBlock(ordering.Event{
+			Index:        uint64(i),
+			Transactions: []validation.TransactionResult{res},
+		})
+	}
+
+	s.Stop()
+
+	sort.Slice(payloads, func(i, j int) bool {
+		return payloads[i].BlockIndex < payloads[j].BlockIndex
+	})
+
+	// the events are only sent for the blocks that changed the status
+	require.Len(t, payloads, 2)
+	require.Equal(t, FormShuffled, payloads[0].Event)
+	require.Equal(t, uint64(1), payloads[0].BlockIndex)
+	require.Equal(t, FormPubSharesSubmitted, payloads[1].Event)
+	require.Equal(t, uint64(3), payloads[1].BlockIndex)
+}
+
+func TestService_Deliver(t *testing.T) {
+	var lock sync.Mutex
+
+	failures := 2
+	attempts := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		attempts++
+
+		if attempts <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewService(&fake.Service{}, ctx, nil, nil, fake.NewInMemoryDB())
+	require.NoError(t, err)
+
+	s.maxAttempts = 3
+	s.backoff = time.Millisecond
+
+	hook := Webhook{ID: "id", URL: srv.URL}
+
+	err = s.deliver(hook, FormClosed, []byte("{}"))
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	failures = 3
+
+	err = s.deliver(hook, FormClosed, []byte("{}"))
+	require.EqualError(t, err, "failed after 3 attempts: unexpected status: 500 Internal Server Error")
+	require.Equal(t, 3, attempts)
+}
+
+// -----------------------------------------------------------------------------
+// Utility functions
+
+// newService returns a service whose store contains the form.
+func newService(t *testing.T, form types.Form) *Service {
+	roster := authority.FromAuthority(fake.NewAuthority(2, fake.NewSigner))
+	form.Roster = roster
+
+	service := fake.NewService(formID, form, ctx)
+
+	formFac := types.NewFormFactory(types.CiphervoteFactory{}, fake.NewRosterFac(roster))
+
+	s, err := NewService(&service, ctx, formFac,
+		types.NewTransactionFactory(types.CiphervoteFactory{}), fake.NewInMemoryDB())
+	require.NoError(t, err)
+
+	return s
+}
+
+func newResult(t *testing.T, msg serde.Message, accepted bool) fakeResult {
+	buf, err := msg.Serialize(ctx)
+	require.NoError(t, err)
+
+	tx := fakeTx{args: map[string][]byte{
+		native.ContractArg: []byte(evoting.ContractName),
+		evoting.FormArg:    buf,
+	}}
+
+	return fakeResult{tx: tx, accepted: accepted}
+}
+
+// fakeTx
+//
+// - implements txn.Transaction
+type fakeTx struct {
+	fake.Transaction
+	args map[string][]byte
+}
+
+func (tx fakeTx) GetArg(key string) []byte {
+	return tx.args[key]
+}
+
+// fakeResult
+//
+// - implements validation.TransactionResult
+type fakeResult struct {
+	validation.TransactionResult
+	tx       txn.Transaction
+	accepted bool
+}
+
+func (r fakeResult) GetTransaction() txn.Transaction {
+	return r.tx
+}
+
+func (r fakeResult) GetStatus() (bool, string) {
+	return r.accepted, ""
+}
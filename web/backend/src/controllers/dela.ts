@@ -15,8 +15,18 @@ export const delaRouter = express.Router();
 
 initEnforcer().catch((e) => console.error(`Couldn't initialize enforcerer: ${e}`));
 
-// get payload creates a payload with a signature on it
-function getPayload(dataStr: string) {
+// get payload creates a payload with a signature on it. The message is signed
+// with a timestamp, a random nonce, and the method and path of the request sent
+// to uri, so that the Dela node refuses it if it is replayed.
+function getPayload(data: object, method: string, uri: string) {
+  const dataStr = JSON.stringify({
+    ...data,
+    Timestamp: Math.floor(Date.now() / 1000),
+    Nonce: crypto.randomBytes(16).toString('hex'),
+    Method: method.toUpperCase(),
+    Path: new URL(uri).pathname,
+  });
+
   let dataStrB64 = Buffer.from(dataStr).toString('base64url');
   while (dataStrB64.length % 4 !== 0) {
     dataStrB64 += '=';
@@ -45,8 +55,8 @@ function getPayload(dataStr: string) {
 
 // sendToDela signs the message and sends it to the dela proxy. It makes no
 // authentication check.
-function sendToDela(dataStr: string, req: express.Request, res: express.Response) {
-  let payload = getPayload(dataStr);
+function sendToDela(data: object, req: express.Request, res: express.Response) {
+  let message = data;
 
   // we strip the `/api` part: /api/form/xxx => /form/xxx
   let uri = process.env.DELA_PROXY_URL + req.baseUrl.slice(4);
@@ -56,16 +66,14 @@ function sendToDela(dataStr: string, req: express.Request, res: express.Response
   // in case this is a DKG init request, we must also update the payload.
   const dkgInitRegex = /\/evoting\/services\/dkg\/actors$/;
   if (uri.match(dkgInitRegex)) {
-    const dataStr2 = JSON.stringify({ FormID: req.body.FormID });
-    payload = getPayload(dataStr2);
+    message = { FormID: req.body.FormID };
     redirectToDefaultProxy = false;
   }
 
   // in case this is a DKG setup request, we must update the payload.
   const dkgSetupRegex = /\/evoting\/services\/dkg\/actors\/.*$/;
   if (uri.match(dkgSetupRegex)) {
-    const dataStr2 = JSON.stringify({ Action: req.body.Action });
-    payload = getPayload(dataStr2);
+    message = { Action: req.body.Action };
 
     // If setup don't redirect to default proxy, if 'computePubshares' then keep
     // default proxy
@@ -85,6 +93,8 @@ function sendToDela(dataStr: string, req: express.Request, res: express.Response
     uri = proxy + req.baseUrl.slice(4);
   }
 
+  const payload = getPayload(message, req.method, uri);
+
   console.log('sending payload:', JSON.stringify(payload), 'to', uri);
 
   axios({
@@ -190,27 +200,17 @@ delaRouter.delete('/forms/:formID', (req, res) => {
     res.status(400).send('Unauthorized');
     return;
   }
-  const edCurve = kyber.curve.newCurve('edwards25519');
-
-  const priv = Buffer.from(process.env.PRIVATE_KEY as string, 'hex');
-  const pub = Buffer.from(process.env.PUBLIC_KEY as string, 'hex');
-
-  const scalar = edCurve.scalar();
-  scalar.unmarshalBinary(priv);
-
-  const point = edCurve.point();
-  point.unmarshalBinary(pub);
-
-  const sign = kyber.sign.schnorr.sign(edCurve, scalar, Buffer.from(formID));
-
   // we strip the `/api` part: /api/form/xxx => /form/xxx
   const uri = process.env.DELA_PROXY_URL + xss(req.url.slice(4));
 
+  // the deletion is signed like the other requests, the signature in the
+  // Authorization header is refused by the Dela nodes as it can be replayed
   axios({
     method: req.method as Method,
     url: uri,
+    data: getPayload({}, req.method, uri),
     headers: {
-      Authorization: sign.toString('hex'),
+      'Content-Type': 'application/json',
     },
   })
     .then((resp) => {
@@ -264,7 +264,5 @@ delaRouter.use('/*', (req, res) => {
     }
   }
 
-  const dataStr = JSON.stringify(bodyData);
-
-  sendToDela(dataStr, req, res);
+  sendToDela(bodyData, req, res);
 });
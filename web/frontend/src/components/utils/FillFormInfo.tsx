@@ -1,6 +1,7 @@
 import { useEffect, useState } from 'react';
 import { ID, Title } from 'types/configuration';
 import { FormInfo, LightFormInfo, Results, Status } from 'types/form';
+import { unmarshalTitle } from 'types/JSONparser';
 
 const useFillFormInfo = (formData: FormInfo) => {
   const [id, setId] = useState<ID>('');
@@ -52,14 +53,14 @@ const useFillFormInfo = (formData: FormInfo) => {
 
 const useFillLightFormInfo = (formData: LightFormInfo) => {
   const [id, setId] = useState<ID>('');
-  const [title, setTitle] = useState<Title>({ En: '', Fr: '', De: '', URL: '' });
+  const [title, setTitle] = useState<Title>({ Text: {}, URL: '' });
   const [status, setStatus] = useState<Status>(null);
   const [pubKey, setPubKey] = useState<string>('');
 
   useEffect(() => {
     if (formData !== null) {
       setId(formData.FormID);
-      setTitle(formData.Title);
+      setTitle(unmarshalTitle(formData.Title));
       setStatus(formData.Status);
       setPubKey(formData.Pubkey);
     }
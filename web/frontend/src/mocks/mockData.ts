@@ -23,18 +23,18 @@ const mockRoster: string[] = [
 ];
 
 const mockForm1: any = {
-  Title: { En: 'Life on the campus', Fr: 'Vie sur le campus', De: 'Leben auf dem Campus', URL: '' },
+  Title: { en: 'Life on the campus', fr: 'Vie sur le campus', de: 'Leben auf dem Campus', URL: '' },
   Scaffold: [
     {
       ID: (0xa2ab).toString(),
-      Title: { En: 'Rate the course', Fr: 'Note la course', De: 'Bewerten Sie den Kurs', URL: '' },
+      Title: { en: 'Rate the course', fr: 'Note la course', de: 'Bewerten Sie den Kurs', URL: '' },
       Order: [(0x3fb2).toString(), (0x41e2).toString(), (0xcd13).toString(), (0xff31).toString()],
       Subjects: [
         {
           Title: {
-            En: 'Let s talk about the food',
-            Fr: 'Parlons de la nourriture',
-            De: 'Sprechen wir über das Essen',
+            en: 'Let s talk about the food',
+            fr: 'Parlons de la nourriture',
+            de: 'Sprechen wir über das Essen',
             URL: '',
           },
           ID: (0xff31).toString(),
@@ -44,39 +44,39 @@ const mockForm1: any = {
           Selects: [
             {
               Title: {
-                En: 'Select your ingredients',
-                Fr: 'Choisi tes ingrédients',
-                De: 'Wählen Sie Ihre Zutaten aus',
+                en: 'Select your ingredients',
+                fr: 'Choisi tes ingrédients',
+                de: 'Wählen Sie Ihre Zutaten aus',
                 URL: '',
               },
               ID: (0xa319).toString(),
               MaxN: 2,
               MinN: 1,
               Choices: [
-                { Choice: '{"en": "tomato", "fr": "tomate", "de": "Tomate"}', URL: '' },
-                { Choice: '{"en": "salad", "fr": "salade", "de": "Salat"}', URL: '' },
-                { Choice: '{"en": "onion", "fr": "oignon", "de": "Zwiebel"}', URL: '' },
+                { Choice: { en: 'tomato', fr: 'tomate', de: 'Tomate' }, URL: '' },
+                { Choice: { en: 'salad', fr: 'salade', de: 'Salat' }, URL: '' },
+                { Choice: { en: 'onion', fr: 'oignon', de: 'Zwiebel' }, URL: '' },
               ],
-              Hint: { En: '', Fr: '', De: '' },
+              Hint: { en: '', fr: '', de: '' },
             },
           ],
           Ranks: [
             {
               Title: {
-                En: 'Rank the cafeteria',
-                Fr: 'Ordonne les cafet',
-                De: 'Ordnen Sie die Mensen',
+                en: 'Rank the cafeteria',
+                fr: 'Ordonne les cafet',
+                de: 'Ordnen Sie die Mensen',
                 URL: '',
               },
               ID: (0x19c7).toString(),
               MaxN: 3,
               MinN: 3,
               Choices: [
-                { Choice: '{"en": "BC", "fr": "BC", "de": "BC"}', URL: '' },
-                { Choice: '{"en": "SV", "fr": "SV", "de": "SV"}', URL: '' },
-                { Choice: '{"en": "Parmentier", "fr": "Parmentier", "de": "Parmentier"}', URL: '' },
+                { Choice: 'BC', URL: '' },
+                { Choice: 'SV', URL: '' },
+                { Choice: 'Parmentier', URL: '' },
               ],
-              Hint: { En: '', Fr: '', De: '' },
+              Hint: { en: '', fr: '', de: '' },
             },
           ],
         },
@@ -85,51 +85,51 @@ const mockForm1: any = {
       Selects: [
         {
           Title: {
-            En: 'How did you find the provided material, from 1 (bad) to 5 (excellent) ?',
-            Fr: 'Comment trouves-tu le matériel fourni, de 1 (mauvais) à 5 (excellent) ?',
-            De: 'Wie bewerten Sie das vorhandene Material, von 1 (schlecht) bis 5 (exzellent)?',
+            en: 'How did you find the provided material, from 1 (bad) to 5 (excellent) ?',
+            fr: 'Comment trouves-tu le matériel fourni, de 1 (mauvais) à 5 (excellent) ?',
+            de: 'Wie bewerten Sie das vorhandene Material, von 1 (schlecht) bis 5 (exzellent)?',
             URL: '',
           },
           ID: (0x3fb2).toString(),
           MaxN: 1,
           MinN: 1,
           Choices: [
-            { Choice: '{"en":"1" ,"fr": "1", "de": "1"}', URL: '' },
-            { Choice: '{"en":"2", "fr": "2", "de": "2"}', URL: '' },
-            { Choice: '{"en":"3", "fr": "3", "de": "3"}', URL: '' },
-            { Choice: '{"en":"4", "fr": "4", "de": "4"}', URL: '' },
-            { Choice: '{ "en": "5", "fr": "5", "de": "5" }', URL: '' },
+            { Choice: '1', URL: '' },
+            { Choice: '2', URL: '' },
+            { Choice: '3', URL: '' },
+            { Choice: '4', URL: '' },
+            { Choice: '5', URL: '' },
           ],
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
         {
           Title: {
-            En: 'How did you find the teaching ?',
-            Fr: 'Comment trouves-tu l enseignement ?',
-            De: 'Wie fanden Sie den Unterricht?',
+            en: 'How did you find the teaching ?',
+            fr: 'Comment trouves-tu l enseignement ?',
+            de: 'Wie fanden Sie den Unterricht?',
             URL: '',
           },
           ID: (0x41e2).toString(),
           MaxN: 1,
           MinN: 1,
           Choices: [
-            { Choice: '{"en" : "bad", "fr": "mauvais", "de": "schlecht"}', URL: '' },
-            { Choice: '{"en" : "normal", "fr": "normal", "de": "durchschnittlich"}', URL: '' },
-            { Choice: '{"en" : "good", "fr": "super", "de": "gut"}', URL: '' },
+            { Choice: { en: 'bad', fr: 'mauvais', de: 'schlecht' }, URL: '' },
+            { Choice: { en: 'normal', fr: 'normal', de: 'durchschnittlich' }, URL: '' },
+            { Choice: { en: 'good', fr: 'super', de: 'gut' }, URL: '' },
           ],
           Hint: {
-            En: 'Be honest. This is anonymous anyway',
-            Fr: 'Sois honnête. C est anonyme de toute façon',
-            De: 'Seien Sie ehrlich. Es bleibt anonym',
+            en: 'Be honest. This is anonymous anyway',
+            fr: 'Sois honnête. C est anonyme de toute façon',
+            de: 'Seien Sie ehrlich. Es bleibt anonym',
           },
         },
       ],
       Texts: [
         {
           Title: {
-            En: 'Who were the two best TAs ?',
-            Fr: 'Quels sont les deux meilleurs TA ?',
-            De: 'Wer waren die beiden besten TutorInnen?',
+            en: 'Who were the two best TAs ?',
+            fr: 'Quels sont les deux meilleurs TA ?',
+            de: 'Wer waren die beiden besten TutorInnen?',
             URL: '',
           },
           ID: (0xcd13).toString(),
@@ -138,10 +138,10 @@ const mockForm1: any = {
           MinN: 1,
           Regex: '',
           Choices: [
-            { Choice: '{"en":"TA1", "fr": "TA1", "de": "TA1"}', URL: '' },
-            { Choice: '{"en":"TA2", "fr":"TA2","de": "TA2"}', URL: '' },
+            { Choice: 'TA1', URL: '' },
+            { Choice: 'TA2', URL: '' },
           ],
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
       ],
     },
@@ -176,44 +176,44 @@ const mockFormResult12: Results = {
 
 const mockForm2: any = {
   Title: {
-    En: 'Please give your opinion',
-    Fr: 'Donne ton avis',
-    De: 'Bitte sagen Sie Ihre Meinung',
+    en: 'Please give your opinion',
+    fr: 'Donne ton avis',
+    de: 'Bitte sagen Sie Ihre Meinung',
     URL: '',
   },
   Scaffold: [
     {
       ID: (0xa2ab).toString(),
-      Title: { En: 'Rate the course', Fr: 'Note le cours', De: 'Bewerten Sie den Kurs', URL: '' },
+      Title: { en: 'Rate the course', fr: 'Note le cours', de: 'Bewerten Sie den Kurs', URL: '' },
       Order: [(0x3fb2).toString(), (0xcd13).toString()],
 
       Selects: [
         {
           Title: {
-            En: 'How did you find the provided material, from 1 (bad) to 5 (excellent) ?',
-            Fr: 'Comment trouves-tu le matériel fourni, de 1 (mauvais) à 5 (excellent) ?',
-            De: 'Wie bewerten Sie das vorhandene Material, von 1 (schlecht) zu 5 (exzellent)?',
+            en: 'How did you find the provided material, from 1 (bad) to 5 (excellent) ?',
+            fr: 'Comment trouves-tu le matériel fourni, de 1 (mauvais) à 5 (excellent) ?',
+            de: 'Wie bewerten Sie das vorhandene Material, von 1 (schlecht) zu 5 (exzellent)?',
             URL: '',
           },
           ID: (0x3fb2).toString(),
           MaxN: 1,
           MinN: 1,
           Choices: [
-            { Choice: '{"en":"1" ,"fr": "1", "de": "1"}', URL: '' },
-            { Choice: '{"en":"2", "fr": "2", "de": "2"}', URL: '' },
-            { Choice: '{"en":"3", "fr": "3", "de": "3"}', URL: '' },
-            { Choice: '{"en":"4", "fr": "4", "de": "4"}', URL: '' },
-            { Choice: '{ "en": "5", "fr": "5", "de": "5" }', URL: '' },
+            { Choice: '1', URL: '' },
+            { Choice: '2', URL: '' },
+            { Choice: '3', URL: '' },
+            { Choice: '4', URL: '' },
+            { Choice: '5', URL: '' },
           ],
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
       ],
       Texts: [
         {
           Title: {
-            En: 'Who were the two best TAs ?',
-            Fr: 'Quels sont les deux meilleurs TA ?',
-            De: 'Wer waren die beiden besten TutorInnen?',
+            en: 'Who were the two best TAs ?',
+            fr: 'Quels sont les deux meilleurs TA ?',
+            de: 'Wer waren die beiden besten TutorInnen?',
             URL: '',
           },
           ID: (0xcd13).toString(),
@@ -221,11 +221,11 @@ const mockForm2: any = {
           MaxN: 2,
           MinN: 2,
           Choices: [
-            { Choice: '{"en":"TA1", "fr": "TA1", "de": "TA1"}', URL: '' },
-            { Choice: '{"en":"TA2", "fr":"TA2","de": "TA2"}', URL: '' },
+            { Choice: 'TA1', URL: '' },
+            { Choice: 'TA2', URL: '' },
           ],
           Regex: '^[A-Z][a-z]+$',
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
       ],
 
@@ -235,69 +235,65 @@ const mockForm2: any = {
     {
       ID: (0x1234).toString(),
       Title: {
-        En: 'Tough choices',
-        Fr: 'Choix difficiles',
-        De: 'Schwierige Entscheidungen',
+        en: 'Tough choices',
+        fr: 'Choix difficiles',
+        de: 'Schwierige Entscheidungen',
         URL: '',
       },
       Order: [(0xa319).toString(), (0xcafe).toString(), (0xbeef).toString()],
       Selects: [
         {
           Title: {
-            En: 'Select your ingredients',
-            Fr: 'Choisis tes ingrédients',
-            De: 'Wählen Sie Ihre Zutaten',
+            en: 'Select your ingredients',
+            fr: 'Choisis tes ingrédients',
+            de: 'Wählen Sie Ihre Zutaten',
             URL: '',
           },
           ID: (0xa319).toString(),
           MaxN: 3,
           MinN: 0,
           Choices: [
-            { Choice: '{"en": "tomato", "fr": "tomate", "de": "Tomate"}', URL: '' },
-            { Choice: '{"en": "salad", "fr": "salade", "de": "Salat"}', URL: '' },
-            { Choice: '{"en": "onion", "fr": "oignon", "de": "Zwiebel"}', URL: '' },
-            { Choice: '{"en": "falafel", "fr": "falafel", "de": "Falafel"}', URL: '' },
+            { Choice: { en: 'tomato', fr: 'tomate', de: 'Tomate' }, URL: '' },
+            { Choice: { en: 'salad', fr: 'salade', de: 'Salat' }, URL: '' },
+            { Choice: { en: 'onion', fr: 'oignon', de: 'Zwiebel' }, URL: '' },
+            { Choice: { en: 'falafel', fr: 'falafel', de: 'Falafel' }, URL: '' },
           ],
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
       ],
 
       Ranks: [
         {
           Title: {
-            En: 'Which cafeteria serves the best coffee ?',
-            Fr: 'Quelle cafétéria sert le meilleur café ?',
-            De: 'Welches Café bietet den besten Kaffee an?',
+            en: 'Which cafeteria serves the best coffee ?',
+            fr: 'Quelle cafétéria sert le meilleur café ?',
+            de: 'Welches Café bietet den besten Kaffee an?',
             URL: '',
           },
           ID: (0xcafe).toString(),
           MaxN: 4,
           MinN: 1,
           Choices: [
-            { Choice: '{"en": "Esplanade", "fr": "Esplanade", "de": "Esplanade"}', URL: '' },
-            { Choice: '{"en": "Giacometti", "fr": "Giacometti", "de": "Giacometti"}', URL: '' },
-            { Choice: '{"en": "Arcadie", "fr": "Arcadie", "de": "Arcadie"}', URL: '' },
-            {
-              Choice:
-                '{"en": "Montreux Jazz Cafe", "fr": "Montreux Jazz Cafe", "de": "Montreux Jazz Cafe"}',
-              URL: '',
-            },
+            { Choice: 'Esplanade', URL: '' },
+            { Choice: 'Giacometti', URL: '' },
+            { Choice: 'Arcadie', URL: '' },
+            { Choice: 'Montreux Jazz Cafe', URL: '' },
           ],
-          Hint: { En: '', Fr: '', De: '' },
+          Hint: { en: '', fr: '', de: '' },
         },
         {
-          Title: { En: 'IN or SC ?', Fr: 'IN ou SC ?', De: 'IN oder SC?', URL: '' },
+          Title: { en: 'IN or SC ?', fr: 'IN ou SC ?', de: 'IN oder SC?', URL: '' },
           ID: (0xbeef).toString(),
           MaxN: 2,
           MinN: 1,
           Choices: [
-            { Choice: '{"en": "IN", "fr": "IN", "de": "IN"}', URL: '' },
-            { Choice: '{"en": "SC", "fr": "SC", "de": "SC"}', URL: '' },
+            { Choice: 'IN', URL: '' },
+            { Choice: 'SC', URL: '' },
           ],
           Hint: {
-            En: 'The right answer is IN ;-)',
-            Fr: 'La bonne réponse est IN ;-)',
-            De: 'Die korrekte Antwort ist IN ;-)',
+            en: 'The right answer is IN ;-)',
+            fr: 'La bonne réponse est IN ;-)',
+            de: 'Die korrekte Antwort ist IN ;-)',
           },
         },
       ],
@@ -308,14 +304,14 @@ const mockForm2: any = {
 };
 
 const mockForm3: any = {
-  Title: { En: 'Lunch', Fr: 'Déjeuner', De: 'Mittagessen', URL: '' },
+  Title: { en: 'Lunch', fr: 'Déjeuner', de: 'Mittagessen', URL: '' },
   Scaffold: [
     {
       ID: '3cVHIxpx',
       Title: {
-        En: 'Choose your lunch',
-        Fr: 'Choisis ton déjeuner',
-        De: 'Wählen Sie Ihr Mittagessen',
+        en: 'Choose your lunch',
+        fr: 'Choisis ton déjeuner',
+        de: 'Wählen Sie Ihr Mittagessen',
         URL: '',
       },
       Order: ['PGP'],
@@ -325,9 +321,9 @@ const mockForm3: any = {
         {
           ID: 'PGP',
           Title: {
-            En: 'Select what you want',
-            Fr: 'Choisis ce que tu veux',
-            De: 'Wählen Sie aus was Sie wünschen',
+            en: 'Select what you want',
+            fr: 'Choisis ce que tu veux',
+            de: 'Wählen Sie aus was Sie wünschen',
             URL: '',
           },
           MaxN: 4,
@@ -335,15 +331,15 @@ const mockForm3: any = {
           MaxLength: 50,
           Regex: '',
           Choices: [
-            { Choice: '{"en": "Firstname", "fr": "Prénom", "de": "Firstname"}', URL: '' },
-            { Choice: '{"en": "Main 🍕", "fr" : "Principal 🍕", "de": "Main 🍕"}', URL: '' },
-            { Choice: '{"en": "Drink 🧃", "fr": "Boisson 🧃", "de": "Drink 🧃"}', URL: '' },
-            { Choice: '{"en":"Dessert 🍰", "fr": "Dessert 🍰", "de": "Nachtisch 🍰"}', URL: '' },
+            { Choice: { en: 'Firstname', fr: 'Prénom', de: 'Firstname' }, URL: '' },
+            { Choice: { en: 'Main 🍕', fr: 'Principal 🍕', de: 'Main 🍕' }, URL: '' },
+            { Choice: { en: 'Drink 🧃', fr: 'Boisson 🧃', de: 'Drink 🧃' }, URL: '' },
+            { Choice: { en: 'Dessert 🍰', fr: 'Dessert 🍰', de: 'Nachtisch 🍰' }, URL: '' },
           ],
           Hint: {
-            En: 'If you change opinion call me before 11:30 a.m.',
-            Fr: "Si tu changes d'avis appelle moi avant 11h30",
-            De: 'Wenn Sie Ihre Meinung ändern, rufen Sie mich vor 11:30 an',
+            en: 'If you change opinion call me before 11:30 a.m.',
+            fr: "Si tu changes d'avis appelle moi avant 11h30",
+            de: 'Wenn Sie Ihre Meinung ändern, rufen Sie mich vor 11:30 an',
           },
         },
       ],
@@ -60,7 +60,7 @@ const BallotDisplay: FC<BallotDisplayProps> = ({
     return (
       <div key={subject.ID}>
         <h3 className="text-xl break-all pt-1 pb-1 sm:pt-2 sm:pb-2 border-t font-bold text-gray-600">
-          {urlizeLabel(internationalize(language, subject.Title), subject.Title.URL)}
+          {urlizeLabel(internationalize(language, subject.Title.Text), subject.Title.URL)}
         </h3>
         {subject.Order.map((id: ID) => (
           <div key={id}>
@@ -81,11 +81,11 @@ const BallotDisplay: FC<BallotDisplayProps> = ({
     <DragDropContext onDragEnd={(dropRes) => handleOnDragEnd(dropRes, answers, setAnswers)}>
       <div className="w-full mb-0 sm:mb-4 mt-4 sm:mt-6">
         <h3 className="pb-6 break-all text-2xl text-center text-gray-700">
-          {urlizeLabel(internationalize(language, titles), titles.URL)}
+          {urlizeLabel(internationalize(language, titles.Text), titles.URL)}
         </h3>
         <div
           dangerouslySetInnerHTML={{
-            __html: DOMPurify.sanitize(configuration.AdditionalInfo, {
+            __html: DOMPurify.sanitize(internationalize(language, configuration.AdditionalInfo), {
               USE_PROFILES: { html: true },
             }),
           }}
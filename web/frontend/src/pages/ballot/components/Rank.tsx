@@ -3,7 +3,7 @@ import { Draggable, DropResult, Droppable } from 'react-beautiful-dnd';
 import { Answers, ID, RankQuestion } from 'types/configuration';
 import { answersFrom } from 'types/getObjectType';
 import HintButton from 'components/buttons/HintButton';
-import { internationalize, urlizeLabel } from './../../utils';
+import { internationalize, internationalizeChoice, urlizeLabel } from './../../utils';
 
 export const handleOnDragEnd = (
   result: DropResult,
@@ -89,7 +89,7 @@ const Rank: FC<RankProps> = ({ rank, answers, language }) => {
       <div className="grid grid-rows-1 grid-flow-col">
         <div>
           <h3 className="text-lg break-words text-gray-600">
-            {urlizeLabel(internationalize(language, titles), titles.URL)}
+            {urlizeLabel(internationalize(language, titles.Text), titles.URL)}
           </h3>
         </div>
         <div className="text-right">
@@ -102,29 +102,13 @@ const Rank: FC<RankProps> = ({ rank, answers, language }) => {
             {(provided) => (
               <ul className={rank.ID} {...provided.droppableProps} ref={provided.innerRef}>
                 {Array.from(answers.RankAnswers.get(rank.ID).entries())
-                  .map(([rankIndex, choiceIndex]) => {
-                    if (language === 'fr' && rank.ChoicesMap.ChoicesMap.has('fr'))
-                      return choiceDisplay(
-                        rank.ChoicesMap.ChoicesMap.get('fr')[choiceIndex],
-                        rank.ChoicesMap.URLs[choiceIndex],
-                        rankIndex
-                      );
-                    else if (language === 'de' && rank.ChoicesMap.ChoicesMap.has('de'))
-                      return choiceDisplay(
-                        rank.ChoicesMap.ChoicesMap.get('de')[choiceIndex],
-                        rank.ChoicesMap.URLs[choiceIndex],
-                        rankIndex
-                      );
-                    else if (rank.ChoicesMap.ChoicesMap.has('en'))
-                      // 'en' is the default language
-                      return choiceDisplay(
-                        rank.ChoicesMap.ChoicesMap.get('en')[choiceIndex],
-                        rank.ChoicesMap.URLs[choiceIndex],
-                        rankIndex
-                      );
-                    return undefined;
-                  })
-                  .filter((e) => e !== undefined)}
+                  .map(([rankIndex, choiceIndex]) =>
+                    choiceDisplay(
+                      internationalizeChoice(language, rank.Choices[choiceIndex]),
+                      rank.Choices[choiceIndex].URL,
+                      rankIndex
+                    )
+                  )}
                 {provided.placeholder}
               </ul>
             )}
@@ -3,7 +3,7 @@ import { useTranslation } from 'react-i18next';
 import { Answers, SelectQuestion } from 'types/configuration';
 import { answersFrom } from 'types/getObjectType';
 import HintButton from 'components/buttons/HintButton';
-import { internationalize, urlizeLabel } from './../../utils';
+import { internationalize, internationalizeChoice, urlizeLabel } from './../../utils';
 type SelectProps = {
   select: SelectQuestion;
   answers: Answers;
@@ -83,7 +83,7 @@ const Select: FC<SelectProps> = ({ select, answers, setAnswers, language }) => {
       <div className="grid grid-rows-1 grid-flow-col">
         <div>
           <h3 className="text-lg break-words text-gray-600">
-            {urlizeLabel(internationalize(language, titles), titles.URL)}
+            {urlizeLabel(internationalize(language, titles.Text), titles.URL)}
           </h3>
         </div>
         <div className="text-right">
@@ -93,31 +93,14 @@ const Select: FC<SelectProps> = ({ select, answers, setAnswers, language }) => {
       <div className="pt-1">{requirementsDisplay()}</div>
       <div className="sm:pl-8 mt-2 pl-6">
         {Array.from(answers.SelectAnswers.get(select.ID).entries())
-          .map(([choiceIndex, isChecked]) => {
-            if (language === 'fr' && select.ChoicesMap.ChoicesMap.has('fr'))
-              return choiceDisplay(
-                isChecked,
-                select.ChoicesMap.ChoicesMap.get('fr')[choiceIndex],
-                select.ChoicesMap.URLs[choiceIndex],
-                choiceIndex
-              );
-            else if (language === 'de' && select.ChoicesMap.ChoicesMap.has('de'))
-              return choiceDisplay(
-                isChecked,
-                select.ChoicesMap.ChoicesMap.get('de')[choiceIndex],
-                select.ChoicesMap.URLs[choiceIndex],
-                choiceIndex
-              );
-            else if (select.ChoicesMap.ChoicesMap.has('en'))
-              return choiceDisplay(
-                isChecked,
-                select.ChoicesMap.ChoicesMap.get('en')[choiceIndex],
-                select.ChoicesMap.URLs[choiceIndex],
-                choiceIndex
-              );
-            return undefined;
-          })
-          .filter((e) => e !== undefined)}
+          .map(([choiceIndex, isChecked]) =>
+            choiceDisplay(
+              isChecked,
+              internationalizeChoice(language, select.Choices[choiceIndex]),
+              select.Choices[choiceIndex].URL,
+              choiceIndex
+            )
+          )}
       </div>
       <div className="text-red-600 text-sm py-2 sm:pl-4 pl-2">{answers.Errors.get(select.ID)}</div>
     </div>
@@ -3,7 +3,7 @@ import { useTranslation } from 'react-i18next';
 import { Answers, TextQuestion } from 'types/configuration';
 import { answersFrom } from 'types/getObjectType';
 import HintButton from 'components/buttons/HintButton';
-import { internationalize, urlizeLabel } from './../../utils';
+import { internationalize, internationalizeChoice, urlizeLabel } from './../../utils';
 
 type TextProps = {
   text: TextQuestion;
@@ -103,7 +103,7 @@ const Text: FC<TextProps> = ({ text, answers, setAnswers, language }) => {
       <div className="grid grid-rows-1 grid-flow-col">
         <div>
           <h3 className="text-lg break-words text-gray-600 w-96">
-            {urlizeLabel(internationalize(language, text.Title), text.Title.URL)}
+            {urlizeLabel(internationalize(language, text.Title.Text), text.Title.URL)}
           </h3>
         </div>
         <div className="text-right">
@@ -111,19 +111,11 @@ const Text: FC<TextProps> = ({ text, answers, setAnswers, language }) => {
         </div>
       </div>
       <div className="pt-1">{requirementsDisplay()}</div>
-      {text.ChoicesMap.ChoicesMap.has(language) ? (
-        <div className="sm:pl-8 mt-2 pl-6">
-          {text.ChoicesMap.ChoicesMap.get(language).map((choice, index) =>
-            choiceDisplay(choice, text.ChoicesMap.URLs[index], index)
-          )}
-        </div>
-      ) : (
-        <div className="sm:pl-8 mt-2 pl-6">
-          {text.ChoicesMap.ChoicesMap.get('en').map((choice, index) =>
-            choiceDisplay(choice, text.ChoicesMap.URLs[index], index)
-          )}
-        </div>
-      )}
+      <div className="sm:pl-8 mt-2 pl-6">
+        {text.Choices.map((choice, index) =>
+          choiceDisplay(internationalizeChoice(language, choice), choice.URL, index)
+        )}
+      </div>
       <div className="text-red-600 text-sm py-2 sm:pl-2 pl-1">{answers.Errors.get(text.ID)}</div>
     </div>
   );
@@ -25,20 +25,29 @@ import {
   countRankResult,
   countSelectResult,
   countTextResult,
+  totalWeight,
 } from './components/utils/countResult';
 import { default as i18n } from 'i18next';
 import SelectResult from './components/SelectResult';
 import TextResult from './components/TextResult';
 import { internationalize, urlizeLabel } from './../utils';
+import { translate } from 'types/langMap';
 
 type GroupedResultProps = {
   rankResult: RankResults;
   selectResult: SelectResults;
   textResult: TextResults;
+  // weights holds the weight of each ballot of the results
+  weights: number[];
 };
 
 // Functional component that displays the result of the votes
-const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textResult }) => {
+const GroupedResult: FC<GroupedResultProps> = ({
+  rankResult,
+  selectResult,
+  textResult,
+  weights,
+}) => {
   const { formId } = useParams();
   const navigate = useNavigate();
   const { t } = useTranslation();
@@ -60,19 +69,24 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
           <div className="align-text-middle flex mt-1 mr-2 h-5 w-5" aria-hidden="true">
             {questionIcons[element.Type]}
           </div>
-          <h2 className="text-lg pb-2">{internationalize(i18n.language, titles)}</h2>
+          <h2 className="text-lg pb-2">{internationalize(i18n.language, titles.Text)}</h2>
         </div>
         {element.Type === RANK && rankResult.has(element.ID) && (
-          <RankResult rank={element as RankQuestion} rankResult={rankResult.get(element.ID)} />
+          <RankResult
+            rank={element as RankQuestion}
+            rankResult={rankResult.get(element.ID)}
+            weights={weights}
+          />
         )}
         {element.Type === SELECT && selectResult.has(element.ID) && (
           <SelectResult
             select={element as SelectQuestion}
             selectResult={selectResult.get(element.ID)}
+            weights={weights}
           />
         )}
         {element.Type === TEXT && textResult.has(element.ID) && (
-          <TextResult textResult={textResult.get(element.ID)} />
+          <TextResult textResult={textResult.get(element.ID)} weights={weights} />
         )}
       </div>
     );
@@ -82,7 +96,7 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
     return (
       <div key={subject.ID}>
         <h2 className="text-xl pt-1 pb-1 sm:pt-2 sm:pb-2 border-t font-bold text-gray-600">
-          {urlizeLabel(internationalize(i18n.language, subject.Title), subject.Title.URL)}
+          {urlizeLabel(internationalize(i18n.language, subject.Title.Text), subject.Title.URL)}
         </h2>
         {subject.Order.map((id: ID) => (
           <div key={id}>
@@ -100,7 +114,7 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
   };
 
   const getResultData = (subject: Subject, dataToDownload: DownloadedResults[]) => {
-    dataToDownload.push({ Title: subject.Title.En, URL: subject.Title.URL });
+    dataToDownload.push({ Title: translate(subject.Title.Text, 'en'), URL: subject.Title.URL });
 
     subject.Order.forEach((id: ID) => {
       const element = subject.Elements.get(id);
@@ -111,12 +125,21 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
           const rank = element as RankQuestion;
 
           if (rankResult.has(id)) {
-            res = countRankResult(rankResult.get(id), element as RankQuestion).resultsInPercent.map(
-              (percent, index) => {
-                return { Candidate: rank.Choices[index], Percentage: `${percent}%` };
-              }
-            );
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
+            res = countRankResult(
+              rankResult.get(id),
+              element as RankQuestion,
+              weights
+            ).resultsInPercent.map((percent, index) => {
+              return {
+                Candidate: translate(rank.Choices[index].Choice, 'en'),
+                Percentage: `${percent}%`,
+              };
+            });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
+            });
           }
           break;
 
@@ -124,16 +147,20 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
           const select = element as SelectQuestion;
 
           if (selectResult.has(id)) {
-            res = countSelectResult(selectResult.get(id))
+            res = countSelectResult(selectResult.get(id), weights)
               .map(([, totalCount], index) => {
                 return {
-                  Candidate: select.Choices[index],
+                  Candidate: translate(select.Choices[index].Choice, 'en'),
                   TotalCount: totalCount,
-                  NumberOfBallots: selectResult.get(id).length, // number of combined ballots for this election
+                  NumberOfBallots: totalWeight(weights, selectResult.get(id).length), // weighted number of combined ballots for this election
                 };
               })
               .sort((x, y) => y.TotalCount - x.TotalCount);
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
+            });
           }
           break;
 
@@ -143,10 +170,14 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
 
         case TEXT:
           if (textResult.has(id)) {
-            res = Array.from(countTextResult(textResult.get(id)).resultsInPercent).map((r) => {
+            res = Array.from(countTextResult(textResult.get(id), weights).resultsInPercent).map((r) => {
               return { Candidate: r[0], Percentage: `${r[1]}%` };
             });
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
+            });
           }
           break;
       }
@@ -154,10 +185,9 @@ const GroupedResult: FC<GroupedResultProps> = ({ rankResult, selectResult, textR
   };
 
   const exportJSONData = () => {
-    const fileName = `result_${configuration.Title.En.replace(/[^a-zA-Z0-9]/g, '_').slice(
-      0,
-      99
-    )}__grouped`; // replace spaces with underscores;
+    const fileName = `result_${translate(configuration.Title.Text, 'en')
+      .replace(/[^a-zA-Z0-9]/g, '_')
+      .slice(0, 99)}__grouped`; // replace spaces with underscores;
 
     const dataToDownload: DownloadedResults[] = [];
 
@@ -10,6 +10,7 @@ import { IndividualSelectResult } from './components/SelectResult';
 import { IndividualTextResult } from './components/TextResult';
 import { IndividualRankResult } from './components/RankResult';
 import { internationalize, urlizeLabel } from './../utils';
+import { translate } from 'types/langMap';
 import { useTranslation } from 'react-i18next';
 import {
   ID,
@@ -77,7 +78,10 @@ const IndividualResult: FC<IndividualResultProps> = ({
               {questionIcons[element.Type]}
             </div>
             <h2 className="flex align-text-middle text-lg pb-2">
-              {urlizeLabel(internationalize(i18n.language, element.Title), element.Title.URL)}
+              {urlizeLabel(
+                internationalize(i18n.language, element.Title.Text),
+                element.Title.URL
+              )}
             </h2>
           </div>
           {element.Type === RANK && rankResult.has(element.ID) && (
@@ -109,7 +113,7 @@ const IndividualResult: FC<IndividualResultProps> = ({
       return (
         <div key={subject.ID}>
           <h2 className="text-xl pt-1 pb-1 sm:pt-2 sm:pb-2 border-t font-bold text-gray-600">
-            {urlizeLabel(internationalize(i18n.language, subject.Title), subject.Title.URL)}
+            {urlizeLabel(internationalize(i18n.language, subject.Title.Text), subject.Title.URL)}
           </h2>
           {subject.Order.map((id: ID) => (
             <div key={id}>
@@ -133,7 +137,7 @@ const IndividualResult: FC<IndividualResultProps> = ({
     dataToDownload: DownloadedResults[],
     BallotID: number
   ) => {
-    dataToDownload.push({ Title: subject.Title.En, URL: subject.Title.URL });
+    dataToDownload.push({ Title: translate(subject.Title.Text, 'en'), URL: subject.Title.URL });
 
     subject.Order.forEach((id: ID) => {
       const element = subject.Elements.get(id);
@@ -145,12 +149,17 @@ const IndividualResult: FC<IndividualResultProps> = ({
 
           if (rankResult.has(id)) {
             res = rankResult.get(id)[BallotID].map((rank, index) => {
+              const choice = rankQues.Choices[rankResult.get(id)[BallotID].indexOf(index)];
               return {
                 Rank: `${index + 1}`,
-                Choice: rankQues.Choices[rankResult.get(id)[BallotID].indexOf(index)],
+                Choice: translate(choice.Choice, 'en'),
               };
             });
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
+            });
           }
           break;
 
@@ -160,9 +169,16 @@ const IndividualResult: FC<IndividualResultProps> = ({
           if (selectResult.has(id)) {
             res = selectResult.get(id)[BallotID].map((select, index) => {
               const checked = select ? 'True' : 'False';
-              return { Candidate: selectQues.Choices[index], Checked: checked };
+              return {
+                Candidate: translate(selectQues.Choices[index].Choice, 'en'),
+                Checked: checked,
+              };
+            });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
             });
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
           }
           break;
 
@@ -175,9 +191,13 @@ const IndividualResult: FC<IndividualResultProps> = ({
 
           if (textResult.has(id)) {
             res = textResult.get(id)[BallotID].map((text, index) => {
-              return { Field: textQues.Choices[index], Answer: text };
+              return { Field: translate(textQues.Choices[index].Choice, 'en'), Answer: text };
+            });
+            dataToDownload.push({
+              Title: translate(element.Title.Text, 'en'),
+              URL: element.Title.URL,
+              Results: res,
             });
-            dataToDownload.push({ Title: element.Title.En, URL: element.Title.URL, Results: res });
           }
           break;
       }
@@ -185,10 +205,9 @@ const IndividualResult: FC<IndividualResultProps> = ({
   };
 
   const exportJSONData = () => {
-    const fileName = `result_${configuration.Title.En.replace(/[^a-zA-Z0-9]/g, '_').slice(
-      0,
-      99
-    )}__individual`;
+    const fileName = `result_${translate(configuration.Title.Text, 'en')
+      .replace(/[^a-zA-Z0-9]/g, '_')
+      .slice(0, 99)}__individual`;
     const ballotsToDownload: BallotResults[] = [];
 
     const indices: number[] = [...Array(ballotNumber).keys()];
@@ -26,6 +26,7 @@ const FormResult: FC = () => {
   const [rankResult, setRankResult] = useState<RankResults>(null);
   const [selectResult, setSelectResult] = useState<SelectResults>(null);
   const [textResult, setTextResult] = useState<TextResults>(null);
+  const [weights, setWeights] = useState<number[]>([]);
 
   // Group the different results by the ID of the question,
   const groupByID = (
@@ -57,6 +58,8 @@ const FormResult: FC = () => {
     let selectRes: SelectResults = new Map<ID, number[][]>();
     let rankRes: RankResults = new Map<ID, number[][]>();
     let textRes: TextResults = new Map<ID, string[][]>();
+    // the weight of each ballot, in the order of the grouped results
+    let ballotWeights: number[] = [];
 
     result.forEach((res) => {
       if (
@@ -64,21 +67,24 @@ const FormResult: FC = () => {
         res.RankResultIDs !== null &&
         res.TextResultIDs !== null
       ) {
+        ballotWeights.push(res.Weight ?? 1);
+
         groupByID(selectRes, res.SelectResultIDs, res.SelectResult, true);
         groupByID(rankRes, res.RankResultIDs, res.RankResult);
         groupByID(textRes, res.TextResultIDs, res.TextResult);
       }
     });
 
-    return { rankRes, selectRes, textRes };
+    return { rankRes, selectRes, textRes, ballotWeights };
   };
   useEffect(() => {
     if (result !== null) {
-      const { rankRes, selectRes, textRes } = groupResultsByID();
+      const { rankRes, selectRes, textRes, ballotWeights } = groupResultsByID();
 
       setRankResult(rankRes);
       setSelectResult(selectRes);
       setTextResult(textRes);
+      setWeights(ballotWeights);
     }
     // eslint-disable-next-line react-hooks/exhaustive-deps
   }, [result]);
@@ -100,15 +106,18 @@ const FormResult: FC = () => {
             </h2>
             <h3 className="py-6 border-t text-2xl text-center text-gray-700">
               {urlizeLabel(
-                internationalize(i18n.language, configuration.Title),
+                internationalize(i18n.language, configuration.Title.Text),
                 configuration.Title.URL
               )}
             </h3>
             <div
               dangerouslySetInnerHTML={{
-                __html: DOMPurify.sanitize(configuration.AdditionalInfo, {
-                  USE_PROFILES: { html: true },
-                }),
+                __html: DOMPurify.sanitize(
+                  internationalize(i18n.language, configuration.AdditionalInfo),
+                  {
+                    USE_PROFILES: { html: true },
+                  }
+                ),
               }}
             />
 
@@ -139,6 +148,7 @@ const FormResult: FC = () => {
                     rankResult={rankResult}
                     selectResult={selectResult}
                     textResult={textResult}
+                    weights={weights}
                   />
                 </Tab.Panel>
                 <Tab.Panel>
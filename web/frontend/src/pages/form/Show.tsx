@@ -18,6 +18,7 @@ import LoadingButton from './components/LoadingButton';
 import { internationalize, urlizeLabel } from './../utils';
 import { default as i18n } from 'i18next';
 import DOMPurify from 'dompurify';
+import { unmarshalTitle } from 'types/JSONparser';
 
 const FormShow: FC = () => {
   const { t } = useTranslation();
@@ -204,7 +205,7 @@ const FormShow: FC = () => {
   useEffect(() => {
     try {
       if (configObj.Title === undefined) return;
-      setTitles(configObj.Title);
+      setTitles(unmarshalTitle(configObj.Title));
     } catch (e) {
       setError(e.error);
     }
@@ -224,13 +225,16 @@ const FormShow: FC = () => {
       {!loading ? (
         <>
           <div className="pt-8 text-2xl font-bold leading-7 text-gray-900 sm:text-3xl sm:truncate">
-            {urlizeLabel(internationalize(i18n.language, titles), titles.URL)}
+            {urlizeLabel(internationalize(i18n.language, titles.Text), titles.URL)}
           </div>
           <div
             dangerouslySetInnerHTML={{
-              __html: DOMPurify.sanitize(configObj.AdditionalInfo, {
-                USE_PROFILES: { html: true },
-              }),
+              __html: DOMPurify.sanitize(
+                internationalize(i18n.language, configObj.AdditionalInfo),
+                {
+                  USE_PROFILES: { html: true },
+                }
+              ),
             }}
           />
 
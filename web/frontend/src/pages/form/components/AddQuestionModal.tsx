@@ -12,6 +12,7 @@ import {
   TextQuestion,
 } from 'types/configuration';
 import { ranksSchema, selectsSchema, textsSchema } from '../../../schema/configurationValidation';
+import { translate } from 'types/langMap';
 import useQuestionForm from './utils/useQuestionForm';
 import DisplayTypeIcon from './DisplayTypeIcon';
 
@@ -193,9 +194,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       </label>
                       {(language === 'en' || !['en', 'fr', 'de'].includes(language)) && (
                         <input
-                          value={Title.En}
+                          value={translate(Title.Text, 'en')}
                           onChange={(e) => handleChange('Title')(e)}
-                          name="En"
+                          name="en"
                           type="text"
                           placeholder={t('enterTitleLg')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -203,9 +204,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       )}
                       {language === 'fr' && (
                         <input
-                          value={Title.Fr}
+                          value={translate(Title.Text, 'fr')}
                           onChange={(e) => handleChange('Title')(e)}
-                          name="Fr"
+                          name="fr"
                           type="text"
                           placeholder={t('enterTitleLg1')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -213,9 +214,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       )}
                       {language === 'de' && (
                         <input
-                          value={Title.De}
+                          value={translate(Title.Text, 'de')}
                           onChange={(e) => handleChange('Title')(e)}
-                          name="De"
+                          name="de"
                           type="text"
                           placeholder={t('enterTitleLg2')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -243,9 +244,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       </label>
                       {(language === 'en' || !['en', 'fr', 'de'].includes(language)) && (
                         <input
-                          value={Hint.En}
+                          value={translate(Hint, 'en')}
                           onChange={(e) => handleChange('Hint')(e)}
-                          name="En"
+                          name="en"
                           type="text"
                           placeholder={t('enterHintLg')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -253,9 +254,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       )}
                       {language === 'fr' && (
                         <input
-                          value={Hint.Fr}
+                          value={translate(Hint, 'fr')}
                           onChange={(e) => handleChange('Hint')(e)}
-                          name="Fr"
+                          name="fr"
                           type="text"
                           placeholder={t('enterHintLg1')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -263,9 +264,9 @@ const AddQuestionModal: FC<AddQuestionModalProps> = ({
                       )}
                       {language === 'de' && (
                         <input
-                          value={Hint.De}
+                          value={translate(Hint, 'de')}
                           onChange={(e) => handleChange('Hint')(e)}
-                          name="De"
+                          name="de"
                           type="text"
                           placeholder={t('enterHintLg2')}
                           className="my-1 px-1 w-60 ml-1 border rounded-md"
@@ -14,6 +14,7 @@ import configurationSchema from '../../../schema/configurationValidation';
 import { Configuration, ID, Subject } from '../../../types/configuration';
 import { emptyConfiguration, newSubject } from '../../../types/getObjectType';
 import { marshalConfig } from '../../../types/JSONparser';
+import { setTranslation, translate } from '../../../types/langMap';
 import DownloadButton from 'components/buttons/DownloadButton';
 import SpinnerIcon from 'components/utils/SpinnerIcon';
 import RedirectToModal from 'components/modal/RedirectToModal';
@@ -59,6 +60,7 @@ const FormForm: FC<FormFormProps> = () => {
   const { Title, Scaffold, AdditionalInfo } = conf;
 
   const [language, setLanguage] = useState(i18n.language);
+  const englishTitle = translate(Title.Text, 'en');
   const regexPattern = /[^a-zA-Z0-9]/g;
   const fctx = useContext(FlashContext);
   const [postError, setPostError] = useState(null);
@@ -154,11 +156,15 @@ const FormForm: FC<FormFormProps> = () => {
     const jsonString = `data:text/json;chatset=utf-8,${encodeURIComponent(JSON.stringify(data))}`;
     const link = document.createElement('a');
     link.href = jsonString;
-    const title = Title.En.replace(regexPattern, '_').slice(0, 99); // replace spaces with underscores
+    const title = englishTitle.replace(regexPattern, '_').slice(0, 99); // replace spaces with underscores
     link.download = title + '.json';
     link.click();
   };
 
+  const setTitleText = (lang: string, text: string) => {
+    setConf({ ...conf, Title: { ...Title, Text: setTranslation(Title.Text, lang, text) } });
+  };
+
   // Called by any of our subject child when they update their schema.
   const notifyParent = (subject: Subject) => {
     const newSubjects = [...Scaffold];
@@ -194,8 +200,8 @@ const FormForm: FC<FormFormProps> = () => {
 
                 {(language === 'en' || !['en', 'fr', 'de'].includes(language)) && (
                   <input
-                    value={Title.En}
-                    onChange={(e) => setConf({ ...conf, Title: { ...Title, En: e.target.value } })}
+                    value={translate(Title.Text, 'en')}
+                    onChange={(e) => setTitleText('en', e.target.value)}
                     name="Title"
                     type="text"
                     placeholder={t('enterMainTitleLg')}
@@ -204,8 +210,8 @@ const FormForm: FC<FormFormProps> = () => {
                 )}
                 {language === 'fr' && (
                   <input
-                    value={Title.Fr}
-                    onChange={(e) => setConf({ ...conf, Title: { ...Title, Fr: e.target.value } })}
+                    value={translate(Title.Text, 'fr')}
+                    onChange={(e) => setTitleText('fr', e.target.value)}
                     name="MainTitle1"
                     type="text"
                     placeholder={t('enterMainTitleLg1')}
@@ -214,8 +220,8 @@ const FormForm: FC<FormFormProps> = () => {
                 )}
                 {language === 'de' && (
                   <input
-                    value={Title.De}
-                    onChange={(e) => setConf({ ...conf, Title: { ...Title, De: e.target.value } })}
+                    value={translate(Title.Text, 'de')}
+                    onChange={(e) => setTitleText('de', e.target.value)}
                     name="MainTitle2"
                     type="text"
                     placeholder={t('enterMainTitleLg2')}
@@ -231,11 +237,11 @@ const FormForm: FC<FormFormProps> = () => {
                   className="m-3 px-1 w-100 text-lg border rounded-md"
                 />
                 <input
-                  value={AdditionalInfo}
+                  value={translate(AdditionalInfo, language)}
                   onChange={(e) =>
                     setConf({
                       ...conf,
-                      AdditionalInfo: e.target.value,
+                      AdditionalInfo: setTranslation(AdditionalInfo, language, e.target.value),
                     })
                   }
                   name="AdditionalInfo"
@@ -246,9 +252,9 @@ const FormForm: FC<FormFormProps> = () => {
                 <div className="ml-1">
                   <button
                     className={`border p-1 rounded-md ${
-                      Title.En.length === 0 ? 'bg-gray-100' : ' '
+                      englishTitle.length === 0 ? 'bg-gray-100' : ' '
                     }`}
-                    disabled={Title.En.length === 0}
+                    disabled={englishTitle.length === 0}
                     onClick={() => setTitleChanging(false)}>
                     <CheckIcon className="h-5 w-5" aria-hidden="true" />
                   </button>
@@ -259,11 +265,13 @@ const FormForm: FC<FormFormProps> = () => {
                 <div
                   className="mt-1 ml-3 w-[90%] break-words"
                   onClick={() => setTitleChanging(true)}>
-                  {urlizeLabel(internationalize(language, Title), Title.URL)}
+                  {urlizeLabel(internationalize(language, Title.Text), Title.URL)}
                 </div>
                 <div
                   dangerouslySetInnerHTML={{
-                    __html: DOMPurify.sanitize(AdditionalInfo, { USE_PROFILES: { html: true } }),
+                    __html: DOMPurify.sanitize(internationalize(language, AdditionalInfo), {
+                      USE_PROFILES: { html: true },
+                    }),
                   }}
                 />
                 <div className="ml-1">
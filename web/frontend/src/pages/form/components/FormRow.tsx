@@ -1,10 +1,12 @@
-import React, { FC, useContext, useEffect, useState } from 'react';
+import React, { FC, useContext } from 'react';
 import { LightFormInfo } from 'types/form';
 import { Link } from 'react-router-dom';
 import FormStatus from './FormStatus';
 import QuickAction from './QuickAction';
 import { default as i18n } from 'i18next';
 import { AuthContext } from '../../..';
+import { internationalize } from '../../utils';
+import { unmarshalTitle } from 'types/JSONparser';
 
 type FormRowProps = {
   form: LightFormInfo;
@@ -15,23 +17,10 @@ const ACTION_CREATE = 'create';
 
 const FormRow: FC<FormRowProps> = ({ form }) => {
   const Blocklist = process.env.REACT_APP_BLOCKLIST?.split(',') ?? [];
-  const [titles, setTitles] = useState<any>({});
   const authCtx = useContext(AuthContext);
-  useEffect(() => {
-    if (form.Title === undefined) return;
-    setTitles({ En: form.Title.En, Fr: form.Title.Fr, De: form.Title.De, URL: form.Title.URL });
-  }, [form]);
-  // let i18next handle choosing the appropriate language
-  const formRowI18n = i18n.createInstance();
-  formRowI18n.init();
-  // get current language
-  formRowI18n.changeLanguage(i18n.language);
-  Object.entries(titles).forEach(([lang, title]: [string, string | undefined]) => {
-    if (title) {
-      formRowI18n.addResource(lang.toLowerCase(), 'form', 'title', title);
-    }
-  });
-  const formTitle = formRowI18n.t('title', { ns: 'form', fallbackLng: 'en' });
+  // fall back to English if the title isn't translated into the current language
+  const { Text } = unmarshalTitle(form.Title);
+  const formTitle = internationalize(i18n.language, Text) || internationalize('en', Text);
   const isAdmin = authCtx.isLogged && authCtx.isAllowed(SUBJECT_ELECTION, ACTION_CREATE);
   const isBlocked = Blocklist.includes(form.FormID);
   if (!isAdmin && isBlocked) return null;
@@ -54,7 +54,7 @@ const Question: FC<QuestionProps> = ({ question, notifyParent, removeQuestion, l
               <DisplayTypeIcon Type={Type} />
             </div>
             <div className="pt-1.5 max-w-md pr-8 truncate">
-              {urlizeLabel(internationalize(language, Title), Title.URL)}
+              {urlizeLabel(internationalize(language, Title.Text), Title.URL)}
             </div>
           </div>
 
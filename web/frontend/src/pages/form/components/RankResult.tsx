@@ -7,11 +7,14 @@ import { prettifyChoice } from './utils/display';
 type RankResultProps = {
   rank: RankQuestion;
   rankResult: number[][];
+  // weights holds the weight of each ballot, they all count once if it is not
+  // set
+  weights?: number[];
 };
 
 // Display the results of a rank question.
-const RankResult: FC<RankResultProps> = ({ rank, rankResult }) => {
-  const { resultsInPercent, minIndices } = countRankResult(rankResult, rank);
+const RankResult: FC<RankResultProps> = ({ rank, rankResult, weights }) => {
+  const { resultsInPercent, minIndices } = countRankResult(rankResult, rank, weights);
 
   const displayResults = () => {
     return resultsInPercent.map((percent, index) => {
@@ -20,7 +23,7 @@ const RankResult: FC<RankResultProps> = ({ rank, rankResult }) => {
       return (
         <React.Fragment key={index}>
           <div className="px-2 sm:px-4 break-words max-w-xs w-max">
-            <span>{prettifyChoice(rank.ChoicesMap, index)}</span>:
+            <span>{prettifyChoice(rank.Choices, index)}</span>:
           </div>
           <ProgressBar isBest={isBest}>{percent}</ProgressBar>
         </React.Fragment>
@@ -43,7 +46,7 @@ export const IndividualRankResult: FC<RankResultProps> = ({ rank, rankResult })
           <React.Fragment key={`rank_${index}`}>
             <div className="flex flex-row px-2 sm:px-4 break-words max-w-xs w-max">
               <div className="mr-2 font-bold">{index + 1}:</div>
-              <div>{prettifyChoice(rank.ChoicesMap, rankResult[0].indexOf(index))}</div>
+              <div>{prettifyChoice(rank.Choices, rankResult[0].indexOf(index))}</div>
             </div>
           </React.Fragment>
         );
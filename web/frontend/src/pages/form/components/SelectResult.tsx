@@ -1,17 +1,20 @@
 import React, { FC } from 'react';
 import { SelectQuestion } from 'types/configuration';
 import { SelectProgressBar } from './ProgressBar';
-import { countSelectResult } from './utils/countResult';
+import { countSelectResult, totalWeight } from './utils/countResult';
 import { prettifyChoice } from './utils/display';
 
 type SelectResultProps = {
   select: SelectQuestion;
   selectResult: number[][];
+  // weights holds the weight of each ballot, they all count once if it is not
+  // set
+  weights?: number[];
 };
 
 // Display the results of a select question.
-const SelectResult: FC<SelectResultProps> = ({ select, selectResult }) => {
-  const sortedResults = countSelectResult(selectResult)
+const SelectResult: FC<SelectResultProps> = ({ select, selectResult, weights }) => {
+  const sortedResults = countSelectResult(selectResult, weights)
     .map((result, index) => {
       const tempResult: [string, number, number] = [...result, index];
       return tempResult;
@@ -24,12 +27,12 @@ const SelectResult: FC<SelectResultProps> = ({ select, selectResult }) => {
       return (
         <React.Fragment key={index}>
           <div className="px-2 sm:px-4 break-words max-w-xs w-max">
-            <span>{prettifyChoice(select.ChoicesMap, origIndex)}</span>:
+            <span>{prettifyChoice(select.Choices, origIndex)}</span>:
           </div>
           <SelectProgressBar
             percent={percent}
             totalCount={totalCount}
-            numberOfBallots={selectResult.length}
+            numberOfBallots={totalWeight(weights, selectResult.length)}
             isBest={totalCount === maxCount}></SelectProgressBar>
         </React.Fragment>
       );
@@ -58,7 +61,7 @@ export const IndividualSelectResult: FC<SelectResultProps> = ({ select, selectRe
           <React.Fragment key={`select_${index}`}>
             <div className="flex flex-row px-2 sm:px-4 break-words max-w-xs w-max">
               <div className="h-4 w-4 mr-2 accent-[#ff0000] ">{displayChoices(result, index)}</div>
-              <div>{prettifyChoice(select.ChoicesMap, index)}</div>
+              <div>{prettifyChoice(select.Choices, index)}</div>
             </div>
           </React.Fragment>
         );
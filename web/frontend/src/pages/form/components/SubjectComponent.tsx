@@ -4,6 +4,7 @@ import PropTypes from 'prop-types';
 import * as types from '../../../types/configuration';
 import { RANK, SELECT, SUBJECT, TEXT } from '../../../types/configuration';
 import { newRank, newSelect, newSubject, newText } from '../../../types/getObjectType';
+import { setTranslation, translate } from '../../../types/langMap';
 
 import Question from './Question';
 import SubjectDropdown from './SubjectDropdown';
@@ -47,7 +48,7 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
   const isSubjectMounted = useRef<boolean>(false);
   const [isOpen, setIsOpen] = useState<boolean>(false);
   const [titleChanging, setTitleChanging] = useState<boolean>(
-    subjectObject.Title.En.length ? false : true
+    translate(subjectObject.Title.Text, 'en').length ? false : true
   );
   const [openModal, setOpenModal] = useState<boolean>(false);
   const [showRemoveElementModal, setShowRemoveElementModal] = useState<boolean>(false);
@@ -56,6 +57,10 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
   const [components, setComponents] = useState<ReactElement[]>([]);
 
   const { Title, Order, Elements } = subject;
+  const englishTitle = translate(Title.Text, 'en');
+  const setTitleText = (lang: string, text: string) => {
+    setSubject({ ...subject, Title: { ...Title, Text: setTranslation(Title.Text, lang, text) } });
+  };
   // When a property changes, we notify the parent with the new subject object
   useEffect(() => {
     // We only notify the parent when the subject is mounted
@@ -309,10 +314,8 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
               <div className="flex flex-col mt-3  mb-2">
                 {(language === 'en' || !['en', 'fr', 'de'].includes(language)) && (
                   <input
-                    value={Title.En}
-                    onChange={(e) =>
-                      setSubject({ ...subject, Title: { ...Title, En: e.target.value } })
-                    }
+                    value={translate(Title.Text, 'en')}
+                    onChange={(e) => setTitleText('en', e.target.value)}
                     name="Title"
                     type="text"
                     placeholder={t('enterSubjectTitleLg')}
@@ -323,10 +326,8 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
                 )}
                 {language === 'fr' && (
                   <input
-                    value={Title.Fr}
-                    onChange={(e) =>
-                      setSubject({ ...subject, Title: { ...Title, Fr: e.target.value } })
-                    }
+                    value={translate(Title.Text, 'fr')}
+                    onChange={(e) => setTitleText('fr', e.target.value)}
                     name="Title"
                     type="text"
                     placeholder={t('enterSubjectTitleLg1')}
@@ -337,10 +338,8 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
                 )}
                 {language === 'de' && (
                   <input
-                    value={Title.De}
-                    onChange={(e) =>
-                      setSubject({ ...subject, Title: { ...Title, De: e.target.value } })
-                    }
+                    value={translate(Title.Text, 'de')}
+                    onChange={(e) => setTitleText('de', e.target.value)}
                     name="Title"
                     type="text"
                     placeholder={t('enterSubjectTitleLg2')}
@@ -363,8 +362,8 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
                 />
                 <div className="ml-1">
                   <button
-                    className={`border p-1 rounded-md ${Title.En.length === 0 && 'bg-gray-100'}`}
-                    disabled={Title.En.length === 0}
+                    className={`border p-1 rounded-md ${englishTitle.length === 0 && 'bg-gray-100'}`}
+                    disabled={englishTitle.length === 0}
                     onClick={() => setTitleChanging(false)}>
                     <CheckIcon className="h-5 w-5" aria-hidden="true" />
                   </button>
@@ -373,7 +372,7 @@ const SubjectComponent: FC<SubjectComponentProps> = ({
             ) : (
               <div className="flex mb-2 max-w-md truncate">
                 <div className="pt-1.5 truncate" onClick={() => setTitleChanging(true)}>
-                  {internationalize(language, Title)}
+                  {internationalize(language, Title.Text)}
                 </div>
                 <div className="ml-1 pr-10">
                   <button
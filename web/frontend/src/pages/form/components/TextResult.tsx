@@ -6,6 +6,9 @@ import { prettifyChoice } from './utils/display';
 
 type TextResultProps = {
   textResult: string[][];
+  // weights holds the weight of each ballot, they all count once if it is not
+  // set
+  weights?: number[];
 };
 type IndividualTextResultProps = {
   text: TextQuestion;
@@ -13,8 +16,8 @@ type IndividualTextResultProps = {
 };
 
 // Display the results of a text question.
-const TextResult: FC<TextResultProps> = ({ textResult }) => {
-  const { resultsInPercent, maxKey } = countTextResult(textResult);
+const TextResult: FC<TextResultProps> = ({ textResult, weights }) => {
+  const { resultsInPercent, maxKey } = countTextResult(textResult, weights);
 
   const displayResults = () => {
     return Array.from(resultsInPercent).map(([textAnswer, result]) => {
@@ -45,7 +48,7 @@ export const IndividualTextResult: FC<IndividualTextResultProps> = ({ text, text
         return (
           <React.Fragment key={`txt_${index}`}>
             <div className="flex flex-row px-2 sm:px-4 break-words max-w-xs w-max">
-              <div className="mr-2 font-bold">{prettifyChoice(text.ChoicesMap, index)}:</div>
+              <div className="mr-2 font-bold">{prettifyChoice(text.Choices, index)}:</div>
               <div>{result}</div>
             </div>
           </React.Fragment>
@@ -1,21 +1,39 @@
 import { RankQuestion } from 'types/configuration';
 
+// Returns the weight of the ballot at the given index, which is 1 if the
+// ballots are not weighted
+const weightOf = (weights: number[] | undefined, index: number) => weights?.[index] ?? 1;
+
+// Returns the total weight of the given number of ballots, which is the number
+// of ballots if they are not weighted
+const totalWeight = (weights: number[] | undefined, ballotCount: number) => {
+  let total = 0;
+
+  for (let i = 0; i < ballotCount; i++) {
+    total += weightOf(weights, i);
+  }
+
+  return total;
+};
+
 // Sum the position for each candidate such that a low score is better
 // (e.g if choice 1 ranked first and then fourth then it will have a
 // score of (1-1 + 4-1) = 3) and returns the counts and the candidate(s)
-// with the lowest score
-const countRankResult = (rankResult: number[][], rank: RankQuestion) => {
+// with the lowest score. The position given by a weighted ballot is
+// multiplied by its weight.
+const countRankResult = (rankResult: number[][], rank: RankQuestion, weights?: number[]) => {
   const resultsInPercent: string[] = [];
   const minIndices: number[] = [];
-  // the maximum score achievable is (number of choices - 1) * number of ballots
+  // the maximum score achievable is (number of choices - 1) * total weight of
+  // the ballots
 
-  let min = (rank.ChoicesMap.ChoicesMap.get('en').length - 1) * rankResult.length;
+  let min = (rank.Choices.length - 1) * totalWeight(weights, rankResult.length);
 
-  const results = rankResult.reduce((a, b) => {
-    return a.map((value, index) => {
-      return value + b[index];
-    });
-  });
+  const results = rankResult.reduce(
+    (tally, currBallot, ballotIndex) =>
+      tally.map((value, index) => value + currBallot[index] * weightOf(weights, ballotIndex)),
+    new Array(rankResult[0].length).fill(0)
+  );
 
   // Total number of "points" attributed
   const total = results.reduceRight((a, b) => {
@@ -40,18 +58,23 @@ const countRankResult = (rankResult: number[][], rank: RankQuestion) => {
 
 // Count the number of vote for a candidate and returns the counts as a
 // percentage of the total number of votes and which candidate(s) in the
-// select.Choices has the most votes
-const countSelectResult = (selectResult: number[][]) => {
+// select.Choices has the most votes. A weighted ballot counts as many votes
+// as its weight.
+const countSelectResult = (selectResult: number[][], weights?: number[]) => {
   const results: [string, number][] = [];
+  const ballotsWeight = totalWeight(weights, selectResult.length);
 
   selectResult
     .reduce(
-      (tally, currBallot) => tally.map((currCount, index) => currCount + currBallot[index]),
+      (tally, currBallot, ballotIndex) =>
+        tally.map(
+          (currCount, index) => currCount + currBallot[index] * weightOf(weights, ballotIndex)
+        ),
       new Array(selectResult[0].length).fill(0)
     )
     .forEach((totalCount) => {
       results.push([
-        (Math.round((totalCount / selectResult.length) * 100 * 100) / 100).toFixed(2).toString(),
+        (Math.round((totalCount / ballotsWeight) * 100 * 100) / 100).toFixed(2).toString(),
         totalCount,
       ]);
     });
@@ -59,16 +82,18 @@ const countSelectResult = (selectResult: number[][]) => {
 };
 
 // Count the number of votes for each candidate and returns the counts and the
-// candidate(s) with the most votes
-const countTextResult = (textResult: string[][]) => {
+// candidate(s) with the most votes. A weighted ballot counts as many votes as
+// its weight.
+const countTextResult = (textResult: string[][], weights?: number[]) => {
   const resultsInPercent: Map<string, string> = new Map();
   const results: Map<string, number> = new Map();
+  const ballotsWeight = totalWeight(weights, textResult.length);
   let max = 0;
   const maxKey: string[] = [];
 
-  textResult.forEach((result) => {
+  textResult.forEach((result, ballotIndex) => {
     result.forEach((res) => {
-      let count = 1;
+      let count = weightOf(weights, ballotIndex);
 
       if (results.has(res)) {
         count += results.get(res);
@@ -86,7 +111,7 @@ const countTextResult = (textResult: string[][]) => {
       maxKey.push(candidate);
     }
 
-    const percentage = (results.get(candidate) / textResult.length) * 100;
+    const percentage = (results.get(candidate) / ballotsWeight) * 100;
     const roundedPercentage = (Math.round(percentage * 100) / 100).toFixed(2);
     resultsInPercent.set(candidate, roundedPercentage);
   });
@@ -94,4 +119,4 @@ const countTextResult = (textResult: string[][]) => {
   return { resultsInPercent, maxKey };
 };
 
-export { countRankResult, countSelectResult, countTextResult };
+export { countRankResult, countSelectResult, countTextResult, totalWeight };
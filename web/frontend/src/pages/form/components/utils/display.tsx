@@ -1,11 +1,7 @@
 import { default as i18n } from 'i18next';
-import { urlizeLabel } from './../../../utils';
+import { Choice } from 'types/configuration';
+import { internationalizeChoice, urlizeLabel } from './../../../utils';
 
-export const prettifyChoice = (choicesMap, index) => {
-  return urlizeLabel(
-    (choicesMap.ChoicesMap.has(i18n.language)
-      ? choicesMap.ChoicesMap.get(i18n.language)
-      : choicesMap.ChoicesMap.get('en'))[index],
-    choicesMap.URLs[index]
-  );
+export const prettifyChoice = (choices: Choice[], index: number) => {
+  return urlizeLabel(internationalizeChoice(i18n.language, choices[index]), choices[index].URL);
 };
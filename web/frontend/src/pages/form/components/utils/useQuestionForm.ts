@@ -1,13 +1,15 @@
 import { useState } from 'react';
 import { RankQuestion, SelectQuestion, TextQuestion } from 'types/configuration';
 import { choicesMapToChoices } from 'types/getObjectType';
-import { availableLanguages } from 'language/Configuration';
+import { setTranslation } from 'types/langMap';
 
 // form hook that handles the form state for all types of questions
 const useQuestionForm = (initState: RankQuestion | SelectQuestion | TextQuestion) => {
   const [state, setState] = useState<RankQuestion | SelectQuestion | TextQuestion>(initState);
   const { MinN, ChoicesMap } = state;
-  const lang = availableLanguages;
+  // the choices can be translated into other languages than the ones of the
+  // editor, which must be kept aligned
+  const langs = Array.from(ChoicesMap.ChoicesMap.keys());
 
   // depending on the type of the Exception in the question, the form state is
   // updated accordingly
@@ -21,10 +23,16 @@ const useQuestionForm = (initState: RankQuestion | SelectQuestion | TextQuestion
       newChoicesMap.set('de', [...newChoicesMap.get('de'), '']);
       switch (Exception) {
         case 'Title':
-          setState({ ...state, Title: { ...state.Title, [name]: value } });
+          // the name of the input is either URL or the language of the title
+          if (name === 'URL') {
+            setState({ ...state, Title: { ...state.Title, URL: value } });
+          } else {
+            const Text = setTranslation(state.Title.Text, name, value);
+            setState({ ...state, Title: { ...state.Title, Text } });
+          }
           break;
         case 'Hint':
-          setState({ ...state, Hint: { ...state.Hint, [name]: value } });
+          setState({ ...state, Hint: setTranslation(state.Hint, name, value) });
           break;
         case 'RankMinMax':
           setState({ ...state, MinN: Number(value), MaxN: Number(value) });
@@ -46,7 +54,7 @@ const useQuestionForm = (initState: RankQuestion | SelectQuestion | TextQuestion
           });
           break;
         case 'deleteChoiceRank':
-          lang.forEach((lg) => {
+          langs.forEach((lg) => {
             const filteredChoicesMap = ChoicesMap.ChoicesMap.get(lg).filter(
               (item: string, idx: number) => idx !== optionnalValues
             );
@@ -112,7 +120,7 @@ const useQuestionForm = (initState: RankQuestion | SelectQuestion | TextQuestion
 
   // remove a choice from the ChoicesMap map
   const deleteChoice = (index: number) => {
-    lang.forEach((lg) => {
+    langs.forEach((lg) => {
       if (ChoicesMap.ChoicesMap.get(lg).length > MinN) {
         ChoicesMap.ChoicesMap.set(
           lg,
@@ -1,14 +1,14 @@
-import { Hint, Title } from 'types/configuration';
+import { Choice, LangMap } from 'types/configuration';
+import { translate } from 'types/langMap';
 
-export function internationalize(language: string, internationalizable: Hint | Title): string {
-  switch (language) {
-    case 'fr':
-      return internationalizable.Fr;
-    case 'de':
-      return internationalizable.De;
-    default:
-      return internationalizable.En;
-  }
+export function internationalize(language: string, internationalizable: LangMap): string {
+  return translate(internationalizable, language);
+}
+
+// Returns the choice in the given language, or in English if it isn't
+// translated into this language.
+export function internationalizeChoice(language: string, choice: Choice): string {
+  return translate(choice.Choice, language) || translate(choice.Choice, 'en');
 }
 
 export const urlizeLabel = (label: string, url?: string) => {
@@ -1,16 +1,31 @@
 import * as yup from 'yup';
+import { translate } from 'types/langMap';
 
 const idSchema = yup.string().min(1).required();
+// langMapSchema accepts a text valid for all languages or an object mapping a
+// language to a text
+const langMapSchema = yup.mixed().test({
+  name: 'lang-map',
+  message: 'Text should be a string or an object of translations',
+  test: (value) =>
+    value === undefined ||
+    typeof value === 'string' ||
+    (typeof value === 'object' &&
+      value !== null &&
+      Object.values(value).every((text) => typeof text === 'string')),
+});
 const titleSchema = yup.object({
-  En: yup.string().required(),
-  Fr: yup.string(),
-  De: yup.string(),
+  Text: langMapSchema.test({
+    name: 'title-en',
+    message: 'Title should have an English text',
+    test: (value) => translate(value, 'en') !== '',
+  }),
   URL: yup.string(),
 });
-const hintSchema = yup.object({
-  En: yup.string(),
-  Fr: yup.string(),
-  De: yup.string(),
+const hintSchema = langMapSchema.test({
+  name: 'hint-object',
+  message: 'Hint should be an object of translations',
+  test: (value) => typeof value !== 'string',
 });
 
 const selectsSchema = yup.object({
@@ -104,7 +119,7 @@ const selectsSchema = yup.object({
             message: `Choices array length should be at least equal to Max in selects [objectID: ${ID}]`,
           });
         }
-        if (Choices.some((choice) => choice.Choice === '')) {
+        if (Choices.some((choice) => translate(choice.Choice, 'en') === '')) {
           return this.createError({
             path,
             message: `Choices should not be empty in selects [objectID: ${ID}]`,
@@ -207,7 +222,7 @@ const ranksSchema = yup.object({
             message: `Choices array length should be equal to MaxN and MinN in ranks [objectID: ${ID}]`,
           });
         }
-        if (Choices.some((choice) => choice.Choice === '')) {
+        if (Choices.some((choice) => translate(choice.Choice, 'en') === '')) {
           return this.createError({
             path,
             message: `Choices should not be empty in ranks [objectID: ${ID}]`,
@@ -430,7 +445,7 @@ const subjectSchema = yup.object({
 const configurationSchema = yup.object({
   Title: yup.lazy(() => titleSchema),
   Scaffold: yup.array().of(subjectSchema).required(),
-  AdditionalInfo: yup.string(),
+  AdditionalInfo: langMapSchema,
 });
 
 export default configurationSchema;
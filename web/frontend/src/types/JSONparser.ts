@@ -13,18 +13,28 @@ const isJson = (str: string) => {
   }
   return true;
 };
+
+// The backend sends a title as a flat map of translations next to its URL,
+// but falls back to {Text, URL} when a language tag clashes with those keys.
+const unmarshalTitle = (title: any): types.Title => {
+  if (title === undefined || title === null) {
+    return { Text: {}, URL: '' };
+  }
+  if (title.Text !== undefined) {
+    return { Text: title.Text, URL: title.URL ?? '' };
+  }
+  const { URL, ...translations } = title;
+  return { Text: translations, URL: URL ?? '' };
+};
+
 const unmarshalText = (text: any): types.TextQuestion => {
   const t = text as types.TextQuestion;
   if (t.Hint === undefined) {
-    t.Hint = {
-      En: '',
-      Fr: '',
-      De: '',
-    };
+    t.Hint = {};
   }
   return {
     ...text,
-    Title: t.Title,
+    Title: unmarshalTitle(t.Title),
     Hint: t.Hint,
     ChoicesMap: choicesToChoicesMap(t.Choices),
     Type: TEXT,
@@ -34,15 +44,11 @@ const unmarshalText = (text: any): types.TextQuestion => {
 const unmarshalRank = (rank: any): types.RankQuestion => {
   const r = rank as types.RankQuestion;
   if (r.Hint === undefined) {
-    r.Hint = {
-      En: '',
-      Fr: '',
-      De: '',
-    };
+    r.Hint = {};
   }
   return {
     ...rank,
-    Title: r.Title,
+    Title: unmarshalTitle(r.Title),
     Hint: r.Hint,
     ChoicesMap: choicesToChoicesMap(r.Choices),
     Type: RANK,
@@ -52,15 +58,11 @@ const unmarshalRank = (rank: any): types.RankQuestion => {
 const unmarshalSelect = (select: any): types.SelectQuestion => {
   const s = select as types.SelectQuestion;
   if (s.Hint === undefined) {
-    s.Hint = {
-      En: '',
-      Fr: '',
-      De: '',
-    };
+    s.Hint = {};
   }
   return {
     ...select,
-    Title: s.Title,
+    Title: unmarshalTitle(s.Title),
     Hint: s.Hint,
     ChoicesMap: choicesToChoicesMap(s.Choices),
     Type: SELECT,
@@ -92,6 +94,7 @@ const unmarshalSubject = (subjectObj: any): types.Subject => {
 
   return {
     ...subjectObj,
+    Title: unmarshalTitle(subjectObj.Title),
     Type: SUBJECT,
     Elements: elements,
   };
@@ -146,6 +149,7 @@ const unmarshalSubjectAndCreateAnswers = (
 
   return {
     ...subjectObj,
+    Title: unmarshalTitle(subjectObj.Title),
     Type: SUBJECT,
     Elements: elements,
   };
@@ -153,7 +157,7 @@ const unmarshalSubjectAndCreateAnswers = (
 
 const unmarshalConfig = (json: any): types.Configuration => {
   const conf = {
-    Title: json.Title,
+    Title: unmarshalTitle(json.Title),
     Scaffold: [],
     AdditionalInfo: json.AdditionalInfo,
   };
@@ -174,7 +178,11 @@ const unmarshalConfigAndCreateAnswers = (
     scaffold.push(subject);
   }
 
-  const newConfiguration = { ...configObj, Scaffold: scaffold };
+  const newConfiguration = {
+    ...configObj,
+    Title: unmarshalTitle(configObj.Title),
+    Scaffold: scaffold,
+  };
 
   return { newConfiguration, newAnswers };
 };
@@ -239,5 +247,6 @@ export {
   unmarshalConfig,
   unmarshalConfigAndCreateAnswers,
   unmarshalSubjectAndCreateAnswers,
+  unmarshalTitle,
   isJson,
 };
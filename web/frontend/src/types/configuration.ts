@@ -5,26 +5,33 @@ export const SELECT: string = 'select';
 export const SUBJECT: string = 'subject';
 export const TEXT: string = 'text';
 
+// Translations maps a BCP-47 language tag to a text in this language.
+type Translations = { [lang: string]: string };
+
+// LangMap is a text that can be translated. A plain string does not depend on
+// the language, such as the name of a candidate.
+type LangMap = string | Translations;
+
 // Title
 interface Title {
-  En: string;
-  Fr: string;
-  De: string;
+  Text: LangMap;
   URL: string;
 }
 
 // Hint
-interface Hint {
-  En: string;
-  Fr: string;
-  De: string;
-}
+type Hint = Translations;
 
 // Choices
 interface Choice {
-  Choice: string;
+  Choice: LangMap;
   URL: string;
 }
+// Condition makes a question only expected if the choice at ChoiceIndex of
+// the select question SelectID has been selected.
+interface Condition {
+  SelectID: ID;
+  ChoiceIndex: number;
+}
 interface ChoicesMap {
   ChoicesMap: Map<string, string[]>;
   URLs: string[];
@@ -43,6 +50,7 @@ interface RankQuestion extends SubjectElement {
   Choices: Choice[];
   ChoicesMap: ChoicesMap;
   Hint: Hint;
+  Condition?: Condition;
 }
 // Text describes a "text" question, which allows the user to enter free text.
 interface TextQuestion extends SubjectElement {
@@ -53,6 +61,7 @@ interface TextQuestion extends SubjectElement {
   Choices: Choice[];
   ChoicesMap: ChoicesMap;
   Hint: Hint;
+  Condition?: Condition;
 }
 
 // Select describes a "select" question, which requires the user to select one
@@ -63,6 +72,7 @@ interface SelectQuestion extends SubjectElement {
   Choices: Choice[];
   ChoicesMap: ChoicesMap;
   Hint: Hint;
+  Condition?: Condition;
 }
 
 interface Subject extends SubjectElement {
@@ -74,7 +84,9 @@ interface Subject extends SubjectElement {
 interface Configuration {
   Title: Title;
   Scaffold: Subject[];
-  AdditionalInfo: string;
+  AdditionalInfo: LangMap;
+  // BCP-47 tags of the languages every element must be translated into
+  Languages?: string[];
 }
 
 // Answers describes the current answers for each type of question
@@ -88,9 +100,12 @@ interface Answers {
 
 export type {
   ID,
+  Translations,
+  LangMap,
   Title,
   Hint,
   Choice,
+  Condition,
   ChoicesMap,
   TextQuestion,
   SelectQuestion,
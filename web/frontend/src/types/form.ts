@@ -1,4 +1,4 @@
-import { ID, Title } from './configuration';
+import { ID } from './configuration';
 
 export const enum Status {
   // Initial is when the form has just been created
@@ -55,11 +55,13 @@ interface FormInfo {
   BallotSize: number;
   Configuration: any;
   Voters: string[];
+  VoterWeights?: { [userID: string]: number };
 }
 
 interface LightFormInfo {
   FormID: ID;
-  Title: Title;
+  // Title is sent flat by the backend, use unmarshalTitle to read it
+  Title: any;
   Status: Status;
   Pubkey: string;
 }
@@ -71,6 +73,8 @@ interface Results {
   RankResult: number[][];
   TextResultIDs: ID[];
   TextResult: string[][];
+  // Weight is only set in weighted forms
+  Weight?: number;
 }
 
 type SelectResults = Map<ID, number[][]>;
@@ -1,15 +1,14 @@
 import ShortUniqueId from 'short-unique-id';
 import * as types from './configuration';
 import { ID, RANK, SELECT, SUBJECT, TEXT } from './configuration';
+import { fromTranslations, getTranslation, translate } from './langMap';
 
 const uid: Function = new ShortUniqueId({ length: 8 });
 
 const emptyConfiguration = (): types.Configuration => {
   return {
     Title: {
-      En: '',
-      Fr: '',
-      De: '',
+      Text: {},
       URL: '',
     },
     Scaffold: [],
@@ -21,9 +20,7 @@ const newSubject = (): types.Subject => {
   return {
     ID: uid(),
     Title: {
-      En: '',
-      Fr: '',
-      De: '',
+      Text: {},
       URL: '',
     },
     Order: [],
@@ -36,9 +33,7 @@ const newRank = (): types.RankQuestion => {
   return {
     ID: uid(),
     Title: {
-      En: '',
-      Fr: '',
-      De: '',
+      Text: {},
       URL: '',
     },
     MaxN: 2,
@@ -46,11 +41,7 @@ const newRank = (): types.RankQuestion => {
     Choices: [],
     ChoicesMap: { ChoicesMap: new Map(Object.entries(obj)), URLs: [''] },
     Type: RANK,
-    Hint: {
-      En: '',
-      Fr: '',
-      De: '',
-    },
+    Hint: {},
   };
 };
 
@@ -58,9 +49,7 @@ const newSelect = (): types.SelectQuestion => {
   return {
     ID: uid(),
     Title: {
-      En: '',
-      Fr: '',
-      De: '',
+      Text: {},
       URL: '',
     },
     MaxN: 1,
@@ -68,11 +57,7 @@ const newSelect = (): types.SelectQuestion => {
     Choices: [],
     ChoicesMap: { ChoicesMap: new Map(Object.entries(obj)), URLs: [''] },
     Type: SELECT,
-    Hint: {
-      En: '',
-      Fr: '',
-      De: '',
-    },
+    Hint: {},
   };
 };
 
@@ -80,9 +65,7 @@ const newText = (): types.TextQuestion => {
   return {
     ID: uid(),
     Title: {
-      En: '',
-      Fr: '',
-      De: '',
+      Text: {},
       URL: '',
     },
     MaxN: 1,
@@ -92,11 +75,7 @@ const newText = (): types.TextQuestion => {
     Choices: [],
     ChoicesMap: { ChoicesMap: new Map(Object.entries(obj)), URLs: [''] },
     Type: TEXT,
-    Hint: {
-      En: '',
-      Fr: '',
-      De: '',
-    },
+    Hint: {},
   };
 };
 
@@ -119,19 +98,33 @@ const answersFrom = (answers: types.Answers): types.Answers => {
   };
 };
 
+// editorLangs are the languages of the choices in the form editor
+const editorLangs = ['en', 'fr', 'de'];
+
 const choicesToChoicesMap = (choices: types.Choice[]): types.ChoicesMap => {
   const choicesMap = { ChoicesMap: new Map<string, string[]>(), URLs: [] };
 
-  // choices is of form `{"en": "choice1", "fr": "choix1"}`
+  // the other languages of the choices are kept as they are
+  const langs = new Set(editorLangs);
   choices.forEach((choice) => {
-    const choiceObj = JSON.parse(choice.Choice) as { [key: string]: string };
-    for (const [lang, c] of Object.entries(choiceObj)) {
-      if (!choicesMap.ChoicesMap.has(lang)) {
-        choicesMap.ChoicesMap.set(lang, [c]);
-      } else {
-        choicesMap.ChoicesMap.get(lang).push(c);
-      }
+    if (typeof choice.Choice !== 'string') {
+      Object.keys(choice.Choice)
+        .filter((lang) => lang !== '' && !langs.has(lang.toLowerCase()))
+        .forEach((lang) => langs.add(lang));
     }
+  });
+
+  langs.forEach((lang) => choicesMap.ChoicesMap.set(lang, []));
+
+  // a choice is either a text valid for all languages, such as the name of a
+  // candidate, or of form `{"en": "choice1", "fr": "choix1"}`
+  choices.forEach((choice) => {
+    langs.forEach((lang) => {
+      const text = editorLangs.includes(lang)
+        ? translate(choice.Choice, lang)
+        : getTranslation(choice.Choice, lang);
+      choicesMap.ChoicesMap.get(lang).push(text);
+    });
     choicesMap.URLs.push(choice.URL);
   });
 
@@ -141,15 +134,12 @@ const choicesToChoicesMap = (choices: types.Choice[]): types.ChoicesMap => {
 const choicesMapToChoices = (ChoicesMap: types.ChoicesMap): types.Choice[] => {
   let choices: types.Choice[] = [];
   for (let i = 0; i < ChoicesMap.ChoicesMap.get('en').length; i++) {
-    const choiceMap = new Map<string, string>();
+    const translations: types.Translations = {};
     for (let key of ChoicesMap.ChoicesMap.keys()) {
-      if (ChoicesMap.ChoicesMap.get(key)[i] === '') {
-        continue;
-      }
-      choiceMap.set(key, ChoicesMap.ChoicesMap.get(key)[i]);
+      translations[key] = ChoicesMap.ChoicesMap.get(key)[i] ?? '';
     }
     choices.push({
-      Choice: JSON.stringify(Object.fromEntries(choiceMap)),
+      Choice: fromTranslations(translations),
       URL: ChoicesMap.URLs[i],
     });
   }
@@ -0,0 +1,76 @@
+import { LangMap, Translations } from './configuration';
+
+// neutralLang is the key of a text that does not depend on the language
+const neutralLang = '';
+
+// Returns the exact translation of the text in the given language, without
+// falling back to another language. Tags are compared case-insensitively, so
+// that legacy keys such as "En" match "en".
+const getTranslation = (text: LangMap | undefined, lang: string): string => {
+  if (text === undefined || typeof text === 'string') {
+    return '';
+  }
+
+  if (text[lang] !== undefined) {
+    return text[lang];
+  }
+
+  const tag = Object.keys(text).find(
+    (key) => key !== neutralLang && key.toLowerCase() === lang.toLowerCase()
+  );
+
+  return tag === undefined ? '' : text[tag];
+};
+
+// Returns the text in the given language. It falls back to the
+// language-neutral text, or an empty string if there is none, as the
+// contract does.
+const translate = (text: LangMap | undefined, lang: string): string => {
+  if (text === undefined) {
+    return '';
+  }
+
+  if (typeof text === 'string') {
+    return text;
+  }
+
+  return getTranslation(text, lang) || text[neutralLang] || '';
+};
+
+// Returns a copy of the text with the translation in the given language
+// replaced. A language-neutral text is kept as a fallback for the other
+// languages.
+const setTranslation = (text: LangMap | undefined, lang: string, value: string): Translations => {
+  let translations: Translations = {};
+
+  if (typeof text === 'string') {
+    if (text !== '') {
+      translations[neutralLang] = text;
+    }
+  } else if (text !== undefined) {
+    translations = { ...text };
+  }
+
+  const tag = Object.keys(translations).find(
+    (key) => key !== neutralLang && key.toLowerCase() === lang.toLowerCase()
+  );
+
+  translations[tag === undefined ? lang : tag] = value;
+
+  return translations;
+};
+
+// Returns the text if all the languages have the same translation, so that it
+// is encoded as a language-neutral text, or the translations otherwise. Empty
+// translations are omitted.
+const fromTranslations = (translations: Translations): LangMap => {
+  const texts = Object.values(translations);
+
+  if (texts.length > 1 && texts.every((text) => text !== '' && text === texts[0])) {
+    return texts[0];
+  }
+
+  return Object.fromEntries(Object.entries(translations).filter(([, text]) => text !== ''));
+};
+
+export { getTranslation, translate, setTranslation, fromTranslations };
@@ -59,20 +59,22 @@ test('Assert ballot is displayed properly', async ({ page }) => {
   const content = await page.getByTestId('content');
   // TODO integrate localisation
   i18n.changeLanguage('en'); // force 'en' for this test
-  await expect(content.locator('xpath=./div/div[3]/h3')).toContainText(Form.Configuration.Title.En);
+  await expect(content.locator('xpath=./div/div[3]/h3')).toContainText(
+    Form.Configuration.Title.en
+  );
   for (const [index, scaffold] of Form.Configuration.Scaffold.entries()) {
     await expect(content.locator(`xpath=./div/div[3]/div/div[${index + 1}]/h3`)).toContainText(
-      scaffold.Title.En
+      scaffold.Title.en
     );
     const select = scaffold.Selects.at(0);
     await expect(
       content.locator(`xpath=./div/div[3]/div/div[${index + 1}]/div/div/div/div[1]/div[1]/h3`)
-    ).toContainText(select.Title.En);
+    ).toContainText(select.Title.en);
     await expect(
       page.getByText(i18n.t('selectBetween', { minSelect: select.MinN, maxSelect: select.MaxN }))
     ).toBeVisible();
-    for (const choice of select.Choices.map((x) => JSON.parse(x))) {
-      await expect(page.getByRole('checkbox', { name: choice.en })).toBeVisible();
+    for (const choice of select.Choices) {
+      await expect(page.getByRole('checkbox', { name: choice.Choice.en })).toBeVisible();
     }
   }
   i18n.changeLanguage(); // unset language for the other tests
@@ -100,8 +102,8 @@ test('Assert minimum/maximum number of choices are handled correctly', async ({
     await test.step(
       `Assert maximum number of choices (${select.MaxN}) are handled correctly`,
       async () => {
-        for (const choice of select.Choices.map((x) => JSON.parse(x))) {
-          await page.getByRole('checkbox', { name: choice.en }).setChecked(true);
+        for (const choice of select.Choices) {
+          await page.getByRole('checkbox', { name: choice.Choice.en }).setChecked(true);
         }
         await castVoteButton.click();
         await expect(
@@ -134,12 +136,12 @@ test('Assert that correct number of choices are accepted', async ({ page, baseUR
   });
   await page
     .getByRole('checkbox', {
-      name: JSON.parse(Form.Configuration.Scaffold.at(0).Selects.at(0).Choices.at(0)).en,
+      name: Form.Configuration.Scaffold.at(0).Selects.at(0).Choices.at(0).Choice.en,
     })
     .setChecked(true);
   await page
     .getByRole('checkbox', {
-      name: JSON.parse(Form.Configuration.Scaffold.at(1).Selects.at(0).Choices.at(0)).en,
+      name: Form.Configuration.Scaffold.at(1).Selects.at(0).Choices.at(0).Choice.en,
     })
     .setChecked(true);
   await page.getByRole('button', { name: i18n.t('castVote') }).click();
@@ -33,19 +33,19 @@ test('Assert form titles are displayed correctly', async ({ page }) => {
   ).toBeVisible();
   const content = await page.getByTestId('content');
   await expect(content.locator('xpath=./div/div/div[2]/h3')).toContainText(
-    Form.Configuration.Title.En
+    Form.Configuration.Title.en
   );
   await expect(page.getByRole('tab', { name: i18n.t('resGroup') })).toBeVisible();
   await expect(page.getByRole('tab', { name: i18n.t('resIndiv') })).toBeVisible();
   for (const [index, scaffold] of Form.Configuration.Scaffold.entries()) {
     await expect(
       content.locator(`xpath=./div/div/div[2]/div/div[2]/div/div/div[${index + 1}]/h2`)
-    ).toContainText(scaffold.Title.En);
+    ).toContainText(scaffold.Title.en);
     await expect(
       content.locator(
         `xpath=./div/div/div[2]/div/div[2]/div/div/div[${index + 1}]/div/div/div[1]/h2`
       )
-    ).toContainText(scaffold.Selects.at(0).Title.En);
+    ).toContainText(scaffold.Selects.at(0).Title.en);
   }
 });
 
@@ -114,7 +114,7 @@ test('Assert individual results are displayed correctly', async ({ page }) => {
             j + 1
           }]`
         );
-        await expect(resultRow.locator('xpath=./div[2]')).toContainText(JSON.parse(choice).en);
+        await expect(resultRow.locator('xpath=./div[2]')).toContainText(choice.Choice.en);
         if (result.at(j)) {
           await expect(resultRow.getByRole('checkbox')).toBeChecked();
         } else {
@@ -57,15 +57,9 @@ export async function assertHasFooter(page: Page) {
   await expect(page.getByTestId('footer')).toBeVisible();
 }
 
-export function translate(internationalizable: any) {
-  switch (i18n.language) {
-    case 'en':
-      return internationalizable.En;
-    case 'fr':
-      return internationalizable.Fr || internationalizable.En;
-    case 'de':
-      return internationalizable.De || internationalizable.En;
-    default:
-      return internationalizable.En;
+export function translate(text: any) {
+  if (typeof text === 'string') {
+    return text;
   }
+  return text[i18n.language] || text.en;
 }